@@ -0,0 +1,160 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// aggregateRow is the spread of one compared metric of one algorithm over several workloads.
+type aggregateRow struct {
+	Algorithm     string  `json:"algorithm"`
+	Metric        string  `json:"metric"`
+	Workloads     int     `json:"workloads"` // the workloads the metric applies to
+	Mean          float64 `json:"mean"`
+	Median        float64 `json:"median"`
+	Worst         float64 `json:"worst"` // the highest, or the lowest of a metric where higher is better
+	WorstWorkload string  `json:"worst_workload"`
+
+	format string
+}
+
+// aggregate summarizes the results of each workload, index-aligned with workloads, into the mean,
+// median and worst case of every compared metric of each algorithm. A metric is summarized over the
+// workloads it applies to, and left out if it applies to none.
+func aggregate(workloads []string, results [][]result) []aggregateRow {
+	all := make([]result, 0)
+	for _, r := range results {
+		all = append(all, r...)
+	}
+	rows := make([]aggregateRow, 0)
+	for i := range results[0] {
+		for _, m := range resultMetrics(all) {
+			row := aggregateRow{Algorithm: results[0][i].title, Metric: m.header, format: m.format}
+			values := make([]float64, 0, len(results))
+			for w, r := range results {
+				s := r[i].schedule
+				if m.applies != nil && !m.applies(s) {
+					continue
+				}
+				v := m.value(s)
+				if len(values) == 0 || m.higherIsBetter && v < row.Worst || !m.higherIsBetter && v > row.Worst {
+					row.Worst, row.WorstWorkload = v, workloads[w]
+				}
+				values = append(values, v)
+			}
+			if len(values) == 0 {
+				continue
+			}
+			d := describe(values)
+			row.Workloads, row.Mean, row.Median = len(values), d.Mean, d.Median
+			rows = append(rows, row)
+		}
+	}
+
+	return rows
+}
+
+// aggregateHeader names the aggregate table and CSV columns.
+var aggregateHeader = []string{"Algorithm", "Metric", "Workloads", "Mean", "Median", "Worst", "Worst workload"}
+
+// aggregateFormats are the output formats of the aggregate command.
+var aggregateFormats = []string{"text", "csv", "json"}
+
+// writeAggregate writes the aggregated rows in a format: a text table in a table style, CSV with the
+// values unrounded, or JSON.
+func writeAggregate(w io.Writer, rows []aggregateRow, format, style string) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(map[string][]aggregateRow{"aggregates": rows})
+	case "csv":
+		cw := csv.NewWriter(w)
+		header := make([]string, len(aggregateHeader))
+		for i, h := range aggregateHeader {
+			header[i] = strings.ReplaceAll(strings.ToLower(h), " ", "_")
+		}
+		_ = cw.Write(header)
+		number := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+		for _, row := range rows {
+			_ = cw.Write([]string{row.Algorithm, row.Metric, strconv.Itoa(row.Workloads),
+				number(row.Mean), number(row.Median), number(row.Worst), row.WorstWorkload})
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+
+	cells := make([][]string, len(rows))
+	for i, row := range rows {
+		cells[i] = []string{row.Algorithm, row.Metric, strconv.Itoa(row.Workloads),
+			fmt.Sprintf(row.format, row.Mean), fmt.Sprintf(row.format, row.Median), fmt.Sprintf(row.format, row.Worst), row.WorstWorkload}
+	}
+	alignment := []int{tablewriter.ALIGN_LEFT, tablewriter.ALIGN_LEFT, tablewriter.ALIGN_RIGHT,
+		tablewriter.ALIGN_RIGHT, tablewriter.ALIGN_RIGHT, tablewriter.ALIGN_RIGHT, tablewriter.ALIGN_LEFT}
+	outputTable(w, style, aggregateHeader, cells, nil, alignment)
+
+	return nil
+}
+
+// aggregateCommand schedules several workloads with the selected algorithms and summarizes each
+// algorithm's metrics over all of them.
+func aggregateCommand(w io.Writer, args []string) error {
+	fs := newFlagSet("aggregate")
+	names := fs.String("algorithms", "all", "comma separated algorithms to aggregate")
+	format := fs.String("format", "text", "output format: "+strings.Join(aggregateFormats, ", "))
+	output := fs.String("o", "", "write the aggregates to this file instead of standard output")
+	tableStyle := fs.String("table-style", "ascii", "text table style: "+strings.Join(tableStyleNames(), ", "))
+	options := addOptionFlags(fs)
+	logging := addLogFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := logging(); err != nil {
+		return err
+	}
+	opts, err := options()
+	if err != nil {
+		return err
+	}
+	if _, ok := tableStyles[*tableStyle]; !ok && *tableStyle != tsvStyle {
+		return fmt.Errorf("%w: unknown table style %q", ErrInvalidArgs, *tableStyle)
+	}
+	known := false
+	for _, f := range aggregateFormats {
+		known = known || f == *format
+	}
+	if !known {
+		return fmt.Errorf("%w: unknown aggregate format %q (want %s)", ErrInvalidArgs, *format, strings.Join(aggregateFormats, ", "))
+	}
+	selected, err := selectAlgorithms(*names)
+	if err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("%w: must give the scheduling files to aggregate", ErrInvalidArgs)
+	}
+
+	results := make([][]result, fs.NArg())
+	for i, path := range fs.Args() {
+		processes, err := loadWorkload(path, opts.timeBase())
+		if err != nil {
+			return err
+		}
+		if err := validateJobs(processes, opts); err != nil {
+			return err
+		}
+		results[i] = scheduleAll(processes, opts, selected)
+	}
+	rows := aggregate(fs.Args(), results)
+	if *output == "" || *output == "-" {
+		return writeAggregate(w, rows, *format, *tableStyle)
+	}
+
+	return writeFile(*output, func(w io.Writer) error { return writeAggregate(w, rows, *format, *tableStyle) })
+}
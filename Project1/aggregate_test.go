@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func Test_aggregate(t *testing.T) {
+	t.Parallel()
+	workloads := map[string]string{
+		"a.csv": "1,4,0,1\n2,2,0,1",            // FCFS waits 0 and 4
+		"b.csv": "1,2,0,1\n2,2,0,1",            // FCFS waits 0 and 2
+		"c.csv": "1,2,0,1\n2,2,0,1,deadline:3", // FCFS waits 0 and 2, a deadline missed
+	}
+	names := []string{"a.csv", "b.csv", "c.csv"}
+	results := make([][]result, len(names))
+	for i, name := range names {
+		processes, err := loadProcesses(strings.NewReader(workloads[name]))
+		if err != nil {
+			t.Fatal(err)
+		}
+		results[i] = scheduleAll(processes, Options{}, algorithms[:1])
+	}
+	rows := make(map[string]aggregateRow)
+	for _, row := range aggregate(names, results) {
+		rows[row.Metric] = row
+	}
+
+	tests := []struct {
+		metric string
+		want   aggregateRow
+	}{
+		{metric: "Average wait", want: aggregateRow{Workloads: 3, Mean: 4.0 / 3, Median: 1, Worst: 2, WorstWorkload: "a.csv"}},
+		{metric: "Throughput", want: aggregateRow{Workloads: 3, Mean: (2.0/6 + 2.0/4 + 2.0/4) / 3, Median: 0.5, Worst: 2.0 / 6, WorstWorkload: "a.csv"}},
+		{metric: "Deadline misses", want: aggregateRow{Workloads: 1, Mean: 100, Median: 100, Worst: 100, WorstWorkload: "c.csv"}},
+	}
+	for _, tt := range tests {
+		got, ok := rows[tt.metric]
+		if !ok {
+			t.Errorf("%s: not aggregated", tt.metric)
+			continue
+		}
+		const epsilon = 1e-9
+		if got.Workloads != tt.want.Workloads || got.WorstWorkload != tt.want.WorstWorkload ||
+			got.Mean-tt.want.Mean > epsilon || tt.want.Mean-got.Mean > epsilon ||
+			got.Median != tt.want.Median || got.Worst-tt.want.Worst > epsilon || tt.want.Worst-got.Worst > epsilon {
+			t.Errorf("%s = %+v, want %+v", tt.metric, got, tt.want)
+		}
+	}
+	if _, ok := rows["Energy"]; ok {
+		t.Error("Energy aggregated, want it left out of workloads it applies to none of")
+	}
+}
+
+func Test_writeAggregate(t *testing.T) {
+	t.Parallel()
+	rows := []aggregateRow{{Algorithm: "First-come, first-serve", Metric: "Average wait", Workloads: 2, Mean: 1.5, Median: 1.5, Worst: 2, WorstWorkload: "a.csv", format: "%.2f"}}
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{format: "csv", want: "algorithm,metric,workloads,mean,median,worst,worst_workload\n\"First-come, first-serve\",Average wait,2,1.5,1.5,2,a.csv\n"},
+		{format: "text", want: "| First-come, first-serve | Average wait |         2 | 1.50 |   1.50 |  2.00 | a.csv          |"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.format, func(t *testing.T) {
+			t.Parallel()
+			var b bytes.Buffer
+			if err := writeAggregate(&b, rows, tt.format, "ascii"); err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(b.String(), tt.want) {
+				t.Errorf("writeAggregate() = %s, want it to contain %s", b.String(), tt.want)
+			}
+		})
+	}
+
+	var b bytes.Buffer
+	if err := writeAggregate(&b, rows, "json", ""); err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Aggregates []map[string]any `json:"aggregates"`
+	}
+	if err := json.Unmarshal(b.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Aggregates) != 1 || got.Aggregates[0]["worst_workload"] != "a.csv" || got.Aggregates[0]["median"] != 1.5 {
+		t.Errorf("writeAggregate() = %s, want the row as JSON", b.String())
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// starvation is a process the starvation analysis flagged, with the reason why.
+type starvation struct {
+	PID    int64
+	Reason string
+}
+
+// parseStarvation parses the starvation flags of the report, times like the workload's in the given
+// time base, into its StarvationWait and StarvationCutoff.
+func (r *Report) parseStarvation(base timeBase) error {
+	for _, field := range []struct {
+		name  string
+		value string
+		ticks *int64
+	}{
+		{name: "starvation wait", value: r.starvationWait, ticks: &r.StarvationWait},
+		{name: "starvation cutoff", value: r.starvationCutoff, ticks: &r.StarvationCutoff},
+	} {
+		if field.value == "" {
+			continue
+		}
+		t, err := parseTime(field.value, base)
+		if err != nil || t < 0 {
+			return fmt.Errorf("%w: %s %q must be a time of 0 or more", ErrInvalidArgs, field.name, field.value)
+		}
+		*field.ticks = t
+	}
+
+	return nil
+}
+
+// Starvation flags the processes that waited longer than the report's wait threshold or that
+// arrived before its cutoff but were not dispatched until after it. Both are in ticks.
+func (s Schedule) Starvation(r Report) []starvation {
+	var (
+		starved = make([]starvation, 0)
+		wait    = r.StarvationWait
+		cutoff  = r.StarvationCutoff
+	)
+	for i, p := range s.Processes {
+		if wait > 0 && s.Wait[i] > wait {
+			starved = append(starved, starvation{
+				PID:    p.ProcessID,
+				Reason: fmt.Sprintf("waited %s, over the threshold of %s", s.formatTime(s.Wait[i]), s.formatTime(wait)),
+			})
+		}
+		if cutoff > 0 && p.ArrivalTime < cutoff && s.FirstRun[i] >= cutoff {
+			starved = append(starved, starvation{
+				PID: p.ProcessID,
+				Reason: fmt.Sprintf("arrived at %s but did not run before %s (first ran at %s)",
+					s.formatTime(p.ArrivalTime), s.formatTime(cutoff), s.formatTime(s.FirstRun[i])),
+			})
+		}
+	}
+
+	return starved
+}
+
+// outputStarvation outputs a warnings section for the processes the starvation analysis flagged.
+func outputStarvation(w io.Writer, s Schedule, r Report) {
+	starved := s.Starvation(r)
+	if len(starved) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintln(w, "Starvation warnings")
+	for _, st := range starved {
+		_, _ = fmt.Fprintf(w, "  process %d %s\n", st.PID, st.Reason)
+	}
+	_, _ = fmt.Fprintln(w)
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSchedule_Starvation(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	}
+	tests := []struct {
+		name   string
+		report Report
+		want   []int64
+	}{
+		{name: "disabled", report: Report{}, want: []int64{}},
+		{name: "wait threshold", report: Report{StarvationWait: 5}, want: []int64{1, 3}},
+		{name: "cutoff", report: Report{StarvationCutoff: 10}, want: []int64{3}},
+	}
+	s := preemptivePriority(processes, Options{})
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := make([]int64, 0)
+			for _, st := range s.Starvation(tt.report) {
+				got = append(got, st.PID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Starvation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReport_parseStarvation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		report     Report
+		base       timeBase
+		wantWait   int64
+		wantCutoff int64
+		wantErr    error
+	}{
+		{name: "unset", report: Report{}},
+		{name: "time units", report: Report{starvationWait: "5", starvationCutoff: "10"}, wantWait: 5, wantCutoff: 10},
+		{name: "resolution", report: Report{starvationWait: "2.5", starvationCutoff: "3"}, base: timeBase{resolution: 10}, wantWait: 25, wantCutoff: 30},
+		{name: "duration", report: Report{starvationWait: "150ms"}, base: timeBase{unit: time.Millisecond}, wantWait: 150},
+		{name: "duration without a unit", report: Report{starvationWait: "150ms"}, wantErr: ErrInvalidArgs},
+		{name: "negative", report: Report{starvationCutoff: "-1"}, wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := tt.report
+			err := r.parseStarvation(tt.base)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (r.StarvationWait != tt.wantWait || r.StarvationCutoff != tt.wantCutoff) {
+				t.Errorf("parseStarvation() = %d, %d, want %d, %d", r.StarvationWait, r.StarvationCutoff, tt.wantWait, tt.wantCutoff)
+			}
+		})
+	}
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// simulateRequest is the JSON body of POST /simulate. Omitted algorithms select every one. With a
+// time unit such as "1ms", process times may be duration strings and results are in that unit.
+type simulateRequest struct {
+	Processes  []apiProcess `json:"processes"`
+	Algorithms []string     `json:"algorithms,omitempty"`
+	TieBreak   string       `json:"tie_break,omitempty"`
+	Seed       int64        `json:"seed,omitempty"`
+	TimeUnit   string       `json:"time_unit,omitempty"`
+}
+
+// apiProcess is a process of a simulateRequest.
+type apiProcess struct {
+	ID       int64   `json:"id"`
+	Burst    apiTime `json:"burst"`
+	Arrival  apiTime `json:"arrival"`
+	Priority int64   `json:"priority"`
+}
+
+// apiTime is a time of an apiProcess: a JSON number, or a string holding a number or a duration.
+type apiTime string
+
+func (t *apiTime) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*t = apiTime(s)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(b, &n); err != nil {
+		return fmt.Errorf("time %s is not a number or string", b)
+	}
+	*t = apiTime(n)
+
+	return nil
+}
+
+// ticks parses the time into ticks of base, an omitted time being 0.
+func (t apiTime) ticks(base timeBase) (int64, error) {
+	if t == "" {
+		return 0, nil
+	}
+
+	return parseTime(string(t), base)
+}
+
+// simulateResponse is the JSON response of POST /simulate, a result per algorithm in request order.
+type simulateResponse struct {
+	Results []apiResult `json:"results"`
+}
+
+// apiResult is the schedule of one algorithm: its headline metrics, time slices and per-process timing.
+type apiResult struct {
+	Algorithm string             `json:"algorithm"`
+	Summary   algorithmSummary   `json:"summary"`
+	Gantt     []apiSlice         `json:"gantt"`
+	Processes []apiProcessTiming `json:"processes"`
+}
+
+type apiSlice struct {
+	PID   int64 `json:"pid"`
+	Start int64 `json:"start"`
+	Stop  int64 `json:"stop"`
+}
+
+type apiProcessTiming struct {
+	ID          int64 `json:"id"`
+	Arrival     int64 `json:"arrival"`
+	Burst       int64 `json:"burst"`
+	Priority    int64 `json:"priority"`
+	Wait        int64 `json:"wait"`
+	Turnaround  int64 `json:"turnaround"`
+	Response    int64 `json:"response"`
+	Completion  int64 `json:"completion"`
+	Preemptions int   `json:"preemptions"`
+}
+
+// newSimulateRequest returns a request with the defaults of the command line flags.
+func newSimulateRequest() simulateRequest {
+	return simulateRequest{TieBreak: string(TieBreakInput), Seed: 1}
+}
+
+// parse checks a simulation request and returns the processes, algorithms and options to run.
+func (body simulateRequest) parse() ([]Process, []algorithm, Options, error) {
+	names := "all"
+	if len(body.Algorithms) > 0 {
+		names = strings.Join(body.Algorithms, ",")
+	}
+	selected, err := selectAlgorithms(names)
+	if err != nil {
+		return nil, nil, Options{}, err
+	}
+	tieBreak, err := parseTieBreak(body.TieBreak)
+	if err != nil {
+		return nil, nil, Options{}, err
+	}
+	var base timeBase
+	if body.TimeUnit != "" {
+		if base.unit, err = time.ParseDuration(body.TimeUnit); err != nil || base.unit <= 0 {
+			return nil, nil, Options{}, fmt.Errorf("%w: time unit %q is not a positive duration", ErrInvalidArgs, body.TimeUnit)
+		}
+	}
+	processes := make([]Process, len(body.Processes))
+	for i, p := range body.Processes {
+		processes[i] = Process{ProcessID: p.ID, Priority: p.Priority}
+		if processes[i].BurstDuration, err = p.Burst.ticks(base); err != nil {
+			return nil, nil, Options{}, fmt.Errorf("%w: process %d: burst: %v", ErrInvalidArgs, p.ID, err)
+		}
+		if processes[i].ArrivalTime, err = p.Arrival.ticks(base); err != nil {
+			return nil, nil, Options{}, fmt.Errorf("%w: process %d: arrival: %v", ErrInvalidArgs, p.ID, err)
+		}
+	}
+	if err := validateProcesses(processes); err != nil {
+		return nil, nil, Options{}, err
+	}
+	opts := Options{TieBreak: tieBreak, Seed: body.Seed, Unit: base.unit}
+	if err := validateJobs(processes, opts); err != nil {
+		return nil, nil, Options{}, err
+	}
+
+	return processes, selected, opts, nil
+}
+
+// newSimulateResponse converts the results of the selected algorithms into their JSON form.
+func newSimulateResponse(selected []algorithm, results []result) simulateResponse {
+	resp := simulateResponse{Results: make([]apiResult, len(results))}
+	for i, res := range results {
+		resp.Results[i] = newAPIResult(selected[i].name, res)
+	}
+
+	return resp
+}
+
+// newAPIResult converts a schedule of the named algorithm into its JSON form.
+func newAPIResult(name string, res result) apiResult {
+	s := res.schedule
+	summary := newRunSummary([]result{res}, nil).Results[0]
+	out := apiResult{
+		Algorithm: name,
+		Summary:   summary,
+		Gantt:     make([]apiSlice, len(s.Gantt)),
+		Processes: make([]apiProcessTiming, len(s.Processes)),
+	}
+	for i, ts := range s.Gantt {
+		out.Gantt[i] = apiSlice{PID: ts.PID, Start: ts.Start, Stop: ts.Stop}
+	}
+	response := s.Response()
+	preemptions := s.Preemptions()
+	for i, p := range s.Processes {
+		out.Processes[i] = apiProcessTiming{
+			ID:          p.ProcessID,
+			Arrival:     p.ArrivalTime,
+			Burst:       p.BurstDuration,
+			Priority:    p.Priority,
+			Wait:        s.Wait[i],
+			Turnaround:  s.Turnaround[i],
+			Response:    response[i],
+			Completion:  s.Completion[i],
+			Preemptions: preemptions[i],
+		}
+	}
+
+	return out
+}
+
+// writeJSON responds with v as JSON and the given status.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// handleSimulate schedules the processes of a JSON simulateRequest and responds with a
+// simulateResponse, or with {"error": ...} and a 4xx status.
+func (sv *server) handleSimulate(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "POST a simulation request"})
+		return
+	}
+	fail := func(err error) {
+		sv.metrics.request(true)
+		logs.Info("bad simulate request", "remote", req.RemoteAddr, "err", err)
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	body := newSimulateRequest()
+	decoder := json.NewDecoder(req.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&body); err != nil {
+		if bodyTooLarge(err) {
+			sv.metrics.request(true)
+			writeJSON(w, http.StatusRequestEntityTooLarge, map[string]string{"error": err.Error()})
+			return
+		}
+		fail(fmt.Errorf("%w: %v", ErrInvalidArgs, err))
+		return
+	}
+	processes, selected, opts, err := body.parse()
+	if err != nil {
+		fail(err)
+		return
+	}
+
+	results, err := sv.simulate(req.Context(), req.RemoteAddr, processes, selected, opts)
+	if err != nil {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": err.Error()})
+		return
+	}
+	logs.Info("simulate", "remote", req.RemoteAddr, "processes", len(processes), "algorithms", len(selected))
+	writeJSON(w, http.StatusOK, newSimulateResponse(selected, results))
+}
+
+// simulateJSON answers a JSON simulation request without a server, for embedding the simulator
+// (e.g. in the WebAssembly build). Errors are answered as {"error": ...} like the HTTP API does.
+func simulateJSON(data []byte) []byte {
+	var out any
+	body := newSimulateRequest()
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&body); err != nil {
+		out = map[string]string{"error": fmt.Sprintf("%v: %v", ErrInvalidArgs, err)}
+	} else if processes, selected, opts, err := body.parse(); err != nil {
+		out = map[string]string{"error": err.Error()}
+	} else {
+		out = newSimulateResponse(selected, scheduleAll(processes, opts, selected))
+	}
+	resp, _ := json.Marshal(out)
+
+	return resp
+}
+
+// renderReport schedules a workload CSV with a comma separated list of algorithms and returns the
+// report in an output format, without touching files.
+func renderReport(workload, format, names string) (string, error) {
+	if _, ok := formats[format]; !ok {
+		return "", fmt.Errorf("%w: unknown format %q", ErrInvalidArgs, format)
+	}
+	selected, err := selectAlgorithms(names)
+	if err != nil {
+		return "", err
+	}
+	processes, err := loadProcesses(strings.NewReader(workload))
+	if err != nil {
+		return "", err
+	}
+	if err := validateProcesses(processes); err != nil {
+		return "", err
+	}
+	opts := Options{TieBreak: TieBreakInput, Seed: 1}
+	if err := validateJobs(processes, opts); err != nil {
+		return "", err
+	}
+	r := Report{SlowdownBound: defaultSlowdownBound, Format: format, Compare: len(selected) > 1}
+	var b bytes.Buffer
+	if err := outputResults(&b, scheduleAll(processes, opts, selected), r); err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
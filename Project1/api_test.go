@@ -0,0 +1,213 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_server_simulate(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer((&server{metrics: newServerMetrics()}).handler())
+	t.Cleanup(srv.Close)
+
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		wantStatus int
+		wantError  string
+		want       []apiResult
+	}{
+		{
+			name:       "simulate",
+			method:     http.MethodPost,
+			body:       `{"processes": [{"id": 1, "burst": 5, "arrival": 0, "priority": 2}, {"id": 2, "burst": 2, "arrival": 1, "priority": 1}], "algorithms": ["sjf"]}`,
+			wantStatus: http.StatusOK,
+			want: []apiResult{{
+				Algorithm: "sjf",
+				Summary: algorithmSummary{
+					Algorithm:         "Shortest-job-first",
+					Processes:         2,
+					AverageWait:       1,
+					AverageTurnaround: 4.5,
+					AverageResponse:   0,
+					Makespan:          7,
+					Throughput:        2.0 / 7,
+					ContextSwitches:   2,
+				},
+				Gantt: []apiSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 3}, {PID: 1, Start: 3, Stop: 7}},
+				Processes: []apiProcessTiming{
+					{ID: 1, Burst: 5, Priority: 2, Wait: 2, Turnaround: 7, Response: 0, Completion: 7, Preemptions: 1},
+					{ID: 2, Arrival: 1, Burst: 2, Priority: 1, Wait: 0, Turnaround: 2, Response: 0, Completion: 3},
+				},
+			}},
+		},
+		{
+			name:       "durations",
+			method:     http.MethodPost,
+			body:       `{"processes": [{"id": 1, "burst": "5ms", "arrival": "0s"}, {"id": 2, "burst": "2000us", "arrival": 1}], "algorithms": ["fcfs"], "time_unit": "1ms"}`,
+			wantStatus: http.StatusOK,
+			want: []apiResult{{
+				Algorithm: "fcfs",
+				Summary: algorithmSummary{
+					Algorithm:         "First-come, first-serve",
+					Processes:         2,
+					AverageWait:       2,
+					AverageTurnaround: 5.5,
+					AverageResponse:   2,
+					Makespan:          7,
+					Throughput:        2.0 / 7,
+					ContextSwitches:   1,
+				},
+				Gantt: []apiSlice{{PID: 1, Start: 0, Stop: 5}, {PID: 2, Start: 5, Stop: 7}},
+				Processes: []apiProcessTiming{
+					{ID: 1, Burst: 5, Wait: 0, Turnaround: 5, Response: 0, Completion: 5},
+					{ID: 2, Arrival: 1, Burst: 2, Wait: 4, Turnaround: 6, Response: 4, Completion: 7},
+				},
+			}},
+		},
+		{
+			name:       "duration without time unit",
+			method:     http.MethodPost,
+			body:       `{"processes": [{"id": 1, "burst": "5ms"}]}`,
+			wantStatus: http.StatusBadRequest,
+			wantError:  "needs a time unit",
+		},
+		{
+			name:       "unknown field",
+			method:     http.MethodPost,
+			body:       `{"procs": []}`,
+			wantStatus: http.StatusBadRequest,
+			wantError:  "unknown field",
+		},
+		{
+			name:       "no processes",
+			method:     http.MethodPost,
+			body:       `{}`,
+			wantStatus: http.StatusBadRequest,
+			wantError:  "no processes",
+		},
+		{
+			name:       "invalid process",
+			method:     http.MethodPost,
+			body:       `{"processes": [{"id": 1, "burst": 0}]}`,
+			wantStatus: http.StatusBadRequest,
+			wantError:  "burst must be positive",
+		},
+		{
+			name:       "bad tie-break",
+			method:     http.MethodPost,
+			body:       `{"processes": [{"id": 1, "burst": 1}], "tie_break": "coin"}`,
+			wantStatus: http.StatusBadRequest,
+			wantError:  "tie-break",
+		},
+		{
+			name:       "body too large",
+			method:     http.MethodPost,
+			body:       `{"processes": [` + strings.Repeat(`{"id": 1, "burst": 1},`, maxRequestBody/20) + `{"id": 2, "burst": 1}]}`,
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantError:  "request body too large",
+		},
+		{
+			name:       "get",
+			method:     http.MethodGet,
+			wantStatus: http.StatusMethodNotAllowed,
+			wantError:  "POST",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req, err := http.NewRequest(tt.method, srv.URL+"/simulate", strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if tt.wantError != "" {
+				var body map[string]string
+				if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+					t.Fatal(err)
+				}
+				if !strings.Contains(body["error"], tt.wantError) {
+					t.Errorf("error = %q, want it to contain %q", body["error"], tt.wantError)
+				}
+				return
+			}
+			var got simulateResponse
+			if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got.Results, tt.want) {
+				t.Errorf("results = %+v, want %+v", got.Results, tt.want)
+			}
+		})
+	}
+}
+
+func Test_simulateJSON(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{
+			name: "simulate",
+			data: `{"processes": [{"id": 1, "burst": 2}], "algorithms": ["fcfs"]}`,
+			want: `{"results":[{"algorithm":"fcfs",`,
+		},
+		{name: "bad JSON", data: `{`, want: `{"error":"invalid args: unexpected EOF"}`},
+		{name: "bad algorithm", data: `{"processes": [{"id": 1, "burst": 2}], "algorithms": ["lottery"]}`, want: `{"error":"invalid args: unknown algorithm`},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := string(simulateJSON([]byte(tt.data))); !strings.HasPrefix(got, tt.want) {
+				t.Errorf("simulateJSON() = %s, want it to start with %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_renderReport(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		workload string
+		format   string
+		names    string
+		want     string
+		wantErr  error
+	}{
+		{name: "markdown", workload: "1,5,0,2\n2,9,3,1\n", format: "markdown", names: "fcfs,rr", want: "## Comparison"},
+		{name: "bad format", workload: "1,5,0,2\n", format: "pdf", names: "all", wantErr: ErrInvalidArgs},
+		{name: "invalid workload", workload: "1,0,0,2\n", format: "text", names: "all", wantErr: ErrValidation},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := renderReport(tt.workload, tt.format, tt.names)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("renderReport() = %s, want it to contain %q", got, tt.want)
+			}
+		})
+	}
+}
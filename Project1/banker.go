@@ -0,0 +1,276 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// bankerState is the resource allocation state the banker's algorithm checks: the free instances of
+// each resource type, and by process the instances it holds and the most it may claim.
+type bankerState struct {
+	available  []int64
+	allocation [][]int64
+	max        [][]int64
+}
+
+// need returns the instances each process may still request: its max less its allocation.
+func (s bankerState) need() [][]int64 {
+	need := make([][]int64, len(s.max))
+	for i := range s.max {
+		need[i] = make([]int64, len(s.max[i]))
+		for j := range s.max[i] {
+			need[i][j] = s.max[i][j] - s.allocation[i][j]
+		}
+	}
+
+	return need
+}
+
+// validate checks every matrix row has an entry per resource type, no count is negative and no
+// process holds more than its max.
+func (s bankerState) validate() error {
+	if len(s.available) == 0 {
+		return fmt.Errorf("%w: no [available] resources", ErrValidation)
+	}
+	if len(s.allocation) == 0 {
+		return fmt.Errorf("%w: no processes", ErrValidation)
+	}
+	if len(s.allocation) != len(s.max) {
+		return fmt.Errorf("%w: %d [allocation] rows but %d [max] rows", ErrValidation, len(s.allocation), len(s.max))
+	}
+	for j, n := range s.available {
+		if n < 0 {
+			return fmt.Errorf("%w: available %s is negative", ErrValidation, resourceName(j))
+		}
+	}
+	for i := range s.allocation {
+		if len(s.allocation[i]) != len(s.available) || len(s.max[i]) != len(s.available) {
+			return fmt.Errorf("%w: P%d: want %d resources", ErrValidation, i, len(s.available))
+		}
+		for j := range s.available {
+			if s.allocation[i][j] < 0 || s.max[i][j] < 0 {
+				return fmt.Errorf("%w: P%d: %s is negative", ErrValidation, i, resourceName(j))
+			}
+			if s.allocation[i][j] > s.max[i][j] {
+				return fmt.Errorf("%w: P%d holds %d %s, more than its max %d", ErrValidation, i, s.allocation[i][j], resourceName(j), s.max[i][j])
+			}
+		}
+	}
+
+	return nil
+}
+
+// bankerStep is one process the safety algorithm lets finish: the work available before, and after
+// the process releases its allocation.
+type bankerStep struct {
+	process int
+	work    []int64
+	after   []int64
+}
+
+// safeSequence runs the safety algorithm: it repeatedly passes over the processes in order, letting
+// each unfinished one whose need the work available covers finish and release its allocation. The
+// state is safe when every process finishes; otherwise the unfinished processes are returned too.
+func (s bankerState) safeSequence() (steps []bankerStep, unfinished []int) {
+	var (
+		need     = s.need()
+		work     = append([]int64(nil), s.available...)
+		finished = make([]bool, len(s.allocation))
+	)
+	for progress := true; progress; {
+		progress = false
+		for i := range s.allocation {
+			if finished[i] || !covers(work, need[i]) {
+				continue
+			}
+			step := bankerStep{process: i, work: work, after: make([]int64, len(work))}
+			for j := range work {
+				step.after[j] = work[j] + s.allocation[i][j]
+			}
+			steps = append(steps, step)
+			work = step.after
+			finished[i] = true
+			progress = true
+		}
+	}
+	for i, done := range finished {
+		if !done {
+			unfinished = append(unfinished, i)
+		}
+	}
+
+	return steps, unfinished
+}
+
+// covers reports whether every entry of have is at least that of want.
+func covers(have, want []int64) bool {
+	for j := range want {
+		if want[j] > have[j] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// resourceName names the resource types A, B, C, ... in order.
+func resourceName(j int) string {
+	if j < 26 {
+		return string(rune('A' + j))
+	}
+
+	return fmt.Sprintf("R%d", j)
+}
+
+func formatVector(v []int64) string {
+	s := make([]string, len(v))
+	for i, n := range v {
+		s[i] = fmt.Sprint(n)
+	}
+
+	return strings.Join(s, " ")
+}
+
+// loadBankerState reads a banker's state file: an "[available]" section with one row of instances
+// per resource type, then "[allocation]" and "[max]" sections with a row per process, in order.
+// Entries are separated by commas or white space. Blank lines and lines starting with # are ignored.
+func loadBankerState(r io.Reader) (bankerState, error) {
+	var (
+		s       bankerState
+		section string
+		scanner = bufio.NewScanner(r)
+	)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		switch {
+		case text == "" || strings.HasPrefix(text, "#"):
+		case strings.HasPrefix(text, "[") && strings.HasSuffix(text, "]"):
+			section = strings.TrimSpace(text[1 : len(text)-1])
+			if section != "available" && section != "allocation" && section != "max" {
+				return bankerState{}, fmt.Errorf("%w: line %d: unknown section [%s] (want available, allocation or max)", ErrInvalidArgs, line, section)
+			}
+		default:
+			fields := strings.FieldsFunc(text, func(c rune) bool { return c == ',' || c == ' ' || c == '\t' })
+			row := make([]int64, len(fields))
+			for i, field := range fields {
+				n, err := strconv.ParseInt(field, 10, 64)
+				if err != nil {
+					return bankerState{}, fmt.Errorf("%w: line %d: %v", ErrInvalidArgs, line, err)
+				}
+				row[i] = n
+			}
+			switch section {
+			case "available":
+				if s.available != nil {
+					return bankerState{}, fmt.Errorf("%w: line %d: more than one [available] row", ErrInvalidArgs, line)
+				}
+				s.available = row
+			case "allocation":
+				s.allocation = append(s.allocation, row)
+			case "max":
+				s.max = append(s.max, row)
+			default:
+				return bankerState{}, fmt.Errorf("%w: line %d: row outside a section", ErrInvalidArgs, line)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return bankerState{}, fmt.Errorf("%w: reading banker's state", err)
+	}
+
+	return s, nil
+}
+
+// outputBankerState outputs each process's allocation, max and need, and the resources available.
+func outputBankerState(w io.Writer, s bankerState, style string) {
+	outputTitle(w, "Resource allocation state")
+	need := s.need()
+	rows := make([][]string, len(s.allocation))
+	for i := range s.allocation {
+		rows[i] = []string{fmt.Sprintf("P%d", i), formatVector(s.allocation[i]), formatVector(s.max[i]), formatVector(need[i])}
+	}
+	names := make([]string, len(s.available))
+	for j := range names {
+		names[j] = resourceName(j)
+	}
+	resources := " (" + strings.Join(names, " ") + ")"
+	header := []string{"Process", "Allocation" + resources, "Max" + resources, "Need" + resources}
+	outputTable(w, style, header, rows, nil, nil)
+	_, _ = fmt.Fprintf(w, "Available%s: %s\n\n", resources, formatVector(s.available))
+}
+
+// outputSafety outputs the steps of the safety algorithm and the safe sequence, or the processes
+// that cannot finish when the state is unsafe.
+func outputSafety(w io.Writer, s bankerState, steps []bankerStep, unfinished []int, style string) {
+	_, _ = fmt.Fprintln(w, "Safety algorithm")
+	need := s.need()
+	rows := make([][]string, len(steps))
+	sequence := make([]string, len(steps))
+	for i, step := range steps {
+		sequence[i] = fmt.Sprintf("P%d", step.process)
+		rows[i] = []string{
+			fmt.Sprint(i + 1),
+			sequence[i],
+			formatVector(step.work),
+			formatVector(need[step.process]),
+			formatVector(step.after),
+		}
+	}
+	outputTable(w, style, []string{"Step", "Process", "Work", "Need", "Work after release"}, rows, nil, nil)
+
+	if len(unfinished) == 0 {
+		_, _ = fmt.Fprintf(w, "Safe: safe sequence <%s>\n", strings.Join(sequence, ", "))
+		return
+	}
+	blocked := make([]string, len(unfinished))
+	for i, p := range unfinished {
+		blocked[i] = fmt.Sprintf("P%d", p)
+	}
+	work := s.available
+	if len(steps) > 0 {
+		work = steps[len(steps)-1].after
+	}
+	_, _ = fmt.Fprintf(w, "Unsafe: no safe sequence; %s cannot finish with %s available\n", strings.Join(blocked, ", "), formatVector(work))
+}
+
+// bankerCommand checks whether the resource allocation state in a file is safe with the banker's algorithm.
+func bankerCommand(w io.Writer, args []string) error {
+	fs := newFlagSet("banker")
+	tableStyle := fs.String("table-style", "ascii", "text table style: "+strings.Join(tableStyleNames(), ", "))
+	logging := addLogFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := logging(); err != nil {
+		return err
+	}
+	if _, ok := tableStyles[*tableStyle]; !ok && *tableStyle != tsvStyle {
+		return fmt.Errorf("%w: unknown table style %q", ErrInvalidArgs, *tableStyle)
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: must give a banker's state file", ErrInvalidArgs)
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("%v: error opening banker's state file", err)
+	}
+	defer f.Close()
+	s, err := loadBankerState(f)
+	if err != nil {
+		return err
+	}
+	if err := s.validate(); err != nil {
+		return err
+	}
+
+	outputBankerState(w, s, *tableStyle)
+	steps, unfinished := s.safeSequence()
+	outputSafety(w, s, steps, unfinished, *tableStyle)
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// textbookState is the textbook example of five processes and three resource types.
+const textbookState = `# five processes, resources A B C
+[available]
+3 3 2
+[allocation]
+0,1,0
+2,0,0
+3,0,2
+2,1,1
+0,0,2
+[max]
+7 5 3
+3 2 2
+9 0 2
+2 2 2
+4 3 3
+`
+
+func Test_safeSequence(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		available      []int64
+		wantSequence   []int
+		wantUnfinished []int
+	}{
+		{name: "safe", available: []int64{3, 3, 2}, wantSequence: []int{1, 3, 4, 0, 2}},
+		{name: "unsafe", available: []int64{0, 1, 1}, wantSequence: []int{3, 1}, wantUnfinished: []int{0, 2, 4}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s, err := loadBankerState(strings.NewReader(textbookState))
+			if err != nil {
+				t.Fatal(err)
+			}
+			s.available = tt.available
+			steps, unfinished := s.safeSequence()
+			sequence := make([]int, len(steps))
+			for i, step := range steps {
+				sequence[i] = step.process
+			}
+			if !reflect.DeepEqual(sequence, tt.wantSequence) {
+				t.Errorf("sequence = %v, want %v", sequence, tt.wantSequence)
+			}
+			if !reflect.DeepEqual(unfinished, tt.wantUnfinished) {
+				t.Errorf("unfinished = %v, want %v", unfinished, tt.wantUnfinished)
+			}
+		})
+	}
+}
+
+func Test_loadBankerState(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+	}{
+		{name: "textbook", input: textbookState},
+		{name: "unknown section", input: "[need]\n1 2\n", wantErr: ErrInvalidArgs},
+		{name: "row outside a section", input: "1 2\n", wantErr: ErrInvalidArgs},
+		{name: "bad number", input: "[available]\n1 x\n", wantErr: ErrInvalidArgs},
+		{name: "two available rows", input: "[available]\n1 2\n3 4\n", wantErr: ErrInvalidArgs},
+		{name: "allocation over max", input: "[available]\n1\n[allocation]\n3\n[max]\n2\n", wantErr: ErrValidation},
+		{name: "missing max", input: "[available]\n1\n[allocation]\n0\n", wantErr: ErrValidation},
+		{name: "short row", input: "[available]\n1 1\n[allocation]\n0\n[max]\n1 1\n", wantErr: ErrValidation},
+		{name: "negative", input: "[available]\n-1\n[allocation]\n0\n[max]\n1\n", wantErr: ErrValidation},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s, err := loadBankerState(strings.NewReader(tt.input))
+			if err == nil {
+				err = s.validate()
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_bankerCommand(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	safe := filepath.Join(dir, "safe.txt")
+	unsafe := filepath.Join(dir, "unsafe.txt")
+	if err := os.WriteFile(safe, []byte(textbookState), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(unsafe, []byte(strings.Replace(textbookState, "3 3 2", "0 1 1", 1)), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		args    []string
+		want    []string
+		wantErr error
+	}{
+		{name: "safe", args: []string{safe}, want: []string{"| P1      | 2 0 0", "Safe: safe sequence <P1, P3, P4, P0, P2>"}},
+		{name: "unsafe", args: []string{unsafe}, want: []string{"Unsafe: no safe sequence; P0, P2, P4 cannot finish with 4 2 2 available"}},
+		{name: "no file", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			err := bankerCommand(&w, tt.args)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(w.String(), want) {
+					t.Errorf("bankerCommand() = %s, want it to contain %q", w.String(), want)
+				}
+			}
+		})
+	}
+}
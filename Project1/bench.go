@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// benchResult is one algorithm's measured performance on one workload size.
+type benchResult struct {
+	algorithm   string
+	processes   int
+	runs        int
+	nsPerRun    float64
+	allocsPerOp float64
+	bytesPerOp  float64
+}
+
+// ProcessesPerSecond returns how many processes the scheduler simulates per wall clock second.
+func (r benchResult) ProcessesPerSecond() float64 {
+	return float64(r.processes) / (r.nsPerRun / float64(time.Second))
+}
+
+// benchmark times an algorithm on a workload, repeating it until at least minTime has passed.
+func benchmark(a algorithm, processes []Process, opts Options, minTime time.Duration) benchResult {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	runs := 0
+	start := time.Now()
+	for runs == 0 || time.Since(start) < minTime {
+		a.run(processes, opts)
+		runs++
+	}
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	return benchResult{
+		algorithm:   a.name,
+		processes:   len(processes),
+		runs:        runs,
+		nsPerRun:    float64(elapsed.Nanoseconds()) / float64(runs),
+		allocsPerOp: float64(after.Mallocs-before.Mallocs) / float64(runs),
+		bytesPerOp:  float64(after.TotalAlloc-before.TotalAlloc) / float64(runs),
+	}
+}
+
+// outputBenchmarks outputs a table of benchmark results.
+func outputBenchmarks(w io.Writer, results []benchResult) {
+	outputTitle(w, "Benchmark")
+	rows := make([][]string, len(results))
+	for i, r := range results {
+		rows[i] = []string{
+			r.algorithm,
+			fmt.Sprint(r.processes),
+			fmt.Sprint(r.runs),
+			fmt.Sprintf("%.0f", r.nsPerRun),
+			fmt.Sprintf("%.0f", r.ProcessesPerSecond()),
+			fmt.Sprintf("%.0f", r.allocsPerOp),
+			fmt.Sprintf("%.0f", r.bytesPerOp),
+		}
+	}
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Algorithm", "Processes", "Runs", "ns/run", "Processes/s", "Allocs/run", "Bytes/run"})
+	table.AppendBulk(rows)
+	table.Render()
+}
+
+// benchCommand times every selected scheduler on generated workloads of increasing size.
+func benchCommand(w io.Writer, args []string) error {
+	fs := newFlagSet("bench")
+	sizes := fs.String("sizes", "100,200,400,800", "comma separated workload sizes")
+	names := fs.String("algorithms", "all", "comma separated algorithms to benchmark")
+	minTime := fs.Duration("time", 200*time.Millisecond, "minimum time to run each benchmark for")
+	options := addOptionFlags(fs)
+	logging := addLogFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := logging(); err != nil {
+		return err
+	}
+	opts, err := options()
+	if err != nil {
+		return err
+	}
+	selected, err := selectAlgorithms(*names)
+	if err != nil {
+		return err
+	}
+
+	arrival, _ := parseDistribution("poisson:0.5")
+	burst, _ := parseDistribution("exp:5")
+	priority, _ := parseDistribution("uniform:1-5")
+
+	results := make([]benchResult, 0)
+	for _, field := range strings.Split(*sizes, ",") {
+		size, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || size <= 0 {
+			return fmt.Errorf("%w: workload size %q", ErrInvalidArgs, field)
+		}
+		processes := generateProcesses(size, arrival, burst, priority, opts.Seed)
+		for _, a := range selected {
+			results = append(results, benchmark(a, processes, opts, *minTime))
+		}
+	}
+	outputBenchmarks(w, results)
+
+	return nil
+}
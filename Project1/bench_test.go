@@ -0,0 +1,35 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_benchmark(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+	}
+	for _, a := range algorithms {
+		got := benchmark(a, processes, Options{}, 0)
+		if got.runs != 1 || got.processes != 2 || got.algorithm != a.name || got.ProcessesPerSecond() <= 0 {
+			t.Errorf("benchmark(%s) = %+v", a.name, got)
+		}
+	}
+}
+
+func BenchmarkSchedulers(b *testing.B) {
+	arrival, _ := parseDistribution("poisson:0.5")
+	burst, _ := parseDistribution("exp:5")
+	priority, _ := parseDistribution("uniform:1-5")
+	processes := generateProcesses(200, arrival, burst, priority, 1)
+	for _, a := range algorithms {
+		a := a
+		b.Run(a.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				a.run(processes, Options{})
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+)
+
+// Column indexes and event types of the Google Borg cluster trace task_events table.
+// See https://github.com/google/cluster-data for the full schema.
+const (
+	borgTimestamp = 0
+	borgJobID     = 2
+	borgTaskIndex = 3
+	borgEventType = 5
+	borgPriority  = 8
+
+	borgSubmit   = 0
+	borgSchedule = 1
+	borgEvict    = 2
+	borgFail     = 3
+	borgFinish   = 4
+	borgKill     = 5
+	borgLost     = 6
+)
+
+type borgTask struct {
+	submit   int64
+	started  int64
+	running  bool
+	runtime  int64
+	priority int64
+	finished bool
+}
+
+// loadBorgTaskEvents converts a Borg task_events trace into processes given:
+// • a reader of the (headerless) task_events CSV
+// • the number of trace microseconds that make up one scheduler time unit
+// Submit time maps to arrival (relative to the first submission), total running time maps to burst
+// and the Borg priority is carried over as-is. Tasks that never finish are dropped.
+func loadBorgTaskEvents(r io.Reader, unit int64) ([]Process, error) {
+	if unit <= 0 {
+		return nil, fmt.Errorf("%w: time unit must be positive", ErrInvalidArgs)
+	}
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	var (
+		tasks = make(map[string]*borgTask)
+		order []string
+	)
+	for line := 1; ; line++ {
+		row, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: reading task events", err)
+		}
+		if len(row) <= borgPriority {
+			return nil, fmt.Errorf("%w: line %d: expected at least %d columns", ErrInvalidArgs, line, borgPriority+1)
+		}
+		ts, err := strconv.ParseInt(row[borgTimestamp], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: timestamp: %v", ErrInvalidArgs, line, err)
+		}
+		event, err := strconv.Atoi(row[borgEventType])
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: event type: %v", ErrInvalidArgs, line, err)
+		}
+
+		key := row[borgJobID] + "/" + row[borgTaskIndex]
+		task, ok := tasks[key]
+		if !ok {
+			task = &borgTask{submit: -1}
+			tasks[key] = task
+			order = append(order, key)
+		}
+
+		switch event {
+		case borgSubmit:
+			if task.submit < 0 {
+				task.submit = ts
+			}
+			if priority, err := strconv.ParseInt(row[borgPriority], 10, 64); err == nil {
+				task.priority = priority
+			}
+		case borgSchedule:
+			task.started = ts
+			task.running = true
+		case borgEvict, borgFail, borgFinish, borgKill, borgLost:
+			if task.running {
+				task.runtime += ts - task.started
+				task.running = false
+			}
+			task.finished = event == borgFinish
+		}
+	}
+
+	var finished []*borgTask
+	for _, key := range order {
+		if t := tasks[key]; t.finished && t.submit >= 0 {
+			finished = append(finished, t)
+		}
+	}
+	sort.SliceStable(finished, func(i, j int) bool {
+		return finished[i].submit < finished[j].submit
+	})
+
+	processes := make([]Process, len(finished))
+	for i, t := range finished {
+		burst := (t.runtime + unit - 1) / unit
+		if burst < 1 {
+			burst = 1
+		}
+		processes[i] = Process{
+			ProcessID:     int64(i + 1),
+			ArrivalTime:   (t.submit - finished[0].submit) / unit,
+			BurstDuration: burst,
+			Priority:      t.priority,
+		}
+	}
+
+	return processes, nil
+}
+
+// writeProcesses writes processes in the same CSV layout loadProcesses reads.
+func writeProcesses(w io.Writer, processes []Process) error {
+	cw := csv.NewWriter(w)
+	for i := range processes {
+		if err := cw.Write([]string{
+			fmt.Sprint(processes[i].ProcessID),
+			fmt.Sprint(processes[i].BurstDuration),
+			fmt.Sprint(processes[i].ArrivalTime),
+			fmt.Sprint(processes[i].Priority),
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// borgCommand converts a Borg task_events trace file into a workload CSV written to w.
+func borgCommand(w io.Writer, args []string) error {
+	fs := newFlagSet("import-borg")
+	unit := fs.Int64("unit", 1_000_000, "trace microseconds per time unit")
+	logging := addLogFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := logging(); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: must give a task_events file to import", ErrInvalidArgs)
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("%v: error opening trace file", err)
+	}
+	defer f.Close()
+
+	processes, err := loadBorgTaskEvents(f, *unit)
+	if err != nil {
+		return err
+	}
+
+	return writeProcesses(w, processes)
+}
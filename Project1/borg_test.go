@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func Test_loadBorgTaskEvents(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		r    io.Reader
+		unit int64
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []Process
+		wantErr error
+	}{
+		{
+			name: "bad CSV",
+			args: args{
+				r:    iotest.ErrReader(io.ErrUnexpectedEOF),
+				unit: 1,
+			},
+			wantErr: io.ErrUnexpectedEOF,
+		},
+		{
+			name: "bad unit",
+			args: args{
+				r: strings.NewReader(""),
+			},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name: "too few columns",
+			args: args{
+				r:    strings.NewReader("0,,1,0,,0"),
+				unit: 1,
+			},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name: "bad timestamp",
+			args: args{
+				r:    strings.NewReader("soon,,10,0,,0,u,0,9,,,,"),
+				unit: 1,
+			},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name: "bad event type",
+			args: args{
+				r:    strings.NewReader("5000000,,10,0,,start,u,0,9,,,,"),
+				unit: 1,
+			},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name: "success",
+			args: args{
+				r: strings.NewReader(`5000000,,10,0,,0,u,0,9,,,,
+6000000,,10,1,,0,u,0,2,,,,
+7000000,,10,0,,1,u,0,9,,,,
+8000000,,10,1,,1,u,0,2,,,,
+9000000,,11,0,,0,u,0,4,,,,
+10000000,,10,0,,2,u,0,9,,,,
+12000000,,10,0,,1,u,0,9,,,,
+15000000,,10,1,,4,u,0,2,,,,
+16000000,,10,0,,4,u,0,9,,,,
+`),
+				unit: 1_000_000,
+			},
+			want: []Process{
+				{
+					ProcessID:     1,
+					ArrivalTime:   0,
+					BurstDuration: 7,
+					Priority:      9,
+				},
+				{
+					ProcessID:     2,
+					ArrivalTime:   1,
+					BurstDuration: 7,
+					Priority:      2,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := loadBorgTaskEvents(tt.args.r, tt.args.unit)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadBorgTaskEvents() = %v, want %v", got, tt.want)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_writeProcesses(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+	}
+	var w bytes.Buffer
+	if err := writeProcesses(&w, processes); err != nil {
+		t.Fatal(err)
+	}
+	got, err := loadProcesses(&w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, processes) {
+		t.Errorf("round trip = %v, want %v", got, processes)
+	}
+}
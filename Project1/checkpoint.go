@@ -0,0 +1,142 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// checkpointState is the content of a checkpoint file: the schedules completed so far, with the
+// fingerprint of the workload and options they were made from.
+type checkpointState struct {
+	Fingerprint string            `json:"fingerprint"`
+	Done        []checkpointEntry `json:"done"`
+}
+
+// checkpointEntry is the schedule of one algorithm in one run.
+type checkpointEntry struct {
+	Run       int      `json:"run"`
+	Algorithm string   `json:"algorithm"`
+	Schedule  Schedule `json:"schedule"`
+}
+
+// checkpointer saves every schedule as it completes to a checkpoint file, and on resuming takes the
+// schedules the file already holds instead of simulating them again. It saves nothing of a schedule
+// still being simulated, which an interruption loses. A nil checkpointer saves nothing.
+type checkpointer struct {
+	path  string
+	state checkpointState
+	done  map[string]Schedule
+}
+
+// fingerprint identifies the processes and the options scheduling them, so a checkpoint is only
+// resumed for the run it was written by.
+func fingerprint(processes []Process, opts Options) string {
+	data, _ := json.Marshal(processes)
+	opts.Progress, opts.progress = nil, nil // where progress goes does not change the schedules
+	opts.GanttStream, opts.gantt, opts.ctx = nil, nil, nil
+	h := sha256.New()
+	_, _ = h.Write(data)
+	_, _ = fmt.Fprintf(h, "%+v", opts)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// openCheckpoint opens the checkpoint file at path for scheduling the processes under the options,
+// resuming from it if it exists. A checkpoint of a different workload or options is an error, rather
+// than silently mixing schedules of both.
+func openCheckpoint(path string, processes []Process, opts Options) (*checkpointer, error) {
+	cp := &checkpointer{
+		path:  path,
+		state: checkpointState{Fingerprint: fingerprint(processes, opts)},
+		done:  make(map[string]Schedule),
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cp, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%v: error opening checkpoint", err)
+	}
+	var saved checkpointState
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, fmt.Errorf("%w: checkpoint %s: %v", ErrInvalidArgs, path, err)
+	}
+	if saved.Fingerprint != cp.state.Fingerprint {
+		return nil, fmt.Errorf("%w: checkpoint %s is of another workload or other options; remove it to start over", ErrInvalidArgs, path)
+	}
+	cp.state = saved
+	for _, e := range saved.Done {
+		cp.done[checkpointKey(e.Run, e.Algorithm)] = e.Schedule
+	}
+	logs.Info("resuming from checkpoint", "file", path, "schedules", len(saved.Done))
+
+	return cp, nil
+}
+
+func checkpointKey(run int, algorithm string) string {
+	return fmt.Sprintf("%d/%s", run, algorithm)
+}
+
+// save writes the checkpoint file, through a temporary file so an interruption leaves the previous
+// checkpoint whole.
+func (cp *checkpointer) save() error {
+	data, err := json.Marshal(cp.state)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(cp.path), filepath.Base(cp.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), cp.path)
+}
+
+// scheduleAll runs each algorithm over the processes like the package's scheduleAll, taking the
+// schedules of run the checkpoint holds and saving the others as they complete.
+func (cp *checkpointer) scheduleAll(run int, processes []Process, opts Options, algs []algorithm) ([]result, error) {
+	if cp == nil {
+		return scheduleAll(processes, opts, algs), nil
+	}
+	results := make([]result, len(algs))
+	for i, a := range algs {
+		if s, ok := cp.done[checkpointKey(run, a.name)]; ok {
+			results[i] = result{title: a.title, schedule: s}
+			continue
+		}
+		results[i] = scheduleAll(processes, opts, []algorithm{a})[0]
+		cp.done[checkpointKey(run, a.name)] = results[i].schedule
+		cp.state.Done = append(cp.state.Done, checkpointEntry{Run: run, Algorithm: a.name, Schedule: results[i].schedule})
+		if err := cp.save(); err != nil {
+			return nil, fmt.Errorf("%v: error writing checkpoint", err)
+		}
+	}
+
+	return results, nil
+}
+
+// finish removes the checkpoint file once every schedule it was for has completed.
+func (cp *checkpointer) finish() error {
+	if cp == nil {
+		return nil
+	}
+	if err := os.Remove(cp.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func Test_checkpointer_resume(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "run.checkpoint")
+	processes := []Process{{ProcessID: 1, BurstDuration: 5, Priority: 2}, {ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1}}
+	opts := Options{Seed: 1}
+
+	cp, err := openCheckpoint(path, processes, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := cp.scheduleAll(0, processes, opts, algorithms[:2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	// An interrupted run leaves the checkpoint behind; the resumed one takes its schedules.
+	resumed, err := openCheckpoint(path, processes, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resumed.done) != 2 {
+		t.Fatalf("resumed %d schedules, want 2", len(resumed.done))
+	}
+	for i, res := range first {
+		got := resumed.done[checkpointKey(0, algorithms[i].name)]
+		if !reflect.DeepEqual(got.Gantt, res.schedule.Gantt) || !reflect.DeepEqual(got.Wait, res.schedule.Wait) {
+			t.Errorf("%s: resumed schedule %+v, want %+v", algorithms[i].name, got, res.schedule)
+		}
+	}
+	resumed.done[checkpointKey(0, "fcfs")] = Schedule{Quantum: 42}
+	all, err := resumed.scheduleAll(0, processes, opts, algorithms[:3])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if all[0].schedule.Quantum != 42 {
+		t.Errorf("fcfs was scheduled again instead of resumed")
+	}
+	if !reflect.DeepEqual(all[2].schedule.Completion, algorithms[2].schedule(processes, opts).Completion) {
+		t.Errorf("priority = %v, want it scheduled", all[2].schedule.Completion)
+	}
+
+	if _, err := openCheckpoint(path, processes, Options{Seed: 2}); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("openCheckpoint() with other options error = %v, want %v", err, ErrInvalidArgs)
+	}
+	if err := resumed.finish(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("finish() left the checkpoint: %v", err)
+	}
+	if err := (*checkpointer)(nil).finish(); err != nil {
+		t.Errorf("nil finish() = %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Job classes tag processes with the kind of work they do, highest class first. Untagged processes
+// count as interactive.
+const (
+	classSystem      = "system"
+	classInteractive = "interactive"
+	classBatch       = "batch"
+)
+
+// jobClasses are the job classes, highest first.
+var jobClasses = []string{classSystem, classInteractive, classBatch}
+
+// classPrefix starts the optional class column of a workload row, "class:batch".
+const classPrefix = "class:"
+
+// parseClass validates a job class name.
+func parseClass(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	for _, class := range jobClasses {
+		if name == class {
+			return class, nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown class %q (want %s)", name, strings.Join(jobClasses, ", "))
+}
+
+// classOf returns the class of a process, interactive when untagged.
+func classOf(p Process) string {
+	if p.Class == "" {
+		return classInteractive
+	}
+
+	return p.Class
+}
+
+// classRank ranks the class of a process; the lower rank is the higher class.
+func classRank(p Process) int {
+	for i, class := range jobClasses {
+		if classOf(p) == class {
+			return i
+		}
+	}
+
+	return len(jobClasses)
+}
+
+// ClassPolicy names how the job classes share the CPU.
+type ClassPolicy string
+
+const (
+	ClassPolicyShared ClassPolicy = "shared" // classes compete on the scheduler's own terms
+	ClassPolicyStrict ClassPolicy = "strict" // a class only runs when no process of a higher class is ready
+)
+
+// classPolicies are the class policies, the default first.
+var classPolicies = []ClassPolicy{ClassPolicyShared, ClassPolicyStrict}
+
+// parseClassPolicy validates a class policy name.
+func parseClassPolicy(name string) (ClassPolicy, error) {
+	for _, policy := range classPolicies {
+		if ClassPolicy(name) == policy {
+			return policy, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: unknown class policy %q", ErrInvalidArgs, name)
+}
+
+func classPolicyNames() []string {
+	names := make([]string, len(classPolicies))
+	for i, policy := range classPolicies {
+		names[i] = string(policy)
+	}
+
+	return names
+}
+
+// classSummary formats the average wait, turnaround and response time of each job class in the
+// schedule, one line per class, or nothing when no process is tagged with a class.
+func (s Schedule) classSummary() []string {
+	tagged := false
+	for _, p := range s.Processes {
+		tagged = tagged || p.Class != ""
+	}
+	if !tagged {
+		return nil
+	}
+
+	response := s.Response()
+	lines := make([]string, 0, len(jobClasses))
+	for _, class := range jobClasses {
+		var wait, turnaround, resp []int64
+		for i, p := range s.Processes {
+			if classOf(p) == class {
+				wait = append(wait, s.Wait[i])
+				turnaround = append(turnaround, s.Turnaround[i])
+				resp = append(resp, response[i])
+			}
+		}
+		if len(wait) == 0 {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("Class %s: %d processes, average wait %s, turnaround %s, response %s",
+			class, len(wait), s.formatUnits(s.inUnits(average(wait))), s.formatUnits(s.inUnits(average(turnaround))),
+			s.formatUnits(s.inUnits(average(resp)))))
+	}
+
+	return lines
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_loadProcesses_class(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		workload string
+		want     []string
+		wantDeps []int64
+		wantErr  bool
+	}{
+		{name: "untagged", workload: "1,5,0,2", want: []string{""}},
+		{name: "class", workload: "1,5,0,2,class:batch", want: []string{classBatch}},
+		{name: "class and deps", workload: "1,5,0,2\n2,5,0,2,class:system,deps:1", want: []string{"", classSystem}, wantDeps: []int64{1}},
+		{name: "deps and class", workload: "1,5,0,2\n2,5,0,2,deps:1,class:system", want: []string{"", classSystem}, wantDeps: []int64{1}},
+		{name: "template", workload: "1,5,0,2,class:batch\ntemplate: count=2\ntemplate: class=system", want: []string{classBatch, classBatch, classBatch, classSystem}},
+		{name: "unknown", workload: "1,5,0,2,class:realtime", wantErr: true},
+		{name: "unknown in template", workload: "template: class=realtime", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			processes, err := loadProcesses(strings.NewReader(tt.workload))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadProcesses() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			got := make([]string, len(processes))
+			for i, p := range processes {
+				got[i] = p.Class
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("classes = %v, want %v", got, tt.want)
+			}
+			if last := processes[len(processes)-1]; !reflect.DeepEqual(last.Deps, tt.wantDeps) {
+				t.Errorf("Deps = %v, want %v", last.Deps, tt.wantDeps)
+			}
+		})
+	}
+}
+
+func Test_parseClassPolicy(t *testing.T) {
+	t.Parallel()
+	if got, err := parseClassPolicy("strict"); err != nil || got != ClassPolicyStrict {
+		t.Errorf("parseClassPolicy(strict) = %q, %v", got, err)
+	}
+	if _, err := parseClassPolicy("fair"); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("parseClassPolicy(fair) error = %v, want ErrInvalidArgs", err)
+	}
+}
+
+func TestSchedulers_classPolicy(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 3, ArrivalTime: 1, Priority: 1, Class: classBatch},
+		{ProcessID: 2, BurstDuration: 2, ArrivalTime: 1, Priority: 1},
+		{ProcessID: 3, BurstDuration: 1, ArrivalTime: 1, Priority: 1, Class: classSystem},
+	}
+	tests := []struct {
+		algorithm      string
+		policy         ClassPolicy
+		wantCompletion []int64
+	}{
+		{algorithm: "fcfs", policy: ClassPolicyShared, wantCompletion: []int64{4, 6, 7}},
+		{algorithm: "fcfs", policy: ClassPolicyStrict, wantCompletion: []int64{7, 4, 2}},
+		{algorithm: "sjf", policy: ClassPolicyShared, wantCompletion: []int64{7, 4, 2}},
+		{algorithm: "sjf", policy: ClassPolicyStrict, wantCompletion: []int64{7, 4, 2}},
+		{algorithm: "priority", policy: ClassPolicyShared, wantCompletion: []int64{4, 6, 7}},
+		{algorithm: "priority", policy: ClassPolicyStrict, wantCompletion: []int64{7, 4, 2}},
+		{algorithm: "rr", policy: ClassPolicyShared, wantCompletion: []int64{7, 6, 4}},
+		{algorithm: "rr", policy: ClassPolicyStrict, wantCompletion: []int64{7, 4, 2}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.algorithm+" "+string(tt.policy), func(t *testing.T) {
+			t.Parallel()
+			selected, err := selectAlgorithms(tt.algorithm)
+			if err != nil {
+				t.Fatal(err)
+			}
+			s := selected[0].schedule(processes, Options{ClassPolicy: tt.policy})
+			if !reflect.DeepEqual(s.Completion, tt.wantCompletion) {
+				t.Errorf("Completion = %v, want %v", s.Completion, tt.wantCompletion)
+			}
+		})
+	}
+}
+
+func TestSchedule_classSummary(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 3, ArrivalTime: 0, Priority: 1, Class: classBatch},
+		{ProcessID: 2, BurstDuration: 2, ArrivalTime: 0, Priority: 1},
+		{ProcessID: 3, BurstDuration: 1, ArrivalTime: 0, Priority: 1, Class: classBatch},
+	}
+	want := []string{
+		"Class interactive: 1 processes, average wait 1.00, turnaround 3.00, response 1.00",
+		"Class batch: 2 processes, average wait 1.50, turnaround 3.50, response 1.50",
+	}
+	s := sjf(processes, Options{})
+	if got := s.classSummary(); !reflect.DeepEqual(got, want) {
+		t.Errorf("classSummary() = %q, want %q", got, want)
+	}
+	processes[0].Class, processes[2].Class = "", ""
+	if got := sjf(processes, Options{}).classSummary(); got != nil {
+		t.Errorf("classSummary() = %q without classes, want none", got)
+	}
+}
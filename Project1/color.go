@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// ansiPalette holds the ANSI foreground color codes assigned to process IDs in terminal output.
+var ansiPalette = []int{34, 33, 31, 36, 32, 93, 35, 95, 91, 94, 96, 92}
+
+// colorPID wraps s in the ANSI escape codes of the process ID's color, the same for a PID in every
+// chart and table. Idle and context switching time are dimmed.
+func colorPID(pid int64, s string) string {
+	if pid == idlePID || pid == switchPID {
+		return "\x1b[2m" + s + "\x1b[0m"
+	}
+	if pid < 0 {
+		pid = -pid
+	}
+
+	return fmt.Sprintf("\x1b[1;%dm%s\x1b[0m", ansiPalette[pid%int64(len(ansiPalette))], s)
+}
+
+// isTerminal reports whether f is a character device, so escape codes written to it are shown as
+// colors rather than ending up in a file or pipe.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_colorPID(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		pid  int64
+		want string
+	}{
+		{name: "first color", pid: 0, want: "\x1b[1;34mx\x1b[0m"},
+		{name: "wraps around", pid: 13, want: "\x1b[1;33mx\x1b[0m"},
+		{name: "idle", pid: idlePID, want: "\x1b[2mx\x1b[0m"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := colorPID(tt.pid, "x"); got != tt.want {
+				t.Errorf("colorPID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_isTerminal(t *testing.T) {
+	t.Parallel()
+	f, err := os.Create(filepath.Join(t.TempDir(), "out.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if isTerminal(f) {
+		t.Error("isTerminal() = true for a regular file")
+	}
+}
@@ -0,0 +1,257 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"runtime"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// comparedMetric is a column of the cross-algorithm summary table.
+type comparedMetric struct {
+	header         string
+	format         string
+	value          func(s Schedule) float64
+	higherIsBetter bool
+	applies        func(s Schedule) bool // whether the metric applies to a schedule, nil if it always does
+}
+
+var comparedMetrics = []comparedMetric{
+	{header: "Average wait", format: "%.2f", value: Schedule.AverageWait},
+	{header: "Average turnaround", format: "%.2f", value: Schedule.AverageTurnaround},
+	{header: "Average normalized turnaround", format: "%.2f", value: Schedule.AverageNormalizedTurnaround},
+	{header: "Average response", format: "%.2f", value: Schedule.AverageResponse},
+	{header: "Throughput", format: "%.2f/t", value: Schedule.Throughput, higherIsBetter: true},
+	{header: "Makespan", format: "%g", value: func(s Schedule) float64 { return s.inUnits(float64(s.Makespan())) }},
+	{header: "Idle time", format: "%g", value: func(s Schedule) float64 { return s.inUnits(float64(s.IdleTime())) }, applies: Schedule.idles},
+	{header: "CPU utilization", format: "%.2f%%", value: func(s Schedule) float64 { return s.Utilization() * 100 }, higherIsBetter: true},
+	{header: "Fairness", format: "%.2f", value: func(s Schedule) float64 { return jainIndex(s.NormalizedTurnaround()) }, higherIsBetter: true},
+	{header: "Context switches", format: "%.0f", value: func(s Schedule) float64 { return float64(s.ContextSwitches()) }},
+	{header: "Deadline misses", format: "%.2f%%", value: func(s Schedule) float64 { return s.MissRatio() * 100 }, applies: Schedule.hasDeadlines},
+	{header: "Maximum tardiness", format: "%g", value: func(s Schedule) float64 { return s.inUnits(float64(maximum(s.Tardiness()))) }, applies: Schedule.hasDeadlines},
+	{header: "Energy", format: "%.2f", value: Schedule.EnergyUse, applies: Schedule.hasEnergyModel},
+	{header: "Scaled turnaround", format: "%.2f", value: Schedule.ScaledTurnaround, applies: Schedule.hasEnergyModel},
+}
+
+// best returns the index of the first result with the best value of the metric.
+func (m comparedMetric) best(results []result) int {
+	best := 0
+	for i := range results {
+		v, b := m.value(results[i].schedule), m.value(results[best].schedule)
+		if m.higherIsBetter && v > b || !m.higherIsBetter && v < b {
+			best = i
+		}
+	}
+
+	return best
+}
+
+// resultMetrics returns the compared metrics that apply to any of the results.
+func resultMetrics(results []result) []comparedMetric {
+	metrics := make([]comparedMetric, 0, len(comparedMetrics))
+	for _, m := range comparedMetrics {
+		applies := m.applies == nil
+		for _, res := range results {
+			applies = applies || m.applies(res.schedule)
+		}
+		if applies {
+			metrics = append(metrics, m)
+		}
+	}
+
+	return metrics
+}
+
+// comparisonRows formats the cross-algorithm summary of the metrics that apply to the results, one
+// row per result. The best value of each metric is marked with an asterisk.
+func comparisonRows(results []result) (header []string, rows [][]string) {
+	rows = make([][]string, len(results))
+	for i := range results {
+		rows[i] = []string{results[i].title}
+	}
+	header = []string{"Algorithm"}
+	for _, m := range resultMetrics(results) {
+		header = append(header, m.header)
+		bestValue := fmt.Sprintf(m.format, m.value(results[m.best(results)].schedule))
+		for i := range results {
+			cell := fmt.Sprintf(m.format, m.value(results[i].schedule))
+			if cell == bestValue {
+				cell += " *"
+			}
+			rows[i] = append(rows[i], cell)
+		}
+	}
+
+	return header, rows
+}
+
+// outputComparison outputs one table comparing the summary metrics of several schedules side by side.
+func outputComparison(w io.Writer, results []result, r Report) {
+	outputTitle(w, "Comparison")
+	header, rows := comparisonRows(results)
+	alignment := []int{tablewriter.ALIGN_LEFT}
+	for range header[1:] {
+		alignment = append(alignment, tablewriter.ALIGN_RIGHT)
+	}
+	outputTable(w, r.TableStyle, header, rows, nil, alignment)
+	_, _ = fmt.Fprintln(w, "* best value")
+}
+
+// compareCommand runs the selected algorithms on one workload, outputs each schedule and ends with
+// a table comparing them.
+func compareCommand(w io.Writer, args []string) (err error) {
+	fs := newFlagSet("compare")
+	names := fs.String("algorithms", "all", "comma separated algorithms to compare")
+	watch := fs.Bool("watch", false, "re-run every time the workload file changes, until interrupted")
+	runs := fs.Int("runs", 1, "schedule this many times, seeded from -seed onwards, and summarize the means with 95% confidence intervals")
+	checkpointPath := fs.String("checkpoint", "", "save each completed schedule to this file and resume from it when rerun after an interruption")
+	rubricPath := fs.String("assert", "", "check the schedules against the assertions of this rubric file, e.g. \"fcfs average wait == 12.33\", failing if any does not hold")
+	pluginCommand := fs.String("plugin", "", "also compare the external scheduler this command runs, e.g. \"python3 my_scheduler.py\"")
+	var sweepSpecs paramFlag
+	fs.Var(&sweepSpecs, "sweep", "schedule once per value of a parameter and tabulate the metrics against it, e.g. quantum=1..20; repeat to sweep every combination: "+strings.Join(sweepParamNames(), ", "))
+	parallel := fs.Int("parallel", runtime.NumCPU(), "schedule this many -sweep combinations at once")
+	policySource := fs.String("policy", "", "also compare the policy picking the ready process with the least or greatest score, e.g. \"min remaining + 0.5*priority\"")
+	options := addOptionFlags(fs)
+	report := addReportFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	r, err := report()
+	if err != nil {
+		return err
+	}
+	r.Compare = true
+	var results []result
+	defer func() { err = writeRunSummary(r, results, err) }()
+	opts, err := options()
+	if err != nil {
+		return err
+	}
+	if err := r.parseStarvation(opts.timeBase()); err != nil {
+		return err
+	}
+	if err := validateRuns(*runs, r); err != nil {
+		return err
+	}
+	var sweeps []sweep
+	if len(sweepSpecs) > 0 {
+		if err := validateSweep(r, *runs, *checkpointPath, *rubricPath); err != nil {
+			return err
+		}
+		if *parallel < 1 {
+			return fmt.Errorf("%w: -parallel must be at least 1", ErrInvalidArgs)
+		}
+		if sweeps, err = parseSweeps(sweepSpecs); err != nil {
+			return err
+		}
+	}
+	var rubric []assertion
+	if *rubricPath != "" {
+		if *runs > 1 {
+			return fmt.Errorf("%w: -assert checks a single run", ErrInvalidArgs)
+		}
+		if rubric, err = loadRubric(*rubricPath); err != nil {
+			return err
+		}
+	}
+	plug := newPlugin(*pluginCommand)
+	selected := make([]algorithm, 0)
+	if *names != "" || plug == nil && *policySource == "" {
+		if selected, err = selectAlgorithms(*names); err != nil {
+			return err
+		}
+	}
+	if *policySource != "" {
+		p, err := parsePolicy(*policySource)
+		if err != nil {
+			return err
+		}
+		selected = append(selected, p.algorithm())
+	}
+	if plug != nil {
+		selected = append(selected, plug.algorithm())
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: must give a scheduling file to compare", ErrInvalidArgs)
+	}
+
+	run := func() error {
+		processes, err := loadWorkload(fs.Arg(0), opts.timeBase())
+		if err != nil {
+			return err
+		}
+		if err := validateJobs(processes, opts); err != nil {
+			return err
+		}
+		if sweeps != nil {
+			rows, err := runSweep(processes, opts, selected, sweeps, *parallel)
+			if err != nil {
+				return err
+			}
+			if err := plug.failed(); err != nil {
+				return err
+			}
+			if r.Output == "" || r.Output == "-" {
+				return writeSweep(w, sweeps, rows, r)
+			}
+			return writeFile(r.Output, func(w io.Writer) error { return writeSweep(w, sweeps, rows, r) })
+		}
+		var cp *checkpointer
+		if *checkpointPath != "" {
+			if cp, err = openCheckpoint(*checkpointPath, processes, opts); err != nil {
+				return err
+			}
+		}
+		if *runs > 1 {
+			if !opts.randomized(processes) {
+				logs.Warn("nothing is random, so every run is the same; set -jitter or -tie-break random")
+			}
+			samples, err := monteCarlo(processes, opts, selected, *runs, cp)
+			if err != nil {
+				return err
+			}
+			if err := plug.failed(); err != nil {
+				return err
+			}
+			results = samples[0]
+			outputMonteCarlo(w, samples, r)
+			return cp.finish()
+		}
+		if results, err = cp.scheduleAll(0, processes, opts, selected); err != nil {
+			return err
+		}
+		if err := plug.failed(); err != nil {
+			return err
+		}
+		if opts.ExactMetrics {
+			if err := inexactMetrics(results); err != nil {
+				return err
+			}
+		}
+		if err := outputResults(w, results, r); err != nil {
+			return err
+		}
+		if err := cp.finish(); err != nil {
+			return err
+		}
+		if rubric != nil {
+			if err := checkRubric(w, rubric, results); err != nil {
+				return err
+			}
+		}
+
+		return missedDeadlines(results)
+	}
+	if !*watch {
+		return run()
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	return watchFile(ctx, fs.Arg(0), watchInterval, w, isTerminal(os.Stdout), run)
+}
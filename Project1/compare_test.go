@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_outputComparison(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	}
+	var w bytes.Buffer
+	outputComparison(&w, scheduleAll(processes, Options{}, algorithms), Report{})
+	wantRows := map[string][]string{
+		"First-come, first-serve": {"3.33 ", "10.00 ", "2 *"},
+		"Shortest-job-first":      {"2.67 *", "9.33 *", "0.67 "},
+		"Round-robin":             {"0.00 *", "16 "},
+	}
+	for _, line := range strings.Split(w.String(), "\n") {
+		for title, cells := range wantRows {
+			if !strings.Contains(line, title) {
+				continue
+			}
+			for _, cell := range cells {
+				if !strings.Contains(line, cell) {
+					t.Errorf("row %q does not contain %q", line, cell)
+				}
+			}
+			delete(wantRows, title)
+		}
+	}
+	if len(wantRows) > 0 {
+		t.Errorf("missing rows %v in %v", wantRows, w.String())
+	}
+}
+
+func Test_selectAlgorithms(t *testing.T) {
+	t.Parallel()
+	got, err := selectAlgorithms("rr, fcfs")
+	if err != nil || len(got) != 2 || got[0].name != "rr" || got[1].name != "fcfs" {
+		t.Errorf("selectAlgorithms() = %v, %v", got, err)
+	}
+	if _, err := selectAlgorithms("lottery"); err == nil {
+		t.Error("selectAlgorithms(lottery) did not fail")
+	}
+}
@@ -0,0 +1,307 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// flagSetCreated, when set, is called with every flag set a command creates. Completion sets it to
+// find a command's flags by running the command with -h.
+var flagSetCreated func(fs *flag.FlagSet)
+
+// newFlagSet creates the flag set of a command, with the flags selecting a profile. Commands parse
+// it with parseFlags.
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.String("profile", "", "fill in the flags not given from this profile of the -config file")
+	fs.String("config", defaultConfigFile, "config file holding the -profile")
+	if flagSetCreated != nil {
+		flagSetCreated(fs)
+	}
+
+	return fs
+}
+
+// commandFlags returns the flag set of a command, without running it.
+func commandFlags(name string) *flag.FlagSet {
+	cmd, ok := commands[name]
+	if !ok {
+		return nil
+	}
+	var created *flag.FlagSet
+	flagSetCreated = func(fs *flag.FlagSet) {
+		fs.SetOutput(io.Discard)
+		if created == nil {
+			created = fs
+		}
+	}
+	defer func() { flagSetCreated = nil }()
+	_ = cmd.run(io.Discard, []string{"-h"})
+
+	return created
+}
+
+// flagValues complete the values of flags that take one of a few names, keyed by flag name or by
+// "command/flag" where a command's flag takes other names. Flags taking a comma separated list are
+// completed one name after the other.
+var flagValues = map[string]struct {
+	names func() []string
+	list  bool
+}{
+	"algorithm":      {names: algorithmNames},
+	"algorithms":     {names: algorithmNames, list: true},
+	"format":         {names: formatNames},
+	"tie-break":      {names: tieBreakNames},
+	"class-policy":   {names: classPolicyNames},
+	"governor":       {names: governorNames},
+	"rr-queue":       {names: rrQueueNames},
+	"priority-order": {names: priorityOrderNames},
+	"table-style":    {names: tableStyleNames},
+	"sort-by":        {names: sortKeyNames},
+	"columns":        {names: columnKeys, list: true},
+	"log-format":     {names: func() []string { return []string{"text", "json"} }},
+	"profile":        {names: profileNames},
+	"opt":            {names: algorithmParamNames},
+
+	"aggregate/format":    {names: func() []string { return aggregateFormats }},
+	"disk/algorithms":     {names: diskAlgorithmNames, list: true},
+	"disk/direction":      {names: func() []string { return []string{"up", "down"} }},
+	"paging/algorithms":   {names: pagingAlgorithmNames, list: true},
+	"memory/algorithms":   {names: fitAlgorithmNames, list: true},
+	"deadlock/algorithm":  {names: resourcePickNames},
+	"deadlock/protocol":   {names: lockingProtocolNames},
+	"multicore/algorithm": {names: multicorePickNames},
+	"placement":           {names: placementNames},
+}
+
+func algorithmNames() []string {
+	all := allAlgorithms()
+	names := make([]string, len(all))
+	for i, a := range all {
+		names[i] = a.name
+	}
+
+	return names
+}
+
+func diskAlgorithmNames() []string {
+	names := make([]string, len(diskAlgorithms))
+	for i, a := range diskAlgorithms {
+		names[i] = a.name
+	}
+
+	return names
+}
+
+func pagingAlgorithmNames() []string {
+	names := make([]string, len(pagingAlgorithms))
+	for i, a := range pagingAlgorithms {
+		names[i] = a.name
+	}
+
+	return names
+}
+
+func fitAlgorithmNames() []string {
+	names := make([]string, len(fitAlgorithms))
+	for i, a := range fitAlgorithms {
+		names[i] = a.name
+	}
+
+	return names
+}
+
+func tieBreakNames() []string {
+	names := make([]string, len(tieBreaks))
+	for i, policy := range tieBreaks {
+		names[i] = string(policy)
+	}
+
+	return names
+}
+
+func sortKeyNames() []string {
+	names := make([]string, 0, len(sortKeys))
+	for name := range sortKeys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// profileNames returns the profiles of the default config file, if there is one.
+func profileNames() []string {
+	f, err := os.Open(defaultConfigFile)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	profiles, err := loadProfiles(f)
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func columnKeys() []string {
+	keys := make([]string, len(scheduleColumns))
+	for i, c := range scheduleColumns {
+		keys[i] = c.key
+	}
+
+	return keys
+}
+
+// complete returns the completions of the last of the words typed after the program name, or none
+// when the shell should complete a file name instead.
+func complete(words []string) []string {
+	if len(words) == 0 {
+		words = []string{""}
+	}
+	current := words[len(words)-1]
+	matching := func(candidates []string) []string {
+		matches := make([]string, 0)
+		for _, c := range candidates {
+			if strings.HasPrefix(c, current) {
+				matches = append(matches, c)
+			}
+		}
+		return matches
+	}
+
+	name := words[0]
+	if len(words) == 1 {
+		if strings.HasPrefix(current, "-") {
+			name = "run"
+		} else {
+			names := make([]string, 0, len(commands)+1)
+			for n := range commands {
+				names = append(names, n)
+			}
+			names = append(names, "help")
+			sort.Strings(names)
+			return matching(names)
+		}
+	} else if _, ok := commands[name]; !ok {
+		name = "run"
+	}
+	fs := commandFlags(name)
+	if fs == nil {
+		return nil
+	}
+
+	if len(words) > 1 {
+		prev := strings.TrimLeft(words[len(words)-2], "-")
+		if f := fs.Lookup(prev); f != nil && strings.HasPrefix(words[len(words)-2], "-") {
+			if b, ok := f.Value.(interface{ IsBoolFlag() bool }); !ok || !b.IsBoolFlag() {
+				values, ok := flagValues[name+"/"+prev]
+				if !ok {
+					values, ok = flagValues[prev]
+				}
+				if !ok {
+					return nil
+				}
+				prefix := ""
+				if values.list {
+					if i := strings.LastIndex(current, ","); i >= 0 {
+						prefix = current[:i+1]
+					}
+				}
+				candidates := values.names()
+				for i := range candidates {
+					candidates[i] = prefix + candidates[i]
+				}
+				return matching(candidates)
+			}
+		}
+	}
+	if !strings.HasPrefix(current, "-") {
+		return nil
+	}
+	flags := make([]string, 0)
+	fs.VisitAll(func(f *flag.Flag) { flags = append(flags, "-"+f.Name) })
+
+	return matching(flags)
+}
+
+// completeCommand writes the completions of the words typed so far, one per line. Shell completion
+// scripts call it as the hidden __complete command.
+func completeCommand(w io.Writer, args []string) error {
+	for _, c := range complete(args) {
+		_, _ = fmt.Fprintln(w, c)
+	}
+
+	return nil
+}
+
+// completionScripts are the completion scripts by shell; %[1]s is the program name and %[2]s the
+// name of its shell function.
+var completionScripts = map[string]string{
+	"bash": `_%[2]s() {
+	local IFS=$'\n'
+	local candidates
+	candidates=($("${COMP_WORDS[0]}" __complete "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null))
+	if [ ${#candidates[@]} -eq 0 ]; then
+		COMPREPLY=($(compgen -f -- "${COMP_WORDS[COMP_CWORD]}"))
+	else
+		COMPREPLY=("${candidates[@]}")
+	fi
+}
+complete -o filenames -F _%[2]s %[1]s
+`,
+	"zsh": `#compdef %[1]s
+_%[2]s() {
+	local -a candidates
+	candidates=("${(@f)$("${words[1]}" __complete "${(@)words[2,CURRENT]}" 2>/dev/null)}")
+	if [[ -z "${candidates[1]}" ]]; then
+		_files
+	else
+		compadd -- "${candidates[@]}"
+	fi
+}
+compdef _%[2]s %[1]s
+`,
+	"fish": `function __%[2]s_complete
+	set -l tokens (commandline -opc) (commandline -ct)
+	$tokens[1] __complete $tokens[2..-1] 2>/dev/null
+end
+complete -c %[1]s -a '(__%[2]s_complete)'
+`,
+}
+
+// completionCommand writes the completion script of a shell.
+func completionCommand(w io.Writer, args []string) error {
+	fs := newFlagSet("completion")
+	program := fs.String("name", filepath.Base(os.Args[0]), "program name to complete")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: must give a shell: bash, zsh or fish", ErrInvalidArgs)
+	}
+	script, ok := completionScripts[fs.Arg(0)]
+	if !ok {
+		return fmt.Errorf("%w: unknown shell %q (want bash, zsh or fish)", ErrInvalidArgs, fs.Arg(0))
+	}
+	function := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, *program)
+	_, err := fmt.Fprintf(w, script, *program, function)
+
+	return err
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Test_complete is not parallel: finding a command's flags briefly sets the flagSetCreated hook,
+// which would pick up the flag sets of commands run by other tests.
+func Test_complete(t *testing.T) {
+	tests := []struct {
+		name  string
+		words []string
+		want  []string
+	}{
+		{name: "commands", words: []string{"co"}, want: []string{"compare", "completion"}},
+		{name: "flags", words: []string{"compare", "-al"}, want: []string{"-algorithms"}},
+		{name: "run flags", words: []string{"-sort"}, want: []string{"-sort-by"}},
+		{name: "flag value", words: []string{"compare", "-format", "m"}, want: []string{"markdown", "mermaid"}},
+		{name: "list value", words: []string{"compare", "-algorithms", "fcfs,"}, want: []string{"fcfs,fcfs", "fcfs,sjf", "fcfs,priority", "fcfs,rr", "fcfs,feedback", "fcfs,edf", "fcfs,llf"}},
+		{name: "command flag value", words: []string{"disk", "-algorithms", "s"}, want: []string{"sstf", "scan"}},
+		{name: "after bool flag", words: []string{"-stats", ""}, want: nil},
+		{name: "file value", words: []string{"compare", "-o", ""}, want: nil},
+		{name: "workload file", words: []string{"step", "ex"}, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := complete(tt.words)
+			if len(got) == 0 && len(tt.want) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("complete() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_completionCommand(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr error
+	}{
+		{name: "bash", args: []string{"-name", "sched-sim", "bash"}, want: "complete -o filenames -F _sched_sim sched-sim\n"},
+		{name: "zsh", args: []string{"-name", "schedsim", "zsh"}, want: "compdef _schedsim schedsim\n"},
+		{name: "fish", args: []string{"-name", "schedsim", "fish"}, want: "complete -c schedsim -a '(__schedsim_complete)'\n"},
+		{name: "unknown shell", args: []string{"tcsh"}, wantErr: ErrInvalidArgs},
+		{name: "no shell", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			err := completionCommand(&w, tt.args)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			if !strings.HasSuffix(w.String(), tt.want) {
+				t.Errorf("completionCommand() = %s, want it to end with %q", w.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// convoyMinFollowers is the number of short processes that must queue behind a run for it to lead a
+// convoy.
+const convoyMinFollowers = 2
+
+// convoy is a run of a long process with short processes queued behind it: the classic FCFS convoy
+// effect. The excess wait is the time the followers waited during the run.
+type convoy struct {
+	Leader     int64
+	Start      int64
+	Stop       int64
+	Followers  []int64
+	ExcessWait int64
+}
+
+// Convoys finds the convoys of the schedule: the runs of a process during which at least
+// convoyMinFollowers processes with bursts at most half the run waited, in order of time.
+func (s Schedule) Convoys() []convoy {
+	index := make(map[int64]int, len(s.Processes))
+	for i, p := range s.Processes {
+		index[p.ProcessID] = i
+	}
+	convoys := make([]convoy, 0)
+	for _, run := range mergeSlices(s.Gantt) {
+		leader, ok := index[run.PID]
+		if !ok {
+			continue
+		}
+		c := convoy{Leader: run.PID, Start: run.Start, Stop: run.Stop, Followers: make([]int64, 0)}
+		for i, p := range s.Processes {
+			if i == leader || 2*p.BurstDuration > run.Stop-run.Start {
+				continue
+			}
+			from, to := p.ArrivalTime, s.Completion[i]
+			if from < run.Start {
+				from = run.Start
+			}
+			if to > run.Stop {
+				to = run.Stop
+			}
+			if from < to {
+				c.Followers = append(c.Followers, p.ProcessID)
+				c.ExcessWait += to - from
+			}
+		}
+		if len(c.Followers) >= convoyMinFollowers {
+			sort.Slice(c.Followers, func(i, j int) bool { return c.Followers[i] < c.Followers[j] })
+			convoys = append(convoys, c)
+		}
+	}
+
+	return convoys
+}
+
+// convoyLines formats a line per convoy of the schedule and the share of all waiting they caused, or
+// nothing when there is none.
+func (s Schedule) convoyLines() []string {
+	convoys := s.Convoys()
+	if len(convoys) == 0 {
+		return nil
+	}
+	lines := make([]string, 0, len(convoys)+1)
+	var excess int64
+	for _, c := range convoys {
+		excess += c.ExcessWait
+		lines = append(lines, fmt.Sprintf("process %d ran from %s to %s while %d shorter processes %v waited %s",
+			c.Leader, s.formatTime(c.Start), s.formatTime(c.Stop), len(c.Followers), c.Followers, s.formatTime(c.ExcessWait)))
+	}
+	var total int64
+	for _, wait := range s.Wait {
+		total += wait
+	}
+	share := 0.0
+	if total > 0 {
+		share = float64(excess) / float64(total) * 100
+	}
+	lines = append(lines, fmt.Sprintf("convoys caused %s of the %s total wait (%.2f%%)", s.formatTime(excess), s.formatTime(total), share))
+
+	return lines
+}
+
+// outputConvoys outputs a section on the convoys of the schedule, if it has any.
+func outputConvoys(w io.Writer, s Schedule) {
+	lines := s.convoyLines()
+	if lines == nil {
+		return
+	}
+	_, _ = fmt.Fprintln(w, "Convoy effect")
+	for _, line := range lines {
+		_, _ = fmt.Fprintf(w, "  %s\n", line)
+	}
+	_, _ = fmt.Fprintln(w)
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSchedule_Convoys(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 20},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 3},
+		{ProcessID: 4, ArrivalTime: 3, BurstDuration: 12},
+	}
+	tests := []struct {
+		name     string
+		schedule Schedule
+		want     []convoy
+	}{
+		{
+			name:     "fcfs",
+			schedule: fcfs(processes, Options{}),
+			want:     []convoy{{Leader: 1, Start: 0, Stop: 20, Followers: []int64{2, 3}, ExcessWait: 37}},
+		},
+		{name: "sjf", schedule: sjf(processes, Options{}), want: []convoy{}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.schedule.Convoys(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Convoys() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchedule_convoyLines(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 10},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 1},
+		{ProcessID: 3, ArrivalTime: 0, BurstDuration: 1},
+	}
+	want := []string{
+		"process 1 ran from 0 to 10 while 2 shorter processes [2 3] waited 20",
+		"convoys caused 20 of the 21 total wait (95.24%)",
+	}
+	if got := fcfs(processes, Options{}).convoyLines(); !reflect.DeepEqual(got, want) {
+		t.Errorf("convoyLines() = %q, want %q", got, want)
+	}
+	if got := sjf(processes, Options{}).convoyLines(); got != nil {
+		t.Errorf("convoyLines() = %q, want none", got)
+	}
+}
@@ -0,0 +1,309 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// depsPrefix starts the optional dependencies column of a workload row, "deps:2,5", listing the IDs
+// of the processes that must complete before the process is ready. The IDs after the first may also
+// be the CSV fields that follow it.
+const depsPrefix = "deps:"
+
+// parseDeps parses the dependencies column starting at fields[0] and running to the end of the row.
+func parseDeps(fields []string) ([]int64, error) {
+	ids := make([]string, 0, len(fields))
+	for i, field := range fields {
+		if i == 0 {
+			field = strings.TrimPrefix(strings.TrimSpace(field), depsPrefix)
+		}
+		for _, id := range strings.Split(field, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				ids = append(ids, id)
+			}
+		}
+	}
+	deps := make([]int64, len(ids))
+	for i, id := range ids {
+		n, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("dependency %q is not a process ID", id)
+		}
+		deps[i] = n
+	}
+
+	return deps, nil
+}
+
+// dependencyIndexes returns the indexes of each process's predecessors, skipping unknown IDs, which
+// validateDependencies reports.
+func dependencyIndexes(processes []Process) [][]int {
+	index := make(map[int64]int, len(processes))
+	for i, p := range processes {
+		index[p.ProcessID] = i
+	}
+	deps := make([][]int, len(processes))
+	for i, p := range processes {
+		for _, id := range p.Deps {
+			if j, ok := index[id]; ok {
+				deps[i] = append(deps[i], j)
+			}
+		}
+	}
+
+	return deps
+}
+
+// validateDependencies checks every dependency names another process of the workload and that they
+// form no cycle, which would leave its processes waiting on each other forever.
+func validateDependencies(processes []Process) error {
+	index := make(map[int64]int, len(processes))
+	for i, p := range processes {
+		index[p.ProcessID] = i
+	}
+	for _, p := range processes {
+		for _, id := range p.Deps {
+			if _, ok := index[id]; !ok {
+				return fmt.Errorf("%w: process %d: depends on unknown process %d", ErrValidation, p.ProcessID, id)
+			}
+			if id == p.ProcessID {
+				return fmt.Errorf("%w: process %d: depends on itself", ErrValidation, p.ProcessID)
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	var (
+		deps  = dependencyIndexes(processes)
+		state = make([]int, len(processes))
+		path  []int
+		visit func(i int) []int
+	)
+	visit = func(i int) []int {
+		state[i] = visiting
+		path = append(path, i)
+		for _, j := range deps[i] {
+			switch state[j] {
+			case visiting:
+				for k := range path {
+					if path[k] == j {
+						return append(path[k:], j)
+					}
+				}
+			case unvisited:
+				if cycle := visit(j); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[i] = visited
+		return nil
+	}
+	for i := range processes {
+		if state[i] != unvisited {
+			continue
+		}
+		if cycle := visit(i); cycle != nil {
+			ids := make([]string, len(cycle))
+			for k, j := range cycle {
+				ids[k] = fmt.Sprintf("P%d", processes[j].ProcessID)
+			}
+			return fmt.Errorf("%w: dependency cycle %s", ErrValidation, strings.Join(ids, " -> "))
+		}
+	}
+
+	return nil
+}
+
+// readiness tells the schedulers which processes are ready to run: those that have arrived and whose
+// predecessors have all completed and, under the strict class policy, when no process of a higher
+// class is. It reads completion times, 0 until a process completes, from the schedule being built.
+type readiness struct {
+	processes  []Process
+	deps       [][]int
+	completion []int64
+	strict     bool
+}
+
+func newReadiness(processes []Process, completion []int64, policy ClassPolicy) readiness {
+	return readiness{
+		processes:  processes,
+		deps:       dependencyIndexes(processes),
+		completion: completion,
+		strict:     policy == ClassPolicyStrict,
+	}
+}
+
+// ready reports whether process i is ready to run at time t.
+func (r readiness) ready(i int, t int64) bool {
+	if !r.released(i, t) {
+		return false
+	}
+	if r.strict {
+		for j, p := range r.processes {
+			if classRank(p) < classRank(r.processes[i]) && r.released(j, t) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// released reports whether process i has arrived, has not completed and its predecessors have by time t.
+func (r readiness) released(i int, t int64) bool {
+	if c := r.completion[i]; r.processes[i].ArrivalTime > t || c != 0 && c <= t {
+		return false
+	}
+	for _, j := range r.deps[i] {
+		if c := r.completion[j]; c == 0 || c > t {
+			return false
+		}
+	}
+
+	return true
+}
+
+// othersReady reports whether a process other than i with time left to run is ready at time t.
+func (r readiness) othersReady(i int, t int64, remTime []int64) bool {
+	for j := range r.processes {
+		if j != i && remTime[j] > 0 && r.ready(j, t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// nextRelease returns the earliest arrival after time t of a process yet to complete, math.MaxInt64
+// if none. Until then only completions make processes ready, so schedulers can skip ahead to it.
+func (r readiness) nextRelease(t int64) int64 {
+	next := int64(math.MaxInt64)
+	for i, p := range r.processes {
+		if r.completion[i] == 0 && p.ArrivalTime > t && p.ArrivalTime < next {
+			next = p.ArrivalTime
+		}
+	}
+
+	return next
+}
+
+// idleUntil returns when a CPU idle at time t next has a process to run: the next release, or the
+// next tick if none is coming.
+func (r readiness) idleUntil(t int64) int64 {
+	if next := r.nextRelease(t); next != math.MaxInt64 {
+		return next
+	}
+
+	return t + 1
+}
+
+// aloneRun returns how long a process with rem time left runs from time t, quantum after quantum,
+// while it is the only one ready: up to the end of the first quantum at or after the next release,
+// or to its completion.
+func aloneRun(rem, quantum, t, next int64) int64 {
+	if next == math.MaxInt64 {
+		return rem
+	}
+	quanta := (next - t + quantum - 1) / quantum
+	if quanta < 1 {
+		quanta = 1
+	}
+	if run := quanta * quantum; run < rem {
+		return run
+	}
+
+	return rem
+}
+
+// fcfsQueue is the first-come, first-serve run queue. Processes without predecessors queue in input
+// order; one with predecessors joins when the last of them completes, behind every process that
+// became ready no later than it. The first queued process that has arrived runs next, under the strict
+// class policy the first of the highest class among them.
+type fcfsQueue struct {
+	processes  []Process
+	deps       [][]int
+	dependents [][]int
+	readyAt    []int64
+	waiting    []int
+	queue      []int
+	strict     bool
+}
+
+func newFCFSQueue(processes []Process, policy ClassPolicy) *fcfsQueue {
+	q := &fcfsQueue{
+		processes:  processes,
+		deps:       dependencyIndexes(processes),
+		dependents: make([][]int, len(processes)),
+		readyAt:    make([]int64, len(processes)),
+		waiting:    make([]int, len(processes)),
+		queue:      make([]int, 0, len(processes)),
+		strict:     policy == ClassPolicyStrict,
+	}
+	for i := range processes {
+		q.readyAt[i] = processes[i].ArrivalTime
+		q.waiting[i] = len(q.deps[i])
+		for _, j := range q.deps[i] {
+			q.dependents[j] = append(q.dependents[j], i)
+		}
+		if q.waiting[i] == 0 {
+			q.queue = append(q.queue, i)
+		}
+	}
+
+	return q
+}
+
+// next removes and returns the process to run at time t, or -1 when the queue is empty: the first
+// queued process that is ready by t or, when none is, the first to become ready after it.
+func (q *fcfsQueue) next(t int64) int {
+	if len(q.queue) == 0 {
+		return -1
+	}
+	at, earliest := -1, int64(math.MaxInt64)
+	for k, i := range q.queue {
+		if q.readyAt[i] > t {
+			if q.readyAt[i] < earliest {
+				earliest = q.readyAt[i]
+			}
+			continue
+		}
+		if at < 0 || q.strict && classRank(q.processes[i]) < classRank(q.processes[q.queue[at]]) {
+			at = k
+		}
+	}
+	if at < 0 {
+		return q.next(earliest)
+	}
+	i := q.queue[at]
+	q.queue = append(q.queue[:at], q.queue[at+1:]...)
+
+	return i
+}
+
+// complete queues the dependents of process i whose predecessors have now all completed, at time t.
+func (q *fcfsQueue) complete(i int, t int64) {
+	for _, j := range q.dependents[i] {
+		if q.waiting[j]--; q.waiting[j] > 0 {
+			continue
+		}
+		if t > q.readyAt[j] {
+			q.readyAt[j] = t
+		}
+		at := len(q.queue)
+		for k, queued := range q.queue {
+			if q.readyAt[queued] > q.readyAt[j] {
+				at = k
+				break
+			}
+		}
+		q.queue = append(q.queue[:at], append([]int{j}, q.queue[at:]...)...)
+	}
+}
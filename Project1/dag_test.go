@@ -0,0 +1,193 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_loadProcesses_deps(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		workload string
+		want     []int64
+		priority int64
+		wantErr  bool
+	}{
+		{name: "none", workload: "1,5,0,2", priority: 2},
+		{name: "one", workload: "1,5,0,2,deps:3", want: []int64{3}, priority: 2},
+		{name: "split by the CSV", workload: "1,5,0,2,deps:3,4", want: []int64{3, 4}, priority: 2},
+		{name: "quoted", workload: `1,5,0,2,"deps:3,4"`, want: []int64{3, 4}, priority: 2},
+		{name: "without priority", workload: "1,5,0,deps:3", want: []int64{3}},
+		{name: "not an ID", workload: "1,5,0,2,deps:x", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			processes, err := loadProcesses(strings.NewReader(tt.workload))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadProcesses() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := processes[0].Deps; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Deps = %v, want %v", got, tt.want)
+			}
+			if got := processes[0].Priority; got != tt.priority {
+				t.Errorf("Priority = %d, want %d", got, tt.priority)
+			}
+		})
+	}
+}
+
+func Test_validateDependencies(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		wantErr   string
+	}{
+		{
+			name: "chain",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 1},
+				{ProcessID: 2, BurstDuration: 1, Deps: []int64{1}},
+				{ProcessID: 3, BurstDuration: 1, Deps: []int64{1, 2}},
+			},
+		},
+		{
+			name:      "unknown",
+			processes: []Process{{ProcessID: 1, BurstDuration: 1, Deps: []int64{9}}},
+			wantErr:   "unknown process 9",
+		},
+		{
+			name:      "itself",
+			processes: []Process{{ProcessID: 1, BurstDuration: 1, Deps: []int64{1}}},
+			wantErr:   "depends on itself",
+		},
+		{
+			name: "cycle",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 1},
+				{ProcessID: 2, BurstDuration: 1, Deps: []int64{4}},
+				{ProcessID: 3, BurstDuration: 1, Deps: []int64{2}},
+				{ProcessID: 4, BurstDuration: 1, Deps: []int64{3, 1}},
+			},
+			wantErr: "dependency cycle P2 -> P4 -> P3 -> P2",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateProcesses(tt.processes)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("validateProcesses() = %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrValidation) || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validateProcesses() = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSchedulers_dependencies(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 3, ArrivalTime: 0, Priority: 2},
+		{ProcessID: 2, BurstDuration: 2, ArrivalTime: 0, Priority: 1, Deps: []int64{1}},
+		{ProcessID: 3, BurstDuration: 1, ArrivalTime: 1, Priority: 3},
+	}
+	tests := []struct {
+		algorithm      string
+		wantCompletion []int64
+	}{
+		{algorithm: "fcfs", wantCompletion: []int64{3, 6, 4}},
+		{algorithm: "sjf", wantCompletion: []int64{4, 6, 2}},
+		{algorithm: "priority", wantCompletion: []int64{3, 5, 6}},
+		{algorithm: "rr", wantCompletion: []int64{4, 6, 2}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.algorithm, func(t *testing.T) {
+			t.Parallel()
+			selected, err := selectAlgorithms(tt.algorithm)
+			if err != nil {
+				t.Fatal(err)
+			}
+			s := selected[0].schedule(processes, Options{})
+			if !reflect.DeepEqual(s.Completion, tt.wantCompletion) {
+				t.Errorf("Completion = %v, want %v", s.Completion, tt.wantCompletion)
+			}
+			if s.FirstRun[1] < s.Completion[0] {
+				t.Errorf("P2 first ran at %d, before P1 completed at %d", s.FirstRun[1], s.Completion[0])
+			}
+		})
+	}
+}
+
+func Test_aloneRun(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name                  string
+		rem, quantum, t, next int64
+		want                  int64
+	}{
+		{name: "no release", rem: 100, quantum: 2, t: 0, next: math.MaxInt64, want: 100},
+		{name: "release on a quantum end", rem: 100, quantum: 2, t: 3, next: 9, want: 6},
+		{name: "release within a quantum", rem: 100, quantum: 4, t: 0, next: 5, want: 8},
+		{name: "release now", rem: 100, quantum: 4, t: 5, next: 5, want: 4},
+		{name: "completes first", rem: 3, quantum: 2, t: 0, next: 10, want: 3},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := aloneRun(tt.rem, tt.quantum, tt.t, tt.next); got != tt.want {
+				t.Errorf("aloneRun() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_skipAhead(t *testing.T) {
+	t.Parallel()
+	processes := []Process{ // ticks one by one, these would take minutes
+		{ProcessID: 1, BurstDuration: 1_000_000_000, ArrivalTime: 0, Priority: 1},
+		{ProcessID: 2, BurstDuration: 1_000_000_000, ArrivalTime: 3_000_000_000, Priority: 1},
+	}
+	tests := []struct {
+		name string
+		alg  string
+		opts Options
+	}{
+		{name: "sjf", alg: "sjf"},
+		{name: "rr", alg: "rr", opts: Options{Quantum: 1}},
+		{name: "rr fifo", alg: "rr", opts: Options{Quantum: 1, RRQueue: RRQueueFIFO}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			selected, err := selectAlgorithms(tt.alg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			s := selected[0].schedule(processes, tt.opts)
+			if want := []int64{1_000_000_000, 4_000_000_000}; !reflect.DeepEqual(s.Completion, want) {
+				t.Errorf("Completion = %v, want %v", s.Completion, want)
+			}
+			if want := []TimeSlice{{Start: 1_000_000_000, Stop: 3_000_000_000}}; !reflect.DeepEqual(s.Idle, want) {
+				t.Errorf("Idle = %v, want %v", s.Idle, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"html/template"
+	"net/http"
+)
+
+// dashboardPage is what the dashboard template renders: the choices offered by the form.
+type dashboardPage struct {
+	Algorithms []dashboardAlgorithm
+	TieBreaks  []TieBreak
+}
+
+type dashboardAlgorithm struct {
+	Name    string
+	Title   string
+	Checked bool
+}
+
+// handleDashboard serves the web UI, which uploads a workload CSV to /run and shows the HTML report.
+func (sv *server) handleDashboard(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path != "/" {
+		http.NotFound(w, req)
+		return
+	}
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		http.Error(w, "GET the dashboard", http.StatusMethodNotAllowed)
+		return
+	}
+	page := dashboardPage{TieBreaks: tieBreaks}
+	for i, a := range allAlgorithms() {
+		page.Algorithms = append(page.Algorithms, dashboardAlgorithm{Name: a.name, Title: a.title, Checked: i < len(algorithms)})
+	}
+	w.Header().Set("Content-Type", contentTypes["html"])
+	_ = dashboardTemplate.Execute(w, page)
+}
+
+var dashboardTemplate = template.Must(template.New("dashboard").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Process scheduler</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 0; color: #222; display: flex; height: 100vh; }
+form { width: 20em; padding: 1.5em; background: #f4f4f4; border-right: 1px solid #ccc; overflow-y: auto; }
+fieldset { border: 1px solid #ccc; margin: 0 0 1em; }
+label { display: block; margin: 0.3em 0; }
+textarea { width: 100%; height: 10em; font-family: monospace; box-sizing: border-box; }
+button { font-size: 1em; padding: 0.4em 1.2em; }
+#error { color: #b00; white-space: pre-wrap; }
+iframe { flex: 1; border: 0; height: 100%; }
+</style>
+</head>
+<body>
+<form id="run">
+<h1>Process scheduler</h1>
+<fieldset><legend>Workload</legend>
+<label>CSV file <input type="file" id="file" accept=".csv,text/csv"></label>
+<label>or paste "id,burst,arrival,priority" rows
+<textarea id="csv">1,5,0,2
+2,9,3,1
+3,6,6,3</textarea></label>
+</fieldset>
+<fieldset><legend>Algorithms</legend>
+{{- range .Algorithms}}
+<label><input type="checkbox" name="algorithms" value="{{.Name}}"{{if .Checked}} checked{{end}}> {{.Title}}</label>
+{{- end}}
+</fieldset>
+<fieldset><legend>Options</legend>
+<label>Tie-break <select name="tie-break">
+{{- range .TieBreaks}}
+<option>{{.}}</option>
+{{- end}}
+</select></label>
+<label>Seed <input type="number" name="seed" value="1"></label>
+</fieldset>
+<button type="submit">Run</button>
+<p id="error"></p>
+</form>
+<iframe id="report" title="Scheduling report"></iframe>
+<script>
+const form = document.getElementById("run");
+const file = document.getElementById("file");
+const csv = document.getElementById("csv");
+file.addEventListener("change", async () => {
+  if (file.files.length) csv.value = await file.files[0].text();
+});
+form.addEventListener("submit", async (event) => {
+  event.preventDefault();
+  const error = document.getElementById("error");
+  error.textContent = "";
+  const data = new FormData(form);
+  const query = new URLSearchParams({
+    algorithms: data.getAll("algorithms").join(","),
+    "tie-break": data.get("tie-break"),
+    seed: data.get("seed"),
+    format: "html",
+  });
+  const resp = await fetch("/run?" + query, { method: "POST", body: csv.value });
+  const body = await resp.text();
+  if (!resp.ok) {
+    error.textContent = body;
+    return;
+  }
+  document.getElementById("report").srcdoc = body;
+});
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// hasDeadlines reports whether any process of the schedule has a deadline.
+func (s Schedule) hasDeadlines() bool {
+	for _, p := range s.Processes {
+		if relativeDeadline(p) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Tardiness returns how long after its deadline each process completed, 0 for a process that met its
+// deadline or has none.
+func (s Schedule) Tardiness() []int64 {
+	tardiness := make([]int64, len(s.Processes))
+	for i, p := range s.Processes {
+		if late := s.Completion[i] - absoluteDeadline(p); relativeDeadline(p) > 0 && late > 0 {
+			tardiness[i] = late
+		}
+	}
+
+	return tardiness
+}
+
+// DeadlineMisses counts the processes that completed after their deadline.
+func (s Schedule) DeadlineMisses() int {
+	var misses int
+	for _, late := range s.Tardiness() {
+		if late > 0 {
+			misses++
+		}
+	}
+
+	return misses
+}
+
+// MissRatio returns the fraction of the processes with a deadline that missed it, 0 if none has one.
+func (s Schedule) MissRatio() float64 {
+	var due int
+	for _, p := range s.Processes {
+		if relativeDeadline(p) > 0 {
+			due++
+		}
+	}
+	if due == 0 {
+		return 0
+	}
+
+	return float64(s.DeadlineMisses()) / float64(due)
+}
+
+// FirstMiss returns the earliest deadline that was missed, and false if every deadline was met.
+func (s Schedule) FirstMiss() (int64, bool) {
+	var (
+		first  int64
+		missed bool
+	)
+	for i, late := range s.Tardiness() {
+		if d := absoluteDeadline(s.Processes[i]); late > 0 && (!missed || d < first) {
+			first, missed = d, true
+		}
+	}
+
+	return first, missed
+}
+
+// deadlineSummary formats the deadline misses and tardiness of the schedule, or nothing when no process
+// has a deadline.
+func (s Schedule) deadlineSummary() []string {
+	if !s.hasDeadlines() {
+		return nil
+	}
+	var (
+		tardiness = make([]int64, 0, len(s.Processes))
+		misses    = s.DeadlineMisses()
+	)
+	for i, late := range s.Tardiness() {
+		if relativeDeadline(s.Processes[i]) > 0 {
+			tardiness = append(tardiness, late)
+		}
+	}
+	first, missed := s.FirstMiss()
+	if !missed {
+		return []string{fmt.Sprintf("Deadlines: all %d met", len(tardiness))}
+	}
+
+	return []string{fmt.Sprintf("Deadlines: %d of %d missed (%.2f%%), first at %s; tardiness average %s, maximum %s",
+		misses, len(tardiness), s.MissRatio()*100, s.formatTime(first),
+		s.formatUnits(s.inUnits(average(tardiness))), s.formatTime(maximum(tardiness)))}
+}
+
+var deadlinesHeader = []string{"ID", "Deadline", "Exit", "Tardiness"}
+
+// deadlineRows formats one row per process with a deadline, in the report's sort order.
+func (s Schedule) deadlineRows(r Report) [][]string {
+	var (
+		rows      = make([][]string, 0, len(s.Processes))
+		tardiness = s.Tardiness()
+	)
+	for _, i := range s.rowOrder(r) {
+		p := s.Processes[i]
+		if relativeDeadline(p) == 0 {
+			continue
+		}
+		rows = append(rows, []string{
+			fmt.Sprint(p.ProcessID),
+			s.formatTime(absoluteDeadline(p)),
+			s.formatTime(s.Completion[i]),
+			s.formatTime(tardiness[i]),
+		})
+	}
+
+	return rows
+}
+
+// outputDeadlines outputs the deadline and tardiness of every process with a deadline.
+func outputDeadlines(w io.Writer, s Schedule, r Report) {
+	if !s.hasDeadlines() {
+		return
+	}
+	_, _ = fmt.Fprintln(w, "Deadline table")
+	outputTable(w, r.TableStyle, deadlinesHeader, s.deadlineRows(r), nil, nil)
+	_, _ = fmt.Fprintln(w)
+}
+
+// missedDeadlines returns an ErrDeadlineMiss error naming the results that missed a deadline, or nil
+// if none did.
+func missedDeadlines(results []result) error {
+	missed := make([]string, 0)
+	for _, res := range results {
+		if res.schedule.DeadlineMisses() > 0 {
+			missed = append(missed, res.title)
+		}
+	}
+	if len(missed) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w under %s", ErrDeadlineMiss, strings.Join(missed, "; "))
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSchedule_deadlines(t *testing.T) {
+	t.Parallel()
+	processes, err := loadProcesses(strings.NewReader("1,4,0,2,period:7\n2,2,0,1,period:5\n3,1,0,3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name          string
+		algorithm     algorithm
+		wantTardiness []int64
+		wantRatio     float64
+		wantFirst     int64
+		wantSummary   []string
+	}{
+		{
+			name:          "edf",
+			algorithm:     algorithm{name: "edf", run: edf},
+			wantTardiness: []int64{0, 0, 0, 0},
+			wantSummary:   []string{"Deadlines: all 3 met"},
+		},
+		{
+			name:          "priority",
+			algorithm:     algorithm{name: "priority", run: preemptivePriority},
+			wantTardiness: []int64{1, 0, 0, 0},
+			wantRatio:     1.0 / 3,
+			wantFirst:     7,
+			wantSummary:   []string{"Deadlines: 1 of 3 missed (33.33%), first at 7; tardiness average 0.33, maximum 1"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s := tt.algorithm.schedule(processes, Options{Horizon: 7})
+			if got := s.Tardiness(); !reflect.DeepEqual(got, tt.wantTardiness) {
+				t.Errorf("Tardiness() = %v, want %v", got, tt.wantTardiness)
+			}
+			if got := s.MissRatio(); got != tt.wantRatio {
+				t.Errorf("MissRatio() = %v, want %v", got, tt.wantRatio)
+			}
+			if got, missed := s.FirstMiss(); got != tt.wantFirst || missed != (tt.wantFirst > 0) {
+				t.Errorf("FirstMiss() = %d, %v, want %d", got, missed, tt.wantFirst)
+			}
+			if got := s.deadlineSummary(); !reflect.DeepEqual(got, tt.wantSummary) {
+				t.Errorf("deadlineSummary() = %q, want %q", got, tt.wantSummary)
+			}
+			err := missedDeadlines([]result{{title: tt.name, schedule: s}})
+			if got := errors.Is(err, ErrDeadlineMiss); got != (tt.wantFirst > 0) {
+				t.Errorf("missedDeadlines() = %v", err)
+			}
+		})
+	}
+}
+
+func Test_comparisonRows_deadlines(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		workload string
+		want     int
+	}{
+		{workload: "1,4,0,2\n2,2,0,1", want: len(comparedMetrics) - 4},
+		{workload: "1,4,0,2,deadline:5\n2,2,0,1", want: len(comparedMetrics) - 2},
+	} {
+		processes, err := loadProcesses(strings.NewReader(tt.workload))
+		if err != nil {
+			t.Fatal(err)
+		}
+		header, rows := comparisonRows(scheduleAll(processes, Options{}, algorithms))
+		if len(header) != tt.want || len(rows[0]) != tt.want {
+			t.Errorf("%q: %d columns, want %d", tt.workload, len(header), tt.want)
+		}
+	}
+}
@@ -0,0 +1,484 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// ResourceEvent is a process requesting or releasing a single-instance resource once it has run for
+// At time units. A request blocks the process until the resource is free.
+type ResourceEvent struct {
+	At       int64
+	Resource string
+	Release  bool
+}
+
+const resourcePrefix = "resource:"
+
+// addResourceEvent adds the event of a "resource: pid=1 at=2 request=A" (or release=A) line to the
+// process of that ID loaded before it. At is a time, read in ticks of a time base.
+func addResourceEvent(line string, loaded []Process, base timeBase) error {
+	var (
+		pid   int64
+		event ResourceEvent
+		found = make(map[string]bool)
+	)
+	for _, kv := range strings.Fields(strings.TrimPrefix(strings.TrimSpace(line), resourcePrefix)) {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok {
+			return fmt.Errorf("%w: resource field %q is not key=value", ErrInvalidArgs, kv)
+		}
+		var err error
+		switch key {
+		case "pid":
+			pid, err = strconv.ParseInt(value, 10, 64)
+		case "at":
+			event.At, err = parseTime(value, base)
+		case "request", "release":
+			event.Resource, event.Release = value, key == "release"
+			if value == "" {
+				err = fmt.Errorf("no resource named")
+			}
+		default:
+			return fmt.Errorf("%w: unknown resource field %q", ErrInvalidArgs, key)
+		}
+		if err != nil {
+			return fmt.Errorf("%w: resource %s %q: %v", ErrInvalidArgs, key, value, err)
+		}
+		found[key] = true
+	}
+	switch {
+	case !found["pid"] || !found["at"]:
+		return fmt.Errorf("%w: resource line needs pid and at", ErrInvalidArgs)
+	case found["request"] == found["release"]:
+		return fmt.Errorf("%w: resource line needs one of request or release", ErrInvalidArgs)
+	}
+
+	for i := len(loaded) - 1; i >= 0; i-- {
+		if loaded[i].ProcessID == pid {
+			loaded[i].Resources = append(loaded[i].Resources, event)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: resource line for process %d before the process", ErrInvalidArgs, pid)
+}
+
+// validateResourceEvents checks a process's events are in time order within its burst, and that it
+// only requests resources it does not hold and releases ones it does.
+func validateResourceEvents(p Process) error {
+	var (
+		held = make(map[string]bool)
+		last int64
+	)
+	for _, e := range p.Resources {
+		switch {
+		case e.At < last:
+			return fmt.Errorf("%w: process %d: resource events out of order at %d", ErrValidation, p.ProcessID, e.At)
+		case e.At < 0 || e.At > p.BurstDuration:
+			return fmt.Errorf("%w: process %d: resource event at %d outside its burst", ErrValidation, p.ProcessID, e.At)
+		case !e.Release && e.At == p.BurstDuration:
+			return fmt.Errorf("%w: process %d: requests %s at the end of its burst", ErrValidation, p.ProcessID, e.Resource)
+		case !e.Release && held[e.Resource]:
+			return fmt.Errorf("%w: process %d: requests %s it already holds", ErrValidation, p.ProcessID, e.Resource)
+		case e.Release && !held[e.Resource]:
+			return fmt.Errorf("%w: process %d: releases %s it does not hold", ErrValidation, p.ProcessID, e.Resource)
+		}
+		held[e.Resource] = !e.Release
+		last = e.At
+	}
+
+	return nil
+}
+
+// resourcePicks choose the process to run next among the ready ones, given by index in input order,
+// and the one that ran last, -1 if none did. They are selectable with deadlock -algorithm.
+var resourcePicks = map[string]func(processes []Process, ready []int, last int) int{
+	// fcfs keeps running the last process until it blocks or completes, then the earliest arrival.
+	"fcfs": func(processes []Process, ready []int, last int) int {
+		best := ready[0]
+		for _, i := range ready {
+			if i == last {
+				return i
+			}
+			if processes[i].ArrivalTime < processes[best].ArrivalTime {
+				best = i
+			}
+		}
+		return best
+	},
+	// rr runs the ready processes in turn, a time unit each.
+	"rr": func(_ []Process, ready []int, last int) int {
+		for _, i := range ready {
+			if i > last {
+				return i
+			}
+		}
+		return ready[0]
+	},
+	// priority runs the ready process with the most important priority, the lowest number once ranked,
+	// keeping the last one on ties.
+	"priority": func(processes []Process, ready []int, last int) int {
+		best := ready[0]
+		for _, i := range ready {
+			if processes[i].Priority < processes[best].Priority || processes[i].Priority == processes[best].Priority && i == last {
+				best = i
+			}
+		}
+		return best
+	},
+}
+
+func resourcePickNames() []string {
+	names := make([]string, 0, len(resourcePicks))
+	for name := range resourcePicks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// waitEdge is an edge of the wait-for graph: a process blocked on a resource another one holds, or
+// under the ceiling protocol on the ceiling of a resource another one holds.
+type waitEdge struct {
+	waiter, holder int64
+	resource       string
+	ceiling        bool
+}
+
+func (e waitEdge) String() string {
+	if e.ceiling {
+		return fmt.Sprintf("%d below ceiling of %s held by %d", e.waiter, e.resource, e.holder)
+	}
+
+	return fmt.Sprintf("%d waits for %s held by %d", e.waiter, e.resource, e.holder)
+}
+
+// resourceTick is what happened in one time unit of a resource simulation.
+type resourceTick struct {
+	time    int64
+	running int64 // idlePID when no process ran
+	waits   []waitEdge
+}
+
+// resourceRun is the outcome of a resource simulation: its ticks and, if it deadlocked, when and the
+// cycle of the wait-for graph. Completion is 0 for the processes that never completed; blocked is the
+// time each process spent blocked by another.
+type resourceRun struct {
+	ticks      []resourceTick
+	gantt      []TimeSlice
+	completion []int64
+	blocked    []int64
+	deadlock   []waitEdge
+	deadlockAt int64
+}
+
+// resourceSim is the state of a resource simulation.
+type resourceSim struct {
+	processes []Process
+	progress  []int64
+	next      []int    // index of each process's next resource event
+	waiting   []string // the resource each process is blocked on, empty when it is not
+	holder    map[string]int
+	queue     map[string][]int // processes blocked on each resource, in the order they blocked
+	protocol  lockingProtocol
+	ceilings  map[string]int64
+	blocker   []int    // the process whose ceiling blocks each process this tick, -1 for none
+	below     []string // the resource of that ceiling
+}
+
+// release frees a resource, handing it to the process that has waited for it longest.
+func (sim *resourceSim) release(resource string) {
+	delete(sim.holder, resource)
+	if waiters := sim.queue[resource]; len(waiters) > 0 {
+		i := waiters[0]
+		sim.queue[resource] = waiters[1:]
+		sim.holder[resource] = i
+		sim.waiting[i] = ""
+		sim.next[i]++
+	}
+}
+
+// events makes the resource events of process i due at its progress, stopping at releases only
+// unless requests is set. It reports false when a request blocked the process.
+func (sim *resourceSim) events(i int, requests bool) bool {
+	events := sim.processes[i].Resources
+	for sim.next[i] < len(events) && events[sim.next[i]].At == sim.progress[i] {
+		e := events[sim.next[i]]
+		switch {
+		case e.Release:
+			sim.next[i]++
+			sim.release(e.Resource)
+		case !requests:
+			return true
+		default:
+			if _, held := sim.holder[e.Resource]; held {
+				sim.waiting[i] = e.Resource
+				sim.queue[e.Resource] = append(sim.queue[e.Resource], i)
+				return false
+			}
+			if h, resource := sim.ceilingBlocker(i); h >= 0 {
+				sim.blocker[i], sim.below[i] = h, resource
+				return false
+			}
+			sim.holder[e.Resource] = i
+			sim.next[i]++
+		}
+	}
+
+	return true
+}
+
+// waitFor returns the edges of the wait-for graph, by waiter in input order.
+func (sim *resourceSim) waitFor() []waitEdge {
+	edges := make([]waitEdge, 0)
+	for i, resource := range sim.waiting {
+		switch {
+		case resource != "":
+			edges = append(edges, waitEdge{
+				waiter:   sim.processes[i].ProcessID,
+				holder:   sim.processes[sim.holder[resource]].ProcessID,
+				resource: resource,
+			})
+		case sim.blocker[i] >= 0:
+			edges = append(edges, waitEdge{
+				waiter:   sim.processes[i].ProcessID,
+				holder:   sim.processes[sim.blocker[i]].ProcessID,
+				resource: sim.below[i],
+				ceiling:  true,
+			})
+		}
+	}
+
+	return edges
+}
+
+// findCycle returns a cycle of the wait-for graph, starting at its first waiter in input order, or
+// nil. Every process waits for at most one other, so following the edges finds any cycle.
+func findCycle(edges []waitEdge) []waitEdge {
+	out := make(map[int64]waitEdge, len(edges))
+	for _, e := range edges {
+		out[e.waiter] = e
+	}
+	for _, start := range edges {
+		seen := make(map[int64]int)
+		path := make([]waitEdge, 0)
+		for e, ok := start, true; ok; e, ok = out[e.holder] {
+			if at, visited := seen[e.waiter]; visited {
+				return path[at:]
+			}
+			seen[e.waiter] = len(path)
+			path = append(path, e)
+		}
+	}
+
+	return nil
+}
+
+// simulateResources runs the processes a time unit at a time, picking among the ready ones with
+// pick by their priority under the locking protocol. Before a process runs it makes its resource
+// requests due, blocking if a resource is held or, under the ceiling protocol, if its priority is not
+// above the ceiling of every resource the others hold; after it runs it makes its releases due, and
+// completing releases everything it holds. The wait-for graph is built every tick, and the
+// simulation stops at the first cycle, a deadlock.
+func simulateResources(processes []Process, pick func([]Process, []int, int) int, protocol lockingProtocol) resourceRun {
+	var (
+		sim = resourceSim{
+			processes: processes,
+			progress:  make([]int64, len(processes)),
+			next:      make([]int, len(processes)),
+			waiting:   make([]string, len(processes)),
+			holder:    make(map[string]int),
+			queue:     make(map[string][]int),
+			protocol:  protocol,
+			ceilings:  resourceCeilings(processes),
+			blocker:   make([]int, len(processes)),
+			below:     make([]string, len(processes)),
+		}
+		run       = resourceRun{completion: make([]int64, len(processes)), blocked: make([]int64, len(processes))}
+		released  = newReadiness(processes, run.completion, ClassPolicyShared)
+		completed = 0
+		last      = -1
+	)
+	for t := int64(0); completed < len(processes); t++ {
+		running := -1
+		for i := range sim.blocker {
+			sim.blocker[i], sim.below[i] = -1, ""
+		}
+		for {
+			ready := make([]int, 0)
+			for i := range processes {
+				if released.ready(i, t) && run.completion[i] == 0 && sim.blockedBy(i) < 0 {
+					ready = append(ready, i)
+				}
+			}
+			if len(ready) == 0 {
+				break
+			}
+			if i := pick(sim.prioritized(), ready, last); sim.events(i, true) {
+				running = i
+				break
+			}
+		}
+		for i := range processes {
+			if sim.blockedBy(i) >= 0 {
+				run.blocked[i]++
+			}
+		}
+
+		tick := resourceTick{time: t, running: idlePID, waits: sim.waitFor()}
+		if running >= 0 {
+			tick.running = processes[running].ProcessID
+		}
+		run.ticks = append(run.ticks, tick)
+		if cycle := findCycle(tick.waits); cycle != nil {
+			run.deadlock, run.deadlockAt = cycle, t
+			break
+		}
+		if n := len(run.gantt); n > 0 && run.gantt[n-1].PID == tick.running {
+			run.gantt[n-1].Stop = t + 1
+		} else {
+			run.gantt = append(run.gantt, TimeSlice{PID: tick.running, Start: t, Stop: t + 1})
+		}
+		if running < 0 {
+			continue
+		}
+
+		last = running
+		sim.progress[running]++
+		sim.events(running, false)
+		if sim.progress[running] == processes[running].BurstDuration {
+			run.completion[running] = t + 1
+			completed++
+			held := make([]string, 0)
+			for resource, i := range sim.holder {
+				if i == running {
+					held = append(held, resource)
+				}
+			}
+			sort.Strings(held)
+			for _, resource := range held {
+				sim.release(resource)
+			}
+		}
+	}
+
+	return run
+}
+
+// outputResourceRun outputs the GANTT chart of a resource simulation, a table of what ran and what
+// was blocked on what every tick, and the deadlock if there was one.
+func outputResourceRun(w io.Writer, title string, processes []Process, run resourceRun, style string) {
+	outputTitle(w, title)
+	outputGantt(w, run.gantt, timeBase{}, Report{})
+
+	_, _ = fmt.Fprintln(w, "Wait-for graph")
+	rows := make([][]string, len(run.ticks))
+	for i, tick := range run.ticks {
+		running := "IDLE"
+		if tick.running != idlePID {
+			running = fmt.Sprint(tick.running)
+		}
+		waits := make([]string, len(tick.waits))
+		for j, e := range tick.waits {
+			waits[j] = e.String()
+		}
+		rows[i] = []string{fmt.Sprint(tick.time), running, strings.Join(waits, "\n")}
+	}
+	alignment := []int{tablewriter.ALIGN_RIGHT, tablewriter.ALIGN_RIGHT, tablewriter.ALIGN_LEFT}
+	outputTable(w, style, []string{"Time", "Running", "Blocked"}, rows, nil, alignment)
+
+	_, _ = fmt.Fprintln(w, "Blocking")
+	rows = make([][]string, len(processes))
+	for i, p := range processes {
+		completion := "-"
+		if run.completion[i] > 0 {
+			completion = fmt.Sprint(run.completion[i])
+		}
+		rows[i] = []string{fmt.Sprint(p.ProcessID), fmt.Sprint(p.Priority), completion, fmt.Sprint(run.blocked[i])}
+	}
+	outputTable(w, style, []string{"ID", "Priority", "Completion", "Blocked"}, rows, nil, nil)
+
+	if run.deadlock == nil {
+		var end int64
+		for _, c := range run.completion {
+			if c > end {
+				end = c
+			}
+		}
+		_, _ = fmt.Fprintf(w, "No deadlock: every process completed by time %d\n", end)
+		return
+	}
+	cycle := make([]string, 0, len(run.deadlock)+1)
+	for _, e := range run.deadlock {
+		cycle = append(cycle, fmt.Sprint(e.waiter))
+	}
+	cycle = append(cycle, cycle[0])
+	_, _ = fmt.Fprintf(w, "Deadlock at time %d: %s\n", run.deadlockAt, strings.Join(cycle, " -> "))
+	for _, e := range run.deadlock {
+		_, _ = fmt.Fprintf(w, "  %s\n", e)
+	}
+	stuck := make([]string, 0)
+	for i, p := range processes {
+		if run.completion[i] == 0 {
+			stuck = append(stuck, fmt.Sprint(p.ProcessID))
+		}
+	}
+	_, _ = fmt.Fprintf(w, "Never completed: %s\n", strings.Join(stuck, ", "))
+}
+
+// deadlockCommand simulates the resource requests and releases of a workload and detects deadlock.
+func deadlockCommand(w io.Writer, args []string) error {
+	fs := newFlagSet("deadlock")
+	name := fs.String("algorithm", "rr", "scheduling of the ready processes: "+strings.Join(resourcePickNames(), ", "))
+	protocolName := fs.String("protocol", string(lockingNone), "locking protocol against priority inversion: "+strings.Join(lockingProtocolNames(), ", "))
+	priorityOrder := addPriorityOrderFlag(fs)
+	tableStyle := fs.String("table-style", "ascii", "text table style: "+strings.Join(tableStyleNames(), ", "))
+	logging := addLogFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := logging(); err != nil {
+		return err
+	}
+	pick, ok := resourcePicks[*name]
+	if !ok {
+		return fmt.Errorf("%w: unknown algorithm %q (want %s)", ErrInvalidArgs, *name, strings.Join(resourcePickNames(), ", "))
+	}
+	protocol, err := parseLockingProtocol(*protocolName)
+	if err != nil {
+		return err
+	}
+	order, err := priorityOrder()
+	if err != nil {
+		return err
+	}
+	if _, ok := tableStyles[*tableStyle]; !ok && *tableStyle != tsvStyle {
+		return fmt.Errorf("%w: unknown table style %q", ErrInvalidArgs, *tableStyle)
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: must give a scheduling file", ErrInvalidArgs)
+	}
+	processes, err := loadWorkload(fs.Arg(0), timeBase{})
+	if err != nil {
+		return err
+	}
+
+	run := simulateResources(order.ranked(processes), pick, protocol)
+	if run.deadlock != nil {
+		logs.Info("deadlock", "time", run.deadlockAt, "processes", len(run.deadlock))
+	}
+	title := "Resource allocation (" + *name + ")"
+	if protocol != lockingNone {
+		title = "Resource allocation (" + *name + ", priority " + string(protocol) + ")"
+	}
+	outputResourceRun(w, title, processes, run, *tableStyle)
+
+	return nil
+}
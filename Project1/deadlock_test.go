@@ -0,0 +1,172 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// crossedLocks is two processes taking two resources in opposite orders.
+const crossedLocks = `1,4,0
+resource: pid=1 at=0 request=A
+resource: pid=1 at=2 request=B
+resource: pid=1 at=3 release=B
+2,4,0
+resource: pid=2 at=0 request=B
+resource: pid=2 at=2 request=A
+`
+
+func Test_loadProcesses_resources(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		input   string
+		want    []ResourceEvent
+		wantErr error
+	}{
+		{
+			name:  "request and release",
+			input: "1,5,0\nresource: pid=1 at=1 request=disk\nresource: pid=1 at=3 release=disk\n",
+			want:  []ResourceEvent{{At: 1, Resource: "disk"}, {At: 3, Resource: "disk", Release: true}},
+		},
+		{name: "before the process", input: "resource: pid=1 at=1 request=A\n1,5,0\n", wantErr: ErrInvalidArgs},
+		{name: "missing at", input: "1,5,0\nresource: pid=1 request=A\n", wantErr: ErrInvalidArgs},
+		{name: "both request and release", input: "1,5,0\nresource: pid=1 at=1 request=A release=A\n", wantErr: ErrInvalidArgs},
+		{name: "unknown field", input: "1,5,0\nresource: pid=1 at=1 lock=A\n", wantErr: ErrInvalidArgs},
+		{name: "past the burst", input: "1,5,0\nresource: pid=1 at=6 request=A\n", wantErr: ErrValidation},
+		{name: "request at the end", input: "1,5,0\nresource: pid=1 at=5 request=A\n", wantErr: ErrValidation},
+		{name: "out of order", input: "1,5,0\nresource: pid=1 at=3 request=A\nresource: pid=1 at=1 request=B\n", wantErr: ErrValidation},
+		{name: "requests twice", input: "1,5,0\nresource: pid=1 at=1 request=A\nresource: pid=1 at=2 request=A\n", wantErr: ErrValidation},
+		{name: "releases unheld", input: "1,5,0\nresource: pid=1 at=1 release=A\n", wantErr: ErrValidation},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			processes, err := loadProcesses(strings.NewReader(tt.input))
+			if err == nil {
+				err = validateProcesses(processes)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(processes[0].Resources, tt.want) {
+				t.Errorf("Resources = %v, want %v", processes[0].Resources, tt.want)
+			}
+		})
+	}
+}
+
+func Test_simulateResources(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		input          string
+		algorithm      string
+		wantDeadlockAt int64
+		wantCycle      []int64
+		wantCompletion []int64
+	}{
+		{name: "rr deadlocks", input: crossedLocks, algorithm: "rr", wantDeadlockAt: 4, wantCycle: []int64{1, 2}, wantCompletion: []int64{0, 0}},
+		{name: "fcfs finishes", input: crossedLocks, algorithm: "fcfs", wantCompletion: []int64{4, 8}},
+		{
+			name:           "blocked until released",
+			input:          "1,3,0\nresource: pid=1 at=0 request=A\n2,2,0\nresource: pid=2 at=0 request=A\nresource: pid=2 at=1 release=A\n",
+			algorithm:      "rr",
+			wantCompletion: []int64{3, 5},
+		},
+		{
+			name:           "released before the end",
+			input:          "1,3,0\nresource: pid=1 at=0 request=A\nresource: pid=1 at=1 release=A\n2,2,0\nresource: pid=2 at=0 request=A\n",
+			algorithm:      "fcfs",
+			wantCompletion: []int64{3, 5},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			processes, err := loadProcesses(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			run := simulateResources(processes, resourcePicks[tt.algorithm], lockingNone)
+			var cycle []int64
+			for _, e := range run.deadlock {
+				cycle = append(cycle, e.waiter)
+			}
+			if !reflect.DeepEqual(cycle, tt.wantCycle) || run.deadlockAt != tt.wantDeadlockAt {
+				t.Errorf("deadlock = %v at %d, want %v at %d", cycle, run.deadlockAt, tt.wantCycle, tt.wantDeadlockAt)
+			}
+			if !reflect.DeepEqual(run.completion, tt.wantCompletion) {
+				t.Errorf("completion = %v, want %v", run.completion, tt.wantCompletion)
+			}
+		})
+	}
+}
+
+func Test_findCycle(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		edges []waitEdge
+		want  []int64
+	}{
+		{name: "none", edges: nil},
+		{name: "chain", edges: []waitEdge{{waiter: 1, holder: 2}, {waiter: 2, holder: 3}}},
+		{name: "cycle behind a chain", edges: []waitEdge{{waiter: 1, holder: 2}, {waiter: 2, holder: 3}, {waiter: 3, holder: 2}}, want: []int64{2, 3}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got []int64
+			for _, e := range findCycle(tt.edges) {
+				got = append(got, e.waiter)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("findCycle() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_deadlockCommand(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "locks.csv")
+	if err := os.WriteFile(path, []byte(crossedLocks), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		args    []string
+		want    []string
+		wantErr error
+	}{
+		{name: "deadlock", args: []string{path}, want: []string{"Deadlock at time 4: 1 -> 2 -> 1", "1 waits for B held by 2", "Never completed: 1, 2"}},
+		{name: "no deadlock", args: []string{"-algorithm", "fcfs", path}, want: []string{"No deadlock: every process completed by time 8"}},
+		{name: "bad algorithm", args: []string{"-algorithm", "sjf", path}, wantErr: ErrInvalidArgs},
+		{name: "bad protocol", args: []string{"-protocol", "mutex", path}, wantErr: ErrInvalidArgs},
+		{name: "no file", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			err := deadlockCommand(&w, tt.args)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(w.String(), want) {
+					t.Errorf("deadlockCommand() = %s, want it to contain %q", w.String(), want)
+				}
+			}
+		})
+	}
+}
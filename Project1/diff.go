@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// diffValue formats a value of a result file, numbers compactly.
+func diffValue(v any) string {
+	if n, ok := v.(float64); ok {
+		return strconv.FormatFloat(n, 'g', 6, 64)
+	}
+
+	return fmt.Sprint(v)
+}
+
+// compareFields returns a line per key of a or b whose values differ, numbers by more than the
+// tolerance, in key order, with how much a number changed from a to b.
+func compareFields(prefix string, a, b map[string]any, tolerance float64) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	diffs := make([]string, 0)
+	for _, k := range keys {
+		av, aok := a[k]
+		bv, bok := b[k]
+		an, anum := av.(float64)
+		bn, bnum := bv.(float64)
+		switch {
+		case !aok:
+			diffs = append(diffs, fmt.Sprintf("%s%s: none -> %s", prefix, k, diffValue(bv)))
+		case !bok:
+			diffs = append(diffs, fmt.Sprintf("%s%s: %s -> none", prefix, k, diffValue(av)))
+		case anum && bnum:
+			if math.Abs(bn-an) > tolerance {
+				diffs = append(diffs, fmt.Sprintf("%s%s: %s -> %s (%+g)", prefix, k, diffValue(an), diffValue(bn),
+					math.Round((bn-an)*1e6)/1e6))
+			}
+		case fmt.Sprint(av) != fmt.Sprint(bv):
+			diffs = append(diffs, fmt.Sprintf("%s%s: %v -> %v", prefix, k, av, bv))
+		}
+	}
+
+	return diffs
+}
+
+// compareGantt returns a line if the GANTT charts differ, with their number of slices and the first
+// slice that differs.
+func compareGantt(prefix string, a, b []apiSlice) []string {
+	slice := func(slices []apiSlice, i int) string {
+		if i >= len(slices) {
+			return "none"
+		}
+		return fmt.Sprintf("P%d %d-%d", slices[i].PID, slices[i].Start, slices[i].Stop)
+	}
+	for i := 0; i < len(a) || i < len(b); i++ {
+		if as, bs := slice(a, i), slice(b, i); as != bs {
+			return []string{fmt.Sprintf("%sgantt: %d -> %d slices, first differing at [%d]: %s -> %s", prefix, len(a), len(b), i, as, bs)}
+		}
+	}
+
+	return nil
+}
+
+// diffResultSets returns a line per difference from the results of a to those of b, matched by
+// algorithm and their processes by ID: the algorithms and processes only one has, the summary and
+// process timing fields that differ, and the first slice their GANTT charts differ in. Results
+// without a GANTT chart are not compared on it.
+func diffResultSets(a, b []expectedResult, aName, bName string, tolerance float64) []string {
+	diffs := make([]string, 0)
+	inB := make(map[string]expectedResult, len(b))
+	for _, res := range b {
+		inB[res.Algorithm] = res
+	}
+	inA := make(map[string]bool, len(a))
+	for _, ra := range a {
+		inA[ra.Algorithm] = true
+		prefix := ra.Algorithm + ": "
+		rb, ok := inB[ra.Algorithm]
+		if !ok {
+			diffs = append(diffs, fmt.Sprintf("%sonly in %s", prefix, aName))
+			continue
+		}
+		diffs = append(diffs, compareFields(prefix+"summary ", ra.Summary, rb.Summary, tolerance)...)
+		if ra.Gantt != nil && rb.Gantt != nil {
+			diffs = append(diffs, compareGantt(prefix, ra.Gantt, rb.Gantt)...)
+		}
+
+		timing := make(map[int64]map[string]any, len(rb.Processes))
+		ids := make([]int64, 0, len(rb.Processes))
+		for _, p := range rb.Processes {
+			id, _ := p["id"].(float64)
+			timing[int64(id)] = p
+			ids = append(ids, int64(id))
+		}
+		for _, pa := range ra.Processes {
+			id, _ := pa["id"].(float64)
+			pb, ok := timing[int64(id)]
+			if !ok {
+				diffs = append(diffs, fmt.Sprintf("%sprocess %d: only in %s", prefix, int64(id), aName))
+				continue
+			}
+			delete(timing, int64(id))
+			diffs = append(diffs, compareFields(fmt.Sprintf("%sprocess %d ", prefix, int64(id)), pa, pb, tolerance)...)
+		}
+		for _, id := range ids {
+			if _, ok := timing[id]; ok {
+				diffs = append(diffs, fmt.Sprintf("%sprocess %d: only in %s", prefix, id, bName))
+			}
+		}
+	}
+	for _, rb := range b {
+		if !inA[rb.Algorithm] {
+			diffs = append(diffs, fmt.Sprintf("%s: only in %s", rb.Algorithm, bName))
+		}
+	}
+
+	return diffs
+}
+
+// diffCommand compares two saved result files, in the form of the /simulate response that verify
+// -update writes, listing every difference from the first to the second.
+func diffCommand(w io.Writer, args []string) error {
+	fs := newFlagSet("diff")
+	tolerance := fs.Float64("tolerance", 1e-6, "largest difference of numbers still counted as equal, e.g. 0.01 for rounded results")
+	logging := addLogFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := logging(); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		return fmt.Errorf("%w: must give two result files", ErrInvalidArgs)
+	}
+	a, err := loadExpected(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	b, err := loadExpected(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	diffs := diffResultSets(a, b, fs.Arg(0), fs.Arg(1), *tolerance)
+	if len(diffs) > 0 {
+		_, _ = fmt.Fprintln(w, strings.Join(diffs, "\n"))
+		return fmt.Errorf("%w: %d differences between %s and %s", ErrMismatch, len(diffs), fs.Arg(0), fs.Arg(1))
+	}
+	_, _ = fmt.Fprintf(w, "OK: %s and %s match\n", fs.Arg(0), fs.Arg(1))
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_diffCommand(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	workload := write("workload.csv", "1,5,0,2\n2,9,3,1\n3,6,6,3\n")
+	reference := filepath.Join(dir, "reference.json")
+	if err := verifyCommand(&bytes.Buffer{}, []string{"-update", "-algorithms", "fcfs,rr", workload, reference}); err != nil {
+		t.Fatalf("verifyCommand(-update) = %v", err)
+	}
+	student := write("student.json", `{"results": [{"algorithm": "fcfs", "summary": {"average_wait": 3.33}, "processes": [{"id": 3, "wait": 8}]}]}`)
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    []string
+		wantErr error
+	}{
+		{name: "same", args: []string{reference, reference}, want: []string{"OK: "}},
+		{
+			name: "student",
+			args: []string{"-tolerance", "0.01", reference, student},
+			want: []string{
+				"fcfs: summary average_turnaround: 10 -> none",
+				"fcfs: process 1: only in " + reference,
+				"fcfs: summary average_response: 3.33333 -> none",
+				"fcfs: process 3 turnaround: 14 -> none",
+				"rr: only in " + reference,
+			},
+			wantErr: ErrMismatch,
+		},
+		{name: "one file", args: []string{reference}, wantErr: ErrInvalidArgs},
+		{name: "no results", args: []string{reference, write("empty.json", `{"results": []}`)}, wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			if err := diffCommand(&w, tt.args); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("diffCommand() error = %v, want %v", err, tt.wantErr)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(w.String(), want) {
+					t.Errorf("diffCommand() = %q, want it to contain %q", w.String(), want)
+				}
+			}
+		})
+	}
+}
+
+func Test_diffResultSets(t *testing.T) {
+	t.Parallel()
+	a := []expectedResult{{
+		Algorithm: "rr",
+		Summary:   map[string]any{"average_wait": 3.0, "context_switches": 4.0},
+		Gantt:     []apiSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 4}},
+		Processes: []map[string]any{{"id": 1.0, "wait": 0.0}, {"id": 2.0, "wait": 2.0}},
+	}}
+	b := []expectedResult{{
+		Algorithm: "rr",
+		Summary:   map[string]any{"average_wait": 3.5, "context_switches": 4.0},
+		Gantt:     []apiSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 3}, {PID: 2, Start: 3, Stop: 4}},
+		Processes: []map[string]any{{"id": 2.0, "wait": 3.0}, {"id": 4.0, "wait": 1.0}},
+	}, {Algorithm: "sjf"}}
+	want := []string{
+		"rr: summary average_wait: 3 -> 3.5 (+0.5)",
+		"rr: gantt: 2 -> 3 slices, first differing at [1]: P2 2-4 -> P2 2-3",
+		"rr: process 1: only in a.json",
+		"rr: process 2 wait: 2 -> 3 (+1)",
+		"rr: process 4: only in b.json",
+		"sjf: only in b.json",
+	}
+	if got := diffResultSets(a, b, "a.json", "b.json", 1e-6); !reflect.DeepEqual(got, want) {
+		t.Errorf("diffResultSets() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,345 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// diskAlgorithm is a disk head scheduling algorithm selectable with -algorithms. run returns the
+// cylinders the head visits in order, not counting where it starts.
+type diskAlgorithm struct {
+	name  string
+	title string
+	run   func(requests []int64, head int64, d disk) []int64
+}
+
+// disk is the geometry and initial direction of the head.
+type disk struct {
+	cylinders int64 // cylinders are numbered 0 to cylinders-1
+	up        bool  // the head starts moving towards higher cylinders
+}
+
+var diskAlgorithms = []diskAlgorithm{
+	{name: "fcfs", title: "First-come, first-serve", run: diskFCFS},
+	{name: "sstf", title: "Shortest-seek-time-first", run: diskSSTF},
+	{name: "scan", title: "SCAN (elevator)", run: diskSCAN},
+	{name: "cscan", title: "C-SCAN", run: diskCSCAN},
+	{name: "look", title: "LOOK", run: diskLOOK},
+}
+
+// diskFCFS serves the requests in the order they were made.
+func diskFCFS(requests []int64, _ int64, _ disk) []int64 {
+	return append([]int64(nil), requests...)
+}
+
+// diskSSTF serves the pending request closest to the head next; the earlier request wins a tie.
+func diskSSTF(requests []int64, head int64, _ disk) []int64 {
+	var (
+		pending = append([]int64(nil), requests...)
+		seq     = make([]int64, 0, len(requests))
+	)
+	for len(pending) > 0 {
+		best := 0
+		for i := range pending {
+			if abs(pending[i]-head) < abs(pending[best]-head) {
+				best = i
+			}
+		}
+		head = pending[best]
+		seq = append(seq, head)
+		pending = append(pending[:best], pending[best+1:]...)
+	}
+
+	return seq
+}
+
+// splitRequests sorts the requests into those at or beyond the head in its direction, nearest
+// first, and those behind it, nearest first.
+func splitRequests(requests []int64, head int64, up bool) (ahead, behind []int64) {
+	sorted := append([]int64(nil), requests...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	for _, c := range sorted {
+		if c == head || (c > head) == up {
+			ahead = append(ahead, c)
+		} else {
+			behind = append(behind, c)
+		}
+	}
+	if !up {
+		reverse(ahead)
+	} else {
+		reverse(behind)
+	}
+
+	return ahead, behind
+}
+
+func reverse(s []int64) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+// diskSCAN sweeps the head to the end of the disk in its direction, serving requests on the way,
+// then back for the ones behind it. It does not run to the end when nothing is left behind.
+func diskSCAN(requests []int64, head int64, d disk) []int64 {
+	ahead, behind := splitRequests(requests, head, d.up)
+	seq := append([]int64(nil), ahead...)
+	if len(behind) > 0 {
+		seq = append(seq, d.end())
+		seq = append(seq, behind...)
+	}
+
+	return seq
+}
+
+// diskCSCAN sweeps the head to the end of the disk in its direction, then returns to the other end
+// and sweeps the same way again for the requests behind it.
+func diskCSCAN(requests []int64, head int64, d disk) []int64 {
+	ahead, behind := splitRequests(requests, head, d.up)
+	seq := append([]int64(nil), ahead...)
+	if len(behind) > 0 {
+		reverse(behind)
+		seq = append(seq, d.end(), disk{cylinders: d.cylinders, up: !d.up}.end())
+		seq = append(seq, behind...)
+	}
+
+	return seq
+}
+
+// diskLOOK is SCAN turning around at the last request in each direction instead of the disk's end.
+func diskLOOK(requests []int64, head int64, d disk) []int64 {
+	ahead, behind := splitRequests(requests, head, d.up)
+
+	return append(ahead, behind...)
+}
+
+// end returns the last cylinder in the head's direction.
+func (d disk) end() int64 {
+	if d.up {
+		return d.cylinders - 1
+	}
+
+	return 0
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
+
+// headMovement returns the total number of cylinders crossed visiting a seek sequence from head.
+func headMovement(head int64, seq []int64) int64 {
+	var total int64
+	for _, c := range seq {
+		total += abs(c - head)
+		head = c
+	}
+
+	return total
+}
+
+// loadDiskRequests reads cylinder requests from a CSV with any number of cylinders per line.
+func loadDiskRequests(r io.Reader) ([]int64, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading cylinder requests", err)
+	}
+	requests := make([]int64, 0)
+	for i, row := range rows {
+		for _, field := range row {
+			if strings.TrimSpace(field) == "" {
+				continue
+			}
+			c, err := strconv.ParseInt(strings.TrimSpace(field), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidArgs, i+1, err)
+			}
+			requests = append(requests, c)
+		}
+	}
+
+	return requests, nil
+}
+
+// validateDisk checks the head and every request are on the disk.
+func validateDisk(requests []int64, head int64, d disk) error {
+	if d.cylinders <= 0 {
+		return fmt.Errorf("%w: cylinders must be positive", ErrInvalidArgs)
+	}
+	if head < 0 || head >= d.cylinders {
+		return fmt.Errorf("%w: head %d is not on a disk of %d cylinders", ErrValidation, head, d.cylinders)
+	}
+	if len(requests) == 0 {
+		return fmt.Errorf("%w: no cylinder requests", ErrValidation)
+	}
+	for i, c := range requests {
+		if c < 0 || c >= d.cylinders {
+			return fmt.Errorf("%w: request %d: cylinder %d is not on a disk of %d cylinders", ErrValidation, i+1, c, d.cylinders)
+		}
+	}
+
+	return nil
+}
+
+// diskChartWidth is the width in characters of a seek chart's cylinder axis.
+const diskChartWidth = 60
+
+// outputSeekChart outputs the head position at every step as a row with a mark scaled along the
+// cylinders, so the sweeps show as the head moving left and right.
+func outputSeekChart(w io.Writer, head int64, seq []int64, d disk) {
+	_, _ = fmt.Fprintln(w, "Seek sequence")
+	column := func(c int64) int {
+		if d.cylinders == 1 {
+			return 0
+		}
+		return int(math.Round(float64(c) / float64(d.cylinders-1) * (diskChartWidth - 1)))
+	}
+	_, _ = fmt.Fprintf(w, "%6s |0%*d\n", "", diskChartWidth-1, d.cylinders-1)
+	for _, c := range append([]int64{head}, seq...) {
+		_, _ = fmt.Fprintf(w, "%6d |%s*\n", c, strings.Repeat(" ", column(c)))
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+// outputDiskResult outputs one algorithm's seek chart, a table of its moves and its totals given:
+// • an output writer
+// • a title for the chart
+// • the number of requests served, which the average seek distance is over
+// • where the head started and the cylinders it visited
+// • the disk and the table style
+func outputDiskResult(w io.Writer, title string, served int, head int64, seq []int64, d disk, style string) {
+	outputTitle(w, title)
+	outputSeekChart(w, head, seq, d)
+
+	rows := make([][]string, len(seq))
+	from := head
+	for i, c := range seq {
+		rows[i] = []string{fmt.Sprint(i + 1), fmt.Sprint(from), fmt.Sprint(c), fmt.Sprint(abs(c - from))}
+		from = c
+	}
+	total := headMovement(head, seq)
+	footer := []string{"", "", "Total", fmt.Sprint(total)}
+	outputTable(w, style, []string{"Step", "From", "To", "Distance"}, rows, footer, nil)
+	_, _ = fmt.Fprintf(w, "Total head movement: %d cylinders\n", total)
+	_, _ = fmt.Fprintf(w, "Average seek distance: %.2f\n", float64(total)/float64(served))
+	_, _ = fmt.Fprintln(w)
+}
+
+// outputDiskComparison outputs a table of each algorithm's total head movement, marking the least.
+func outputDiskComparison(w io.Writer, titles []string, totals []int64, served int, style string) {
+	_, _ = fmt.Fprintln(w, "Comparison")
+	best := totals[0]
+	for _, t := range totals {
+		if t < best {
+			best = t
+		}
+	}
+	rows := make([][]string, len(titles))
+	for i := range titles {
+		mark := ""
+		if totals[i] == best {
+			mark = " *"
+		}
+		average := float64(totals[i]) / float64(served)
+		rows[i] = []string{titles[i], fmt.Sprint(totals[i]) + mark, fmt.Sprintf("%.2f", average)}
+	}
+	alignment := []int{tablewriter.ALIGN_LEFT, tablewriter.ALIGN_RIGHT, tablewriter.ALIGN_RIGHT}
+	outputTable(w, style, []string{"Algorithm", "Total head movement", "Average seek distance"}, rows, nil, alignment)
+	_, _ = fmt.Fprintln(w, "* least head movement")
+}
+
+// selectDiskAlgorithms looks up a comma separated list of disk algorithm names; "all" selects every one.
+func selectDiskAlgorithms(names string) ([]diskAlgorithm, error) {
+	if names == "all" {
+		return diskAlgorithms, nil
+	}
+	selected := make([]diskAlgorithm, 0)
+	for _, name := range strings.Split(names, ",") {
+		found := false
+		for _, a := range diskAlgorithms {
+			if a.name == strings.TrimSpace(name) {
+				selected = append(selected, a)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: unknown disk algorithm %q", ErrInvalidArgs, name)
+		}
+	}
+
+	return selected, nil
+}
+
+// diskCommand simulates disk head scheduling of the cylinder requests in a CSV file.
+func diskCommand(w io.Writer, args []string) error {
+	fs := newFlagSet("disk")
+	names := fs.String("algorithms", "all", "comma separated disk algorithms: fcfs, sstf, scan, cscan, look")
+	head := fs.Int64("head", 0, "cylinder the head starts at")
+	cylinders := fs.Int64("cylinders", 200, "number of cylinders")
+	direction := fs.String("direction", "up", "direction the head starts moving in: up or down")
+	tableStyle := fs.String("table-style", "ascii", "text table style: "+strings.Join(tableStyleNames(), ", "))
+	logging := addLogFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := logging(); err != nil {
+		return err
+	}
+	if *direction != "up" && *direction != "down" {
+		return fmt.Errorf("%w: unknown direction %q (want up or down)", ErrInvalidArgs, *direction)
+	}
+	if _, ok := tableStyles[*tableStyle]; !ok && *tableStyle != tsvStyle {
+		return fmt.Errorf("%w: unknown table style %q", ErrInvalidArgs, *tableStyle)
+	}
+	selected, err := selectDiskAlgorithms(*names)
+	if err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: must give a cylinder request file", ErrInvalidArgs)
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("%v: error opening cylinder request file", err)
+	}
+	defer f.Close()
+	requests, err := loadDiskRequests(f)
+	if err != nil {
+		return err
+	}
+	d := disk{cylinders: *cylinders, up: *direction == "up"}
+	if err := validateDisk(requests, *head, d); err != nil {
+		return err
+	}
+
+	var (
+		titles = make([]string, len(selected))
+		totals = make([]int64, len(selected))
+	)
+	for i, a := range selected {
+		seq := a.run(requests, *head, d)
+		outputDiskResult(w, a.title, len(requests), *head, seq, d, *tableStyle)
+		titles[i], totals[i] = a.title, headMovement(*head, seq)
+	}
+	if len(selected) > 1 {
+		outputDiskComparison(w, titles, totals, len(requests), *tableStyle)
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_diskAlgorithms(t *testing.T) {
+	t.Parallel()
+	// The request queue of the textbook example: the head starts at cylinder 53 of 200.
+	requests := []int64{98, 183, 37, 122, 14, 124, 65, 67}
+	tests := []struct {
+		name      string
+		algorithm func(requests []int64, head int64, d disk) []int64
+		up        bool
+		want      []int64
+		wantTotal int64
+	}{
+		{name: "fcfs", algorithm: diskFCFS, want: requests, wantTotal: 640},
+		{name: "sstf", algorithm: diskSSTF, want: []int64{65, 67, 37, 14, 98, 122, 124, 183}, wantTotal: 236},
+		{name: "scan down", algorithm: diskSCAN, want: []int64{37, 14, 0, 65, 67, 98, 122, 124, 183}, wantTotal: 236},
+		{name: "scan up", algorithm: diskSCAN, up: true, want: []int64{65, 67, 98, 122, 124, 183, 199, 37, 14}, wantTotal: 331},
+		{name: "cscan up", algorithm: diskCSCAN, up: true, want: []int64{65, 67, 98, 122, 124, 183, 199, 0, 14, 37}, wantTotal: 382},
+		{name: "cscan down", algorithm: diskCSCAN, want: []int64{37, 14, 0, 199, 183, 124, 122, 98, 67, 65}, wantTotal: 386},
+		{name: "look down", algorithm: diskLOOK, want: []int64{37, 14, 65, 67, 98, 122, 124, 183}, wantTotal: 208},
+		{name: "look up", algorithm: diskLOOK, up: true, want: []int64{65, 67, 98, 122, 124, 183, 37, 14}, wantTotal: 299},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := tt.algorithm(requests, 53, disk{cylinders: 200, up: tt.up})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("seek sequence = %v, want %v", got, tt.want)
+			}
+			if total := headMovement(53, got); total != tt.wantTotal {
+				t.Errorf("headMovement() = %d, want %d", total, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func Test_diskSCAN_nothingBehind(t *testing.T) {
+	t.Parallel()
+	got := diskSCAN([]int64{60, 70}, 53, disk{cylinders: 200, up: true})
+	if want := []int64{60, 70}; !reflect.DeepEqual(got, want) {
+		t.Errorf("diskSCAN() = %v, want %v", got, want)
+	}
+}
+
+func Test_loadDiskRequests(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		input   string
+		want    []int64
+		wantErr error
+	}{
+		{name: "one per line", input: "98\n183\n37\n", want: []int64{98, 183, 37}},
+		{name: "many per line", input: "98, 183,37\n122\n", want: []int64{98, 183, 37, 122}},
+		{name: "bad number", input: "98,x\n", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := loadDiskRequests(strings.NewReader(tt.input))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadDiskRequests() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_diskCommand(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "requests.csv")
+	if err := os.WriteFile(path, []byte("98,183,37,122,14,124,65,67\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		args    []string
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "compare",
+			args: []string{"-head", "53", "-direction", "down", "-algorithms", "sstf,look", path},
+			want: []string{"Total head movement: 236 cylinders", "Total head movement: 208 cylinders", "| LOOK                     |               208 * |"},
+		},
+		{name: "head off the disk", args: []string{"-head", "200", path}, wantErr: ErrValidation},
+		{name: "request off the disk", args: []string{"-cylinders", "100", path}, wantErr: ErrValidation},
+		{name: "bad direction", args: []string{"-direction", "left", path}, wantErr: ErrInvalidArgs},
+		{name: "bad algorithm", args: []string{"-algorithms", "elevator", path}, wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			err := diskCommand(&w, tt.args)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(w.String(), want) {
+					t.Errorf("diskCommand() = %s, want it to contain %q", w.String(), want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// outputDOT outputs the results as a Graphviz digraph with one cluster per result. Every process is a
+// node labeled with its arrival and completion, and an edge leads from each process to the one that
+// ran next, labeled with the switch time. Edges where the outgoing process had not finished are
+// preemptions and drawn dashed and red.
+func outputDOT(w io.Writer, results []result, _ Report) error {
+	_, _ = fmt.Fprintln(w, "digraph schedule {")
+	_, _ = fmt.Fprintln(w, "  rankdir=LR;")
+	_, _ = fmt.Fprintln(w, "  node [shape=box, style=filled, fontcolor=white];")
+	for i, res := range results {
+		s := res.schedule
+		node := func(pid int64) string { return fmt.Sprintf("r%d_p%d", i+1, pid) }
+		completion := make(map[int64]int64, len(s.Processes))
+
+		_, _ = fmt.Fprintf(w, "  subgraph cluster_%d {\n", i+1)
+		_, _ = fmt.Fprintf(w, "    label=%s;\n", strconv.Quote(res.title))
+		for j, p := range s.Processes {
+			completion[p.ProcessID] = s.Completion[j]
+			label := fmt.Sprintf("P%d\narrival %s\ncompletion %s", p.ProcessID, s.formatTime(p.ArrivalTime), s.formatTime(s.Completion[j]))
+			_, _ = fmt.Fprintf(w, "    %s [label=%s, fillcolor=%q];\n", node(p.ProcessID), strconv.Quote(label), pidColor(p.ProcessID))
+		}
+		for j := 1; j < len(s.Gantt); j++ {
+			from, to := s.Gantt[j-1], s.Gantt[j]
+			if from.PID == to.PID {
+				continue
+			}
+			style := ""
+			if completion[from.PID] > from.Stop {
+				style = ", style=dashed, color=red"
+			}
+			_, _ = fmt.Fprintf(w, "    %s -> %s [label=\"%s\"%s];\n", node(from.PID), node(to.PID), s.formatTime(to.Start), style)
+		}
+		_, _ = fmt.Fprintln(w, "  }")
+	}
+	_, err := fmt.Fprintln(w, "}")
+
+	return err
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_outputDOT(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 3, Priority: 2},
+		{ProcessID: 1, ArrivalTime: 1, BurstDuration: 1, Priority: 1},
+	}
+	results := []result{{title: "Priority", schedule: preemptivePriority(processes, Options{})}}
+
+	var w bytes.Buffer
+	if err := outputDOT(&w, results, Report{}); err != nil {
+		t.Fatal(err)
+	}
+	want := `digraph schedule {
+  rankdir=LR;
+  node [shape=box, style=filled, fontcolor=white];
+  subgraph cluster_1 {
+    label="Priority";
+    r1_p2 [label="P2\narrival 0\ncompletion 4", fillcolor="#e15759"];
+    r1_p1 [label="P1\narrival 1\ncompletion 2", fillcolor="#f28e2b"];
+    r1_p2 -> r1_p1 [label="1", style=dashed, color=red];
+    r1_p1 -> r1_p2 [label="2"];
+  }
+}
+`
+	if got := w.String(); got != want {
+		t.Errorf("outputDOT() = %q, want %q", got, want)
+	}
+}
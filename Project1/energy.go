@@ -0,0 +1,217 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The energy model estimates what a schedule costs on a CPU with dynamic voltage and frequency scaling
+// (DVFS). Frequencies are fractions of the maximum; at frequency f a process runs f times as fast and
+// draws f³ of the dynamic power it draws at the maximum, plus a static power whenever the CPU is on.
+// Power is in units of the dynamic power at the maximum frequency, so energy is in those units times
+// time units. The schedule is replayed in its own order, with every run stretched by its frequency.
+
+// Governor names how the CPU frequency follows the load.
+type Governor string
+
+const (
+	GovernorPerformance Governor = "performance"  // always the maximum frequency, held while idle
+	GovernorOndemand    Governor = "ondemand"     // the lowest frequency that keeps up with the recent load
+	GovernorRaceToIdle  Governor = "race-to-idle" // the maximum frequency, then deep sleep while idle
+)
+
+// governors are the governors, in the order they are listed.
+var governors = []Governor{GovernorPerformance, GovernorOndemand, GovernorRaceToIdle}
+
+const (
+	staticPower      = 0.1  // power the CPU draws whenever it is on
+	performanceIdle  = 0.3  // idle power with the clock held at the maximum frequency
+	deepSleepPower   = 0.02 // idle power in deep sleep
+	ondemandWindow   = 10   // time units of recent load the ondemand governor looks at
+	ondemandUpThresh = 0.8  // load from which the ondemand governor wants the maximum frequency
+)
+
+// parseGovernor validates a governor name; empty turns the energy model off.
+func parseGovernor(name string) (Governor, error) {
+	if name == "" {
+		return "", nil
+	}
+	for _, g := range governors {
+		if Governor(name) == g {
+			return g, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: unknown governor %q", ErrInvalidArgs, name)
+}
+
+func governorNames() []string {
+	names := make([]string, len(governors))
+	for i, g := range governors {
+		names[i] = string(g)
+	}
+
+	return names
+}
+
+// parseFrequencies parses a comma separated list of frequency levels, fractions of the maximum above 0
+// and up to 1, into ascending order.
+func parseFrequencies(list string) ([]float64, error) {
+	levels := make([]float64, 0)
+	for _, field := range strings.Split(list, ",") {
+		f, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil || f <= 0 || f > 1 {
+			return nil, fmt.Errorf("%w: frequency %q must be above 0 and at most 1", ErrInvalidArgs, field)
+		}
+		levels = append(levels, f)
+	}
+	sort.Float64s(levels)
+
+	return levels, nil
+}
+
+// EnergyModel configures the energy estimate of a schedule: a governor, none to estimate nothing,
+// picking among frequency levels in ascending order.
+type EnergyModel struct {
+	Governor    Governor
+	Frequencies []float64
+}
+
+// idlePower returns the power the CPU draws while idle under the model's governor.
+func (m EnergyModel) idlePower() float64 {
+	switch m.Governor {
+	case GovernorPerformance:
+		return performanceIdle
+	case GovernorRaceToIdle:
+		return deepSleepPower
+	default:
+		return staticPower
+	}
+}
+
+// frequency returns the frequency the governor runs at given the fraction of the recent time the CPU
+// was busy.
+func (m EnergyModel) frequency(load float64) float64 {
+	max := m.Frequencies[len(m.Frequencies)-1]
+	if m.Governor != GovernorOndemand || load >= ondemandUpThresh {
+		return max
+	}
+	for _, f := range m.Frequencies {
+		if f >= load/ondemandUpThresh*max {
+			return f
+		}
+	}
+
+	return max
+}
+
+// energyRun is a schedule replayed under frequency scaling, in time units.
+type energyRun struct {
+	energy     float64   // busy plus idle energy
+	busy       float64   // energy spent running processes
+	frequency  float64   // time-weighted average frequency while running
+	completion []float64 // index-aligned with the schedule's processes
+	makespan   float64
+	turnaround float64 // average
+}
+
+// replayEnergy replays the schedule under its energy model a tick at a time: each tick of a run takes
+// 1/f ticks at the frequency the governor picks, given how busy the CPU was over the ondemand window
+// of the schedule before it, and nothing starts before it did in the schedule.
+func (s Schedule) replayEnergy() energyRun {
+	var (
+		m       = s.Energy
+		run     = energyRun{completion: make([]float64, len(s.Processes))}
+		first   = s.FirstArrival()
+		busy    = make([]int64, s.LastCompletion()-first+1) // busy[t-first] counts busy ticks before t
+		index   = make(map[int64]int, len(s.Processes))
+		window  = ondemandWindow * s.ticksPerUnit()
+		now     = float64(first)
+		idle    float64
+		running float64
+	)
+	for i, p := range s.Processes {
+		index[p.ProcessID] = i
+	}
+	for t := first; t < s.LastCompletion(); t++ {
+		busy[t-first+1] = busy[t-first]
+		if s.running(t) != idlePID {
+			busy[t-first+1]++
+		}
+	}
+	for _, ts := range s.Gantt {
+		if start := float64(ts.Start); start > now {
+			idle += start - now
+			now = start
+		}
+		for t := ts.Start; t < ts.Stop; t++ {
+			from := t - window
+			if from < first {
+				from = first
+			}
+			load := 0.0
+			if t > from {
+				load = float64(busy[t-first]-busy[from-first]) / float64(t-from)
+			}
+			f := m.frequency(load)
+			now += 1 / f
+			running += 1 / f
+			run.busy += (staticPower + f*f*f) / f
+			run.frequency += 1
+		}
+		run.completion[index[ts.PID]] = s.inUnits(now)
+	}
+	if running > 0 {
+		run.frequency /= running
+	}
+	run.busy = s.inUnits(run.busy)
+	run.energy = run.busy + s.inUnits(idle)*m.idlePower()
+	run.makespan = s.inUnits(now - float64(first))
+	for i, p := range s.Processes {
+		run.turnaround += run.completion[i] - s.inUnits(float64(p.ArrivalTime))
+	}
+	run.turnaround /= float64(len(s.Processes))
+
+	return run
+}
+
+// hasEnergyModel reports whether the schedule's energy is estimated.
+func (s Schedule) hasEnergyModel() bool {
+	return s.Energy.Governor != ""
+}
+
+// EnergyUse returns the estimated energy of the schedule under its energy model, 0 without one.
+func (s Schedule) EnergyUse() float64 {
+	if !s.hasEnergyModel() {
+		return 0
+	}
+
+	return s.replayEnergy().energy
+}
+
+// ScaledTurnaround returns the average turnaround of the schedule replayed under its energy model, its
+// plain average turnaround without one.
+func (s Schedule) ScaledTurnaround() float64 {
+	if !s.hasEnergyModel() {
+		return s.AverageTurnaround()
+	}
+
+	return s.replayEnergy().turnaround
+}
+
+// energySummary formats the energy estimate and the latency under frequency scaling, or nothing
+// without an energy model.
+func (s Schedule) energySummary() []string {
+	if !s.hasEnergyModel() {
+		return nil
+	}
+	run := s.replayEnergy()
+
+	return []string{
+		fmt.Sprintf("Energy (%s): %.2f (running %.2f, idle %.2f), average frequency %.2f",
+			s.Energy.Governor, run.energy, run.busy, run.energy-run.busy, run.frequency),
+		fmt.Sprintf("With frequency scaling: makespan %.2f, average turnaround %.2f", run.makespan, run.turnaround),
+	}
+}
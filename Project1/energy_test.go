@@ -0,0 +1,88 @@
+package main
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSchedule_replayEnergy(t *testing.T) {
+	t.Parallel()
+	processes, err := loadProcesses(strings.NewReader("1,2,0,1\n2,2,4,1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		governor       Governor
+		wantEnergy     float64
+		wantFrequency  float64
+		wantMakespan   float64
+		wantTurnaround float64
+	}{
+		{governor: GovernorPerformance, wantEnergy: 5, wantFrequency: 1, wantMakespan: 6, wantTurnaround: 2},
+		{governor: GovernorRaceToIdle, wantEnergy: 4.44, wantFrequency: 1, wantMakespan: 6, wantTurnaround: 2},
+		{governor: GovernorOndemand, wantEnergy: 3.85, wantFrequency: 0.8, wantMakespan: 6, wantTurnaround: 2.5},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(string(tt.governor), func(t *testing.T) {
+			t.Parallel()
+			opts := Options{Energy: EnergyModel{Governor: tt.governor, Frequencies: []float64{0.5, 1}}}
+			run := algorithms[1].schedule(processes, opts).replayEnergy()
+			for _, v := range []struct {
+				name      string
+				got, want float64
+			}{
+				{"energy", run.energy, tt.wantEnergy},
+				{"frequency", run.frequency, tt.wantFrequency},
+				{"makespan", run.makespan, tt.wantMakespan},
+				{"turnaround", run.turnaround, tt.wantTurnaround},
+			} {
+				if math.Abs(v.got-v.want) > 1e-9 {
+					t.Errorf("%s = %v, want %v", v.name, v.got, v.want)
+				}
+			}
+		})
+	}
+}
+
+func Test_parseFrequencies(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		list    string
+		want    []float64
+		wantErr bool
+	}{
+		{list: "1", want: []float64{1}},
+		{list: "1, 0.5,0.75", want: []float64{0.5, 0.75, 1}},
+		{list: "0", wantErr: true},
+		{list: "1.5", wantErr: true},
+		{list: "fast", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseFrequencies(tt.list)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseFrequencies(%q) error = %v, wantErr %v", tt.list, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseFrequencies(%q) = %v, want %v", tt.list, got, tt.want)
+		}
+	}
+}
+
+func Test_parseGovernor(t *testing.T) {
+	t.Parallel()
+	for _, name := range governorNames() {
+		if g, err := parseGovernor(name); err != nil || string(g) != name {
+			t.Errorf("parseGovernor(%q) = %q, %v", name, g, err)
+		}
+	}
+	if g, err := parseGovernor(""); err != nil || g != "" {
+		t.Errorf("parseGovernor(\"\") = %q, %v, want none", g, err)
+	}
+	if _, err := parseGovernor("powersave"); err == nil {
+		t.Error("parseGovernor(\"powersave\") succeeded, want an error")
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Kinds of scheduling events, in the order events at the same time are listed.
+const (
+	eventCompletion = "completion"
+	eventArrival    = "arrival"
+	eventPreemption = "preemption"
+	eventQuantum    = "quantum expiry"
+	eventDispatch   = "dispatch"
+)
+
+var eventOrder = map[string]int{
+	eventCompletion: 0,
+	eventArrival:    1,
+	eventPreemption: 2,
+	eventQuantum:    3,
+	eventDispatch:   4,
+}
+
+// schedEvent is one scheduling decision or state change of a process.
+type schedEvent struct {
+	Time   int64
+	Kind   string
+	PID    int64
+	Detail string
+}
+
+// Events derives the scheduling events of the schedule from its processes and time slices: every
+// arrival, dispatch and completion, and every slice that ended with work left, which is a quantum
+// expiry when the scheduler has a quantum and a preemption otherwise.
+func (s Schedule) Events() []schedEvent {
+	var (
+		events    = make([]schedEvent, 0, len(s.Processes)*3+len(s.Gantt)*2)
+		remaining = make(map[int64]int64, len(s.Processes))
+	)
+	for _, p := range s.Processes {
+		remaining[p.ProcessID] = p.BurstDuration
+		events = append(events, schedEvent{
+			Time:   p.ArrivalTime,
+			Kind:   eventArrival,
+			PID:    p.ProcessID,
+			Detail: fmt.Sprintf("burst %s, priority %d", s.formatTime(p.BurstDuration), p.Priority),
+		})
+	}
+	for _, ts := range s.Gantt {
+		events = append(events, schedEvent{
+			Time:   ts.Start,
+			Kind:   eventDispatch,
+			PID:    ts.PID,
+			Detail: "remaining " + s.formatTime(remaining[ts.PID]),
+		})
+		remaining[ts.PID] -= ts.Stop - ts.Start
+		end := schedEvent{Time: ts.Stop, Kind: eventCompletion, PID: ts.PID}
+		if remaining[ts.PID] > 0 {
+			end.Kind = eventPreemption
+			if s.Quantum > 0 {
+				end.Kind = eventQuantum
+			}
+			end.Detail = "remaining " + s.formatTime(remaining[ts.PID])
+		}
+		events = append(events, end)
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].Time != events[j].Time {
+			return events[i].Time < events[j].Time
+		}
+		return eventOrder[events[i].Kind] < eventOrder[events[j].Kind]
+	})
+
+	return events
+}
+
+// outputEvents outputs the schedule's events, one line each.
+func outputEvents(w io.Writer, s Schedule) {
+	_, _ = fmt.Fprintln(w, "Scheduling events")
+	for _, e := range s.Events() {
+		line := fmt.Sprintf("%6s  %-14s  process %d", s.formatTime(e.Time), e.Kind, e.PID)
+		if e.Detail != "" {
+			line += " (" + e.Detail + ")"
+		}
+		_, _ = fmt.Fprintln(w, line)
+	}
+	_, _ = fmt.Fprintln(w)
+}
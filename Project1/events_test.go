@@ -0,0 +1,57 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSchedule_Events(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1, Priority: 1},
+	}
+	tests := []struct {
+		name string
+		s    Schedule
+		want []schedEvent
+	}{
+		{
+			name: "preemptive priority",
+			s:    preemptivePriority(processes, Options{}),
+			want: []schedEvent{
+				{Time: 0, Kind: eventArrival, PID: 1, Detail: "burst 3, priority 2"},
+				{Time: 0, Kind: eventDispatch, PID: 1, Detail: "remaining 3"},
+				{Time: 1, Kind: eventArrival, PID: 2, Detail: "burst 1, priority 1"},
+				{Time: 1, Kind: eventPreemption, PID: 1, Detail: "remaining 2"},
+				{Time: 1, Kind: eventDispatch, PID: 2, Detail: "remaining 1"},
+				{Time: 2, Kind: eventCompletion, PID: 2},
+				{Time: 2, Kind: eventDispatch, PID: 1, Detail: "remaining 2"},
+				{Time: 4, Kind: eventCompletion, PID: 1},
+			},
+		},
+		{
+			name: "round-robin",
+			s:    roundRobin(processes, Options{}).merged(),
+			want: []schedEvent{
+				{Time: 0, Kind: eventArrival, PID: 1, Detail: "burst 3, priority 2"},
+				{Time: 0, Kind: eventDispatch, PID: 1, Detail: "remaining 3"},
+				{Time: 1, Kind: eventArrival, PID: 2, Detail: "burst 1, priority 1"},
+				{Time: 1, Kind: eventQuantum, PID: 1, Detail: "remaining 2"},
+				{Time: 1, Kind: eventDispatch, PID: 2, Detail: "remaining 1"},
+				{Time: 2, Kind: eventCompletion, PID: 2},
+				{Time: 2, Kind: eventDispatch, PID: 1, Detail: "remaining 2"},
+				{Time: 4, Kind: eventCompletion, PID: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.s.Events(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Events() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
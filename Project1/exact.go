@@ -0,0 +1,55 @@
+package main
+
+import "fmt"
+
+// exactMetrics recomputes the completion, first dispatch, turnaround and wait of every process from
+// the GANTT slices alone: a process completes when its last slice stops, and waits for the part of
+// its turnaround it spent not running. Unlike the schedulers, it does not clamp negative waits.
+func (s *Schedule) exactMetrics() {
+	index := make(map[int64]int, len(s.Processes))
+	for i, p := range s.Processes {
+		index[p.ProcessID] = i
+		s.Completion[i], s.FirstRun[i] = 0, -1
+	}
+	ran := make([]int64, len(s.Processes))
+	for _, ts := range s.Gantt {
+		i, ok := index[ts.PID]
+		if !ok {
+			continue
+		}
+		ran[i] += ts.Stop - ts.Start
+		if ts.Stop > s.Completion[i] {
+			s.Completion[i] = ts.Stop
+		}
+		if s.FirstRun[i] < 0 || ts.Start < s.FirstRun[i] {
+			s.FirstRun[i] = ts.Start
+		}
+	}
+	for i, p := range s.Processes {
+		s.Turnaround[i] = s.Completion[i] - p.ArrivalTime
+		s.Wait[i] = s.Turnaround[i] - ran[i]
+	}
+}
+
+// inexactMetrics returns an error naming the first process of the results whose GANTT slices do not
+// add up to its burst or whose exact wait is negative, which only a scheduler bug can cause.
+func inexactMetrics(results []result) error {
+	for _, res := range results {
+		s := res.schedule
+		ran := make(map[int64]int64, len(s.Processes))
+		for _, ts := range s.Gantt {
+			ran[ts.PID] += ts.Stop - ts.Start
+		}
+		for i, p := range s.Processes {
+			switch {
+			case ran[p.ProcessID] != p.BurstDuration:
+				return fmt.Errorf("%s: process %d ran for %s of its %s burst", res.title, p.ProcessID,
+					s.formatTime(ran[p.ProcessID]), s.formatTime(p.BurstDuration))
+			case s.Wait[i] < 0:
+				return fmt.Errorf("%s: process %d has a negative wait of %s", res.title, p.ProcessID, s.formatTime(s.Wait[i]))
+			}
+		}
+	}
+
+	return nil
+}
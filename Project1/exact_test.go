@@ -0,0 +1,69 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSchedule_exactMetrics(t *testing.T) {
+	t.Parallel()
+	processes, err := loadProcesses(strings.NewReader("1,5,0,2\n2,9,3,1\n3,6,6,3\n4,2,30,1\n5,3,31,2,deps:4"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, a := range algorithms {
+		a := a
+		t.Run(a.name, func(t *testing.T) {
+			t.Parallel()
+			want := a.schedule(processes, Options{})
+			got := a.schedule(processes, Options{ExactMetrics: true})
+			for name, pair := range map[string][2][]int64{
+				"Completion": {got.Completion, want.Completion},
+				"FirstRun":   {got.FirstRun, want.FirstRun},
+				"Wait":       {got.Wait, want.Wait},
+				"Turnaround": {got.Turnaround, want.Turnaround},
+			} {
+				if !reflect.DeepEqual(pair[0], pair[1]) {
+					t.Errorf("exact %s = %v, want %v", name, pair[0], pair[1])
+				}
+			}
+			if err := inexactMetrics([]result{{title: a.title, schedule: got}}); err != nil {
+				t.Errorf("inexactMetrics() = %v", err)
+			}
+		})
+	}
+}
+
+func Test_inexactMetrics(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: 1, BurstDuration: 3}, {ProcessID: 2, ArrivalTime: 4, BurstDuration: 2}}
+	tests := []struct {
+		name    string
+		gantt   []TimeSlice
+		wantErr string
+	}{
+		{name: "exact", gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 4, Stop: 6}}},
+		{name: "before arrival", gantt: []TimeSlice{{PID: 2, Start: 0, Stop: 2}, {PID: 1, Start: 2, Stop: 5}}, wantErr: "process 2 has a negative wait of -4"},
+		{name: "short", gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 4, Stop: 6}}, wantErr: "process 1 ran for 2 of its 3 burst"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s := newSchedule(processes)
+			s.Gantt = tt.gantt
+			s.exactMetrics()
+			err := inexactMetrics([]result{{title: "Test", schedule: s}})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("inexactMetrics() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("inexactMetrics() = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
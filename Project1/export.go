@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// algorithmName returns the name of the algorithm with a title, or the title of one not selectable
+// by name, such as a plugin or policy.
+func algorithmName(title string) string {
+	for _, a := range allAlgorithms() {
+		if a.title == title {
+			return a.name
+		}
+	}
+
+	return title
+}
+
+// outputJSON outputs the results in the form of the /simulate response: each algorithm's summary,
+// GANTT slices and the timing of every process, times in ticks. It is the form verify and diff read.
+func outputJSON(w io.Writer, results []result, _ Report) error {
+	resp := simulateResponse{Results: make([]apiResult, len(results))}
+	for i, res := range results {
+		resp.Results[i] = newAPIResult(algorithmName(res.title), res)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(resp)
+}
+
+// csvHeader names the columns of the CSV format.
+var csvHeader = []string{"algorithm", "id", "priority", "burst", "arrival", "wait", "turnaround",
+	"normalized_turnaround", "bounded_slowdown", "response", "completion", "switches", "preemptions"}
+
+// outputCSV outputs a row per process of every result with all the schedule table's metrics and its
+// preemptions, times in time units and numbers unrounded.
+func outputCSV(w io.Writer, results []result, r Report) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write(csvHeader)
+	for _, res := range results {
+		s := res.schedule
+		base := s.timeBase()
+		normalized, slowdown, response := s.NormalizedTurnaround(), s.BoundedSlowdown(r.SlowdownBound), s.Response()
+		switches, preemptions := s.SwitchesPerProcess(), s.Preemptions()
+		for _, i := range s.rowOrder(r) {
+			p := s.Processes[i]
+			_ = cw.Write([]string{
+				res.title,
+				strconv.FormatInt(p.ProcessID, 10),
+				strconv.FormatInt(p.Priority, 10),
+				csvTime(p.BurstDuration, base),
+				csvTime(p.ArrivalTime, base),
+				csvTime(s.Wait[i], base),
+				csvTime(s.Turnaround[i], base),
+				strconv.FormatFloat(normalized[i], 'f', -1, 64),
+				strconv.FormatFloat(slowdown[i], 'f', -1, 64),
+				csvTime(response[i], base),
+				csvTime(s.Completion[i], base),
+				fmt.Sprint(switches[i]),
+				fmt.Sprint(preemptions[i]),
+			})
+		}
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func Test_outputCSV(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1, Priority: 1},
+	}
+	results := []result{{title: "Shortest-job-first", schedule: sjf(processes, Options{})}}
+	var w bytes.Buffer
+	if err := outputCSV(&w, results, Report{SlowdownBound: 1}); err != nil {
+		t.Fatal(err)
+	}
+	want := "algorithm,id,priority,burst,arrival,wait,turnaround,normalized_turnaround,bounded_slowdown,response,completion,switches,preemptions\n" +
+		"Shortest-job-first,1,2,4,0,1,5,1.25,1.25,0,5,1,1\n" +
+		"Shortest-job-first,2,1,1,1,0,1,1,1,0,2,1,0\n"
+	if got := w.String(); got != want {
+		t.Errorf("outputCSV() = %q, want %q", got, want)
+	}
+}
+
+func Test_outputJSON(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1, Priority: 1},
+	}
+	results := []result{
+		{title: "Shortest-job-first", schedule: sjf(processes, Options{})},
+		{title: "Policy min remaining", schedule: fcfs(processes, Options{})},
+	}
+	var w bytes.Buffer
+	if err := outputJSON(&w, results, Report{}); err != nil {
+		t.Fatal(err)
+	}
+	var got simulateResponse
+	if err := json.Unmarshal(w.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if names := []string{got.Results[0].Algorithm, got.Results[1].Algorithm}; !reflect.DeepEqual(names, []string{"sjf", "Policy min remaining"}) {
+		t.Errorf("outputJSON() algorithms = %q", names)
+	}
+	want := []apiProcessTiming{
+		{ID: 1, Burst: 4, Priority: 2, Wait: 1, Turnaround: 5, Completion: 5, Preemptions: 1},
+		{ID: 2, Arrival: 1, Burst: 1, Priority: 1, Turnaround: 1, Completion: 2},
+	}
+	if !reflect.DeepEqual(got.Results[0].Processes, want) {
+		t.Errorf("outputJSON() processes = %+v, want %+v", got.Results[0].Processes, want)
+	}
+}
+
+func Test_Schedule_Preemptions(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2},
+	}
+	s := roundRobin(processes, Options{Quantum: 1})
+	if got, want := s.Preemptions(), []int{2, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Preemptions() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import "math"
+
+// feedbackMaxLevel caps the feedback queues so the quantum of the lowest, 2^feedbackMaxLevel time
+// units, cannot overflow.
+const feedbackMaxLevel = 30
+
+// FeedbackConfig configures the feedback scheduler, the textbook one when zero.
+type FeedbackConfig struct {
+	Queues  int   // number of queues, 0 for feedbackMaxLevel+1
+	Quantum int64 // quantum of the highest queue in ticks, doubling in each lower one, 0 for a time unit
+}
+
+// maxLevel returns the lowest queue of the configuration.
+func (c FeedbackConfig) maxLevel() int {
+	if c.Queues > 0 && c.Queues-1 < feedbackMaxLevel {
+		return c.Queues - 1
+	}
+
+	return feedbackMaxLevel
+}
+
+// feedback schedules the processes with the textbook feedback scheduler: processes enter queue 0, the
+// highest, and run first come first served within the highest queue holding a ready process for a
+// quantum of 2^i time units in queue i. A process that uses up its quantum is preempted and demoted
+// to the next queue, unless no other process is ready, in which case it keeps its queue. The options'
+// feedback configuration can change the number of queues and the quantum of the highest.
+func feedback(processes []Process, opts Options) Schedule {
+	var (
+		serviceTime int64
+		remTime     = make([]int64, len(processes))
+		admitted    = make([]bool, len(processes))
+		level       = make([]int, len(processes))
+		queues      = make([][]int, 1)
+		s           = newSchedule(processes)
+		ready       = newReadiness(processes, s.Completion, opts.ClassPolicy)
+	)
+	s.Quantum = opts.ticksPerUnit()
+	if opts.Feedback.Quantum > 0 {
+		s.Quantum = opts.Feedback.Quantum
+	}
+	maxLevel := opts.Feedback.maxLevel()
+	completed := 0
+	count := len(processes)
+
+	for i := range processes { // Populating the remaining time array that will be updated along the way
+		remTime[i] = processes[i].BurstDuration
+	}
+	admit := func() { // queue every process that has become ready, in input order
+		for i := range processes {
+			if !admitted[i] && ready.released(i, serviceTime) {
+				admitted[i] = true
+				queues[0] = append(queues[0], i)
+			}
+		}
+	}
+	others := func(running int) bool { // whether a process other than the running one is queued
+		for _, queue := range queues {
+			for _, i := range queue {
+				if i != running {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	for completed != count && !opts.cancelled() {
+		admit()
+		current, at := -1, 0
+		for l := 0; l < len(queues) && current < 0; l++ {
+			for k, i := range queues[l] {
+				if ready.ready(i, serviceTime) {
+					current, at = i, k
+					break
+				}
+			}
+		}
+		if current < 0 {
+			idle := ready.idleUntil(serviceTime)
+			s.addIdle(serviceTime, idle)
+			serviceTime = idle
+			continue
+		}
+		l := level[current]
+		queues[l] = append(queues[l][:at], queues[l][at+1:]...)
+
+		if remTime[current] == processes[current].BurstDuration {
+			s.FirstRun[current] = serviceTime
+		}
+		run := remTime[current]
+		if s.Quantum <= math.MaxInt64>>l && s.Quantum<<l < run { // a quantum too long to shift never expires
+			run = s.Quantum << l
+		}
+		s.Gantt = opts.gantt.append(s.Gantt, TimeSlice{
+			PID:   processes[current].ProcessID,
+			Start: serviceTime,
+			Stop:  serviceTime + run,
+		})
+		serviceTime += run
+		remTime[current] -= run
+
+		if remTime[current] == 0 {
+			completed++
+			opts.progress.complete()
+			s.Completion[current] = serviceTime
+			s.Wait[current] = s.Completion[current] - processes[current].BurstDuration - processes[current].ArrivalTime
+			continue
+		}
+		admit()
+		if others(current) && l < maxLevel {
+			l++
+			level[current] = l
+			if l == len(queues) {
+				queues = append(queues, nil)
+			}
+		}
+		queues[l] = append(queues[l], current)
+	}
+
+	for i := range s.Wait {
+		s.Turnaround[i] = processes[i].BurstDuration + s.Wait[i]
+	}
+
+	return s
+}
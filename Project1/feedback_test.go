@@ -0,0 +1,102 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_feedback(t *testing.T) {
+	t.Parallel()
+	// The five process example of Stallings' Operating Systems, A to E as 1 to 5.
+	stallings := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3},
+		{ProcessID: 2, ArrivalTime: 2, BurstDuration: 6},
+		{ProcessID: 3, ArrivalTime: 4, BurstDuration: 4},
+		{ProcessID: 4, ArrivalTime: 6, BurstDuration: 5},
+		{ProcessID: 5, ArrivalTime: 8, BurstDuration: 2},
+	}
+	tests := []struct {
+		name           string
+		processes      []Process
+		opts           Options
+		wantCompletion []int64
+		wantTurnaround []int64
+		wantGantt      []TimeSlice
+	}{
+		{
+			name:           "textbook",
+			processes:      stallings,
+			wantCompletion: []int64{4, 17, 18, 20, 14},
+			wantTurnaround: []int64{4, 15, 14, 14, 6},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 3}, {PID: 1, Start: 3, Stop: 4},
+				{PID: 3, Start: 4, Stop: 5}, {PID: 2, Start: 5, Stop: 7}, {PID: 4, Start: 7, Stop: 8},
+				{PID: 5, Start: 8, Stop: 9}, {PID: 3, Start: 9, Stop: 11}, {PID: 4, Start: 11, Stop: 13},
+				{PID: 5, Start: 13, Stop: 14}, {PID: 2, Start: 14, Stop: 17}, {PID: 3, Start: 17, Stop: 18},
+				{PID: 4, Start: 18, Stop: 20},
+			},
+		},
+		{
+			name: "alone keeps its queue",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4},
+				{ProcessID: 2, ArrivalTime: 6, BurstDuration: 3},
+			},
+			wantCompletion: []int64{4, 9},
+			wantTurnaround: []int64{4, 3},
+			wantGantt:      []TimeSlice{{PID: 1, Start: 0, Stop: 4}, {PID: 2, Start: 6, Stop: 9}},
+		},
+		{
+			name: "quantum in time units",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 30},
+				{ProcessID: 2, ArrivalTime: 0, BurstDuration: 5},
+			},
+			opts:           Options{Resolution: 10},
+			wantCompletion: []int64{35, 15},
+			wantTurnaround: []int64{35, 15},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 10}, {PID: 2, Start: 10, Stop: 15}, {PID: 1, Start: 15, Stop: 35},
+			},
+		},
+		{
+			name: "long idle gap",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 1},
+				{ProcessID: 2, ArrivalTime: 1 << 40, BurstDuration: 1},
+			},
+			wantCompletion: []int64{1, 1<<40 + 1},
+			wantTurnaround: []int64{1, 1},
+			wantGantt:      []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1 << 40, Stop: 1<<40 + 1}},
+		},
+		{
+			name: "quantum too long to shift",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 1<<62 + 1},
+				{ProcessID: 2, ArrivalTime: 0, BurstDuration: 1},
+			},
+			opts:           Options{Feedback: FeedbackConfig{Quantum: 1 << 62}},
+			wantCompletion: []int64{1<<62 + 2, 1<<62 + 1},
+			wantTurnaround: []int64{1<<62 + 2, 1<<62 + 1},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1 << 62}, {PID: 2, Start: 1 << 62, Stop: 1<<62 + 1}, {PID: 1, Start: 1<<62 + 1, Stop: 1<<62 + 2},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s := feedback(tt.processes, tt.opts)
+			if !reflect.DeepEqual(s.Completion, tt.wantCompletion) {
+				t.Errorf("Completion = %v, want %v", s.Completion, tt.wantCompletion)
+			}
+			if !reflect.DeepEqual(s.Turnaround, tt.wantTurnaround) {
+				t.Errorf("Turnaround = %v, want %v", s.Turnaround, tt.wantTurnaround)
+			}
+			if got := s.merged().Gantt; !reflect.DeepEqual(got, tt.wantGantt) {
+				t.Errorf("Gantt = %v, want %v", got, tt.wantGantt)
+			}
+		})
+	}
+}
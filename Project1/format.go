@@ -0,0 +1,183 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// result is a titled schedule, the unit every output format renders.
+type result struct {
+	title    string
+	schedule Schedule
+}
+
+// formats are the output formats selectable with -format.
+var formats = map[string]func(w io.Writer, results []result, r Report) error{
+	"text":     outputText,
+	"markdown": outputMarkdown,
+	"html":     outputHTML,
+	"svg":      outputSVG,
+	"png":      outputPNG,
+	"mermaid":  outputMermaid,
+	"chrome":   outputChromeTrace,
+	"dot":      outputDOT,
+	"latex":    outputLaTeX,
+	"influx":   outputInflux,
+	"json":     outputJSON,
+	"csv":      outputCSV,
+}
+
+func formatNames() []string {
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// outputResults outputs the results in the report's format, to the report's output file if it has one
+// and to w otherwise. Contiguous slices of the same process are merged unless the report keeps them raw.
+func outputResults(w io.Writer, results []result, r Report) error {
+	format, ok := formats[r.Format]
+	if !ok {
+		return fmt.Errorf("%w: unknown format %q", ErrInvalidArgs, r.Format)
+	}
+	if !r.RawSlices {
+		merged := make([]result, len(results))
+		for i := range results {
+			merged[i] = result{title: results[i].title, schedule: results[i].schedule.merged()}
+		}
+		results = merged
+	}
+	if r.QueueOutput != "" {
+		if err := writeFile(r.QueueOutput, func(w io.Writer) error {
+			return writeQueueLengths(w, results, strings.HasSuffix(r.QueueOutput, ".json"))
+		}); err != nil {
+			return err
+		}
+	}
+	if r.GanttCSV != "" {
+		if err := writeFile(r.GanttCSV, func(w io.Writer) error { return writeGanttCSV(w, results) }); err != nil {
+			return err
+		}
+	}
+	switch {
+	case r.Output == "" || r.Output == "-":
+		return format(w, results, r)
+	case r.Split || isDirectory(r.Output):
+		return writeSplit(format, results, r)
+	default:
+		return writeFile(r.Output, func(w io.Writer) error { return format(w, results, r) })
+	}
+}
+
+// formatExtensions are the file extensions of the output formats whose name is not their extension.
+var formatExtensions = map[string]string{
+	"text":     ".txt",
+	"markdown": ".md",
+	"mermaid":  ".mmd",
+	"chrome":   ".json",
+	"latex":    ".tex",
+	"influx":   ".lp",
+}
+
+// isDirectory reports whether an output path names a directory: one that exists or ends in a slash.
+func isDirectory(path string) bool {
+	if strings.HasSuffix(path, "/") || strings.HasSuffix(path, string(filepath.Separator)) {
+		return true
+	}
+	info, err := os.Stat(path)
+
+	return err == nil && info.IsDir()
+}
+
+// slug turns a title into a file name part, e.g. "First-come, first-serve" into "first-come-first-serve".
+func slug(title string) string {
+	var b strings.Builder
+	dash := false
+	for _, c := range strings.ToLower(title) {
+		if c >= 'a' && c <= 'z' || c >= '0' && c <= '9' {
+			b.WriteRune(c)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// writeSplit writes each result to its own file, plus the whole report to a comparison file when the
+// report compares them. Into a directory output the files are named after the algorithms; otherwise
+// the algorithm is added to the output file name, so "out.txt" splits into "out-round-robin.txt" and so on.
+func writeSplit(format func(w io.Writer, results []result, r Report) error, results []result, r Report) error {
+	ext, ok := formatExtensions[r.Format]
+	if !ok {
+		ext = "." + r.Format
+	}
+	name := func(part string) string { return filepath.Join(r.Output, part+ext) }
+	if !isDirectory(r.Output) {
+		base := strings.TrimSuffix(r.Output, filepath.Ext(r.Output))
+		if filepath.Ext(r.Output) != "" {
+			ext = filepath.Ext(r.Output)
+		}
+		name = func(part string) string { return base + "-" + part + ext }
+	} else if err := os.MkdirAll(r.Output, 0o755); err != nil {
+		return fmt.Errorf("%v: error creating output directory", err)
+	}
+
+	single := r
+	single.Compare = false
+	for _, res := range results {
+		if err := writeFile(name(slug(res.title)), func(w io.Writer) error {
+			return format(w, []result{res}, single)
+		}); err != nil {
+			return err
+		}
+	}
+	if !r.Compare {
+		return nil
+	}
+
+	return writeFile(name("comparison"), func(w io.Writer) error { return format(w, results, r) })
+}
+
+// writeFile creates the file at path and writes it with write.
+func writeFile(path string, write func(w io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("%v: error creating output file", err)
+	}
+	if err := write(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	logs.Info("wrote report", "file", path)
+
+	return nil
+}
+
+// outputText outputs each result as a plain-text GANTT chart and tables.
+func outputText(w io.Writer, results []result, r Report) error {
+	if r.BurstHistogram > 0 && len(results) > 0 {
+		outputBurstHistogram(w, results[0].schedule, r)
+	}
+	for _, res := range results {
+		outputResult(w, res.title, res.schedule, r)
+	}
+	if r.Compare {
+		outputComparison(w, results, r)
+	}
+
+	return nil
+}
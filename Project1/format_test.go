@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func Test_slug(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{title: "First-come, first-serve", want: "first-come-first-serve"},
+		{title: "Round-robin (q=2)", want: "round-robin-q-2"},
+		{title: "  Priority  ", want: "priority"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+			if got := slug(tt.title); got != tt.want {
+				t.Errorf("slug() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_outputResults_split(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2}}
+	results := []result{
+		{title: "First-come, first-serve", schedule: fcfs(processes, Options{})},
+		{title: "Priority", schedule: fcfs(processes, Options{})},
+	}
+	tests := []struct {
+		name   string
+		output string
+		split  bool
+		format string
+		want   []string
+	}{
+		{
+			name:   "directory",
+			output: "results/",
+			format: "text",
+			want:   []string{"comparison.txt", "first-come-first-serve.txt", "priority.txt"},
+		},
+		{
+			name:   "split file",
+			output: "out.md",
+			split:  true,
+			format: "markdown",
+			want:   []string{"out-comparison.md", "out-first-come-first-serve.md", "out-priority.md"},
+		},
+		{
+			name:   "directory default extension",
+			output: "results/",
+			format: "svg",
+			want:   []string{"comparison.svg", "first-come-first-serve.svg", "priority.svg"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			output := filepath.Join(dir, tt.output)
+			if tt.output[len(tt.output)-1] == '/' {
+				output += "/"
+			}
+			r := Report{
+				SlowdownBound: defaultSlowdownBound,
+				Format:        tt.format,
+				Output:        output,
+				Split:         tt.split,
+				Compare:       true,
+			}
+			var w bytes.Buffer
+			if err := outputResults(&w, results, r); err != nil {
+				t.Fatal(err)
+			}
+			if w.Len() != 0 {
+				t.Errorf("outputResults() wrote %q to w, want nothing", w.String())
+			}
+			matches, err := filepath.Glob(filepath.Join(dir, "*", "*"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			top, err := filepath.Glob(filepath.Join(dir, "*.*"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, path := range append(matches, top...) {
+				if info, err := os.Stat(path); err == nil && !info.IsDir() {
+					got = append(got, filepath.Base(path))
+				}
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("files = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
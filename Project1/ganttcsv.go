@@ -0,0 +1,46 @@
+package main
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// ganttCSVHeader names the columns of a GANTT CSV file; multi-core charts add a cpu column.
+var ganttCSVHeader = []string{"algorithm", "pid", "start", "stop"}
+
+// csvTime formats a time in ticks as a plain number of time units, for plotting.
+func csvTime(t int64, base timeBase) string {
+	return strconv.FormatFloat(float64(t)/float64(base.ticksPerUnit()), 'f', -1, 64)
+}
+
+// writeGanttCSV writes the GANTT slices of every result as CSV with an algorithm, pid, start and stop
+// column, times in time units. The CPU idling between slices is left out.
+func writeGanttCSV(w io.Writer, results []result) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write(ganttCSVHeader)
+	for _, res := range results {
+		base := res.schedule.timeBase()
+		for _, ts := range res.schedule.Gantt {
+			_ = cw.Write([]string{res.title, strconv.FormatInt(ts.PID, 10), csvTime(ts.Start, base), csvTime(ts.Stop, base)})
+		}
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// writeMulticoreGanttCSV writes the GANTT slices of every CPU of a multi-core run as CSV, like
+// writeGanttCSV with a cpu column added, in order of start time on each CPU.
+func writeMulticoreGanttCSV(w io.Writer, title string, run multicoreRun, base timeBase) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write(append(ganttCSVHeader[:len(ganttCSVHeader):len(ganttCSVHeader)], "cpu"))
+	for c, gantt := range run.gantt {
+		for _, ts := range gantt {
+			_ = cw.Write([]string{title, strconv.FormatInt(ts.PID, 10), csvTime(ts.Start, base), csvTime(ts.Stop, base), strconv.Itoa(c)})
+		}
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
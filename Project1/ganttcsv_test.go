@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_writeGanttCSV(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 15, Priority: 1},
+		{ProcessID: 2, ArrivalTime: 20, BurstDuration: 5, Priority: 1},
+	}
+	tests := []struct {
+		name       string
+		resolution int64
+		want       string
+	}{
+		{
+			name: "whole",
+			want: "algorithm,pid,start,stop\nFCFS,1,0,15\nFCFS,2,20,25\n",
+		},
+		{
+			name:       "fractional",
+			resolution: 10,
+			want:       "algorithm,pid,start,stop\nFCFS,1,0,1.5\nFCFS,2,2,2.5\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			s := fcfs(processes, Options{})
+			s.Resolution = tt.resolution
+			if err := writeGanttCSV(&w, []result{{title: "FCFS", schedule: s}}); err != nil {
+				t.Fatal(err)
+			}
+			if got := w.String(); got != tt.want {
+				t.Errorf("writeGanttCSV() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_writeMulticoreGanttCSV(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2},
+	}
+	run := simulateMulticore(processes, []float64{1, 1}, multicorePicks["fcfs"], PlacementPerformance)
+	var w bytes.Buffer
+	if err := writeMulticoreGanttCSV(&w, "Multi-core", run, timeBase{}); err != nil {
+		t.Fatal(err)
+	}
+	want := "algorithm,pid,start,stop,cpu\nMulti-core,1,0,4,0\nMulti-core,2,0,2,1\n"
+	if got := w.String(); got != want {
+		t.Errorf("writeMulticoreGanttCSV() = %q, want %q", got, want)
+	}
+}
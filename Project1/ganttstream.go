@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// ganttEncoder streams the GANTT slices of a schedule to a writer as the scheduler makes them,
+// instead of keeping them, so simulations of millions of slices run in memory proportional to the
+// processes. Contiguous slices of the same process are run-length encoded into one line,
+// "pid,start,duration", under a "# algorithm" line. It counts the context switches the chart would
+// have shown. A nil encoder keeps the slices as usual.
+type ganttEncoder struct {
+	w        io.Writer
+	base     timeBase
+	pending  TimeSlice
+	started  bool
+	switches map[int64]int
+}
+
+// newGanttEncoder returns an encoder of the schedule of the named algorithm writing to w in the time
+// units of a time base, or nil when w is nil.
+func newGanttEncoder(w io.Writer, name string, base timeBase) *ganttEncoder {
+	if w == nil {
+		return nil
+	}
+	_, _ = fmt.Fprintf(w, "# %s\n", name)
+
+	return &ganttEncoder{w: w, base: base, switches: make(map[int64]int)}
+}
+
+// append adds a slice to the GANTT chart. Without an encoder it returns the slices with it appended;
+// with one, it encodes the slice and returns the slices as they were.
+func (e *ganttEncoder) append(gantt []TimeSlice, ts TimeSlice) []TimeSlice {
+	if e == nil {
+		return append(gantt, ts)
+	}
+	switch {
+	case !e.started:
+		e.started = true
+	case e.pending.PID == ts.PID && e.pending.Stop == ts.Start:
+		e.pending.Stop = ts.Stop
+		return gantt
+	default:
+		if e.pending.PID != ts.PID {
+			e.switches[ts.PID]++
+		}
+		e.write()
+	}
+	e.pending = ts
+
+	return gantt
+}
+
+func (e *ganttEncoder) write() {
+	_, _ = fmt.Fprintf(e.w, "%d,%s,%s\n", e.pending.PID, formatTicks(e.pending.Start, e.base),
+		formatTicks(e.pending.Stop-e.pending.Start, e.base))
+}
+
+// finish writes the last run of slices and returns the context switches that dispatched each of the
+// processes, or nil without an encoder.
+func (e *ganttEncoder) finish(processes []Process) []int {
+	if e == nil {
+		return nil
+	}
+	if e.started {
+		e.write()
+	}
+	switches := make([]int, len(processes))
+	for i, p := range processes {
+		switches[i] = e.switches[p.ProcessID]
+	}
+
+	return switches
+}
+
+// streamed reports whether the schedule's GANTT slices were streamed instead of kept.
+func (s Schedule) streamed() bool {
+	return s.streamedSwitches != nil
+}
+
+// validateGanttStream checks that nothing else run alongside -gantt-stream needs the GANTT slices it
+// does not keep.
+func validateGanttStream(opts Options, r Report, runs int, checkpoint string) error {
+	switch {
+	case r.Format != "text":
+		return fmt.Errorf("%w: -gantt-stream only reports to the text format", ErrInvalidArgs)
+	case runs > 1 || checkpoint != "":
+		return fmt.Errorf("%w: -gantt-stream cannot be combined with -runs or -checkpoint", ErrInvalidArgs)
+	case opts.ExactMetrics || opts.SwitchCost > 0 || opts.Energy.Governor != "" || r.Trace || r.Ticks || r.GanttCSV != "":
+		return fmt.Errorf("%w: -exact-metrics, -switch-cost, -governor, -trace, -ticks and -gantt-csv need the GANTT slices -gantt-stream does not keep", ErrInvalidArgs)
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_ganttEncoder(t *testing.T) {
+	t.Parallel()
+	processes, err := loadProcesses(strings.NewReader("1,5,0,2\n2,9,3,1\n3,6,6,3\n4,2,30,1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, a := range algorithms {
+		a := a
+		t.Run(a.name, func(t *testing.T) {
+			t.Parallel()
+			kept := a.schedule(processes, Options{})
+			var b bytes.Buffer
+			streamed := a.schedule(processes, Options{GanttStream: &b})
+			if len(streamed.Gantt) != 0 {
+				t.Errorf("Gantt = %v, want none kept", streamed.Gantt)
+			}
+
+			var want strings.Builder
+			_, _ = fmt.Fprintf(&want, "# %s\n", a.name)
+			for _, ts := range mergeSlices(kept.Gantt) {
+				_, _ = fmt.Fprintf(&want, "%d,%d,%d\n", ts.PID, ts.Start, ts.Stop-ts.Start)
+			}
+			if got := b.String(); got != want.String() {
+				t.Errorf("stream = %q, want %q", got, want.String())
+			}
+			if got, want := streamed.SwitchesPerProcess(), kept.SwitchesPerProcess(); !reflect.DeepEqual(got, want) {
+				t.Errorf("SwitchesPerProcess() = %v, want %v", got, want)
+			}
+			if !reflect.DeepEqual(streamed.Completion, kept.Completion) {
+				t.Errorf("Completion = %v, want %v", streamed.Completion, kept.Completion)
+			}
+		})
+	}
+}
+
+func Test_validateGanttStream(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		opts       Options
+		report     Report
+		runs       int
+		checkpoint string
+		wantErr    bool
+	}{
+		{name: "text", report: Report{Format: "text"}, runs: 1},
+		{name: "svg", report: Report{Format: "svg"}, runs: 1, wantErr: true},
+		{name: "runs", report: Report{Format: "text"}, runs: 10, wantErr: true},
+		{name: "checkpoint", report: Report{Format: "text"}, runs: 1, checkpoint: "run.checkpoint", wantErr: true},
+		{name: "trace", report: Report{Format: "text", Trace: true}, runs: 1, wantErr: true},
+		{name: "gantt csv", report: Report{Format: "text", GanttCSV: "gantt.csv"}, runs: 1, wantErr: true},
+		{name: "exact metrics", opts: Options{ExactMetrics: true}, report: Report{Format: "text"}, runs: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := validateGanttStream(tt.opts, tt.report, tt.runs, tt.checkpoint); (err != nil) != tt.wantErr {
+				t.Errorf("validateGanttStream() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
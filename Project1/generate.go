@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// distribution draws non-negative integer samples from a named statistical distribution.
+type distribution func(rng *rand.Rand) int64
+
+// parseDistribution parses a "name:params" distribution spec:
+// • const:N — always N
+// • uniform:A-B — uniformly between A and B inclusive
+// • exp:MEAN — exponential with the given mean, at least 1
+// • normal:MEAN,STDDEV — normal, clamped at 0
+// • poisson:RATE — for arrivals, exponential inter-arrival gaps of a Poisson process with the given rate
+func parseDistribution(spec string) (distribution, error) {
+	name, params, _ := strings.Cut(spec, ":")
+	bad := fmt.Errorf("%w: distribution %q", ErrInvalidArgs, spec)
+	switch name {
+	case "const":
+		n, err := strconv.ParseInt(params, 10, 64)
+		if err != nil {
+			return nil, bad
+		}
+		return func(*rand.Rand) int64 { return n }, nil
+	case "uniform":
+		lo, hi, ok := strings.Cut(params, "-")
+		a, errA := strconv.ParseInt(lo, 10, 64)
+		b, errB := strconv.ParseInt(hi, 10, 64)
+		if !ok || errA != nil || errB != nil || b < a {
+			return nil, bad
+		}
+		return func(rng *rand.Rand) int64 { return a + rng.Int63n(b-a+1) }, nil
+	case "exp":
+		mean, err := strconv.ParseFloat(params, 64)
+		if err != nil || mean <= 0 {
+			return nil, bad
+		}
+		return func(rng *rand.Rand) int64 {
+			return int64(math.Max(1, math.Round(rng.ExpFloat64()*mean)))
+		}, nil
+	case "normal":
+		m, s, ok := strings.Cut(params, ",")
+		mean, errM := strconv.ParseFloat(m, 64)
+		stddev, errS := strconv.ParseFloat(s, 64)
+		if !ok || errM != nil || errS != nil || stddev < 0 {
+			return nil, bad
+		}
+		return func(rng *rand.Rand) int64 {
+			return int64(math.Max(0, math.Round(rng.NormFloat64()*stddev+mean)))
+		}, nil
+	case "poisson":
+		rate, err := strconv.ParseFloat(params, 64)
+		if err != nil || rate <= 0 {
+			return nil, bad
+		}
+		return func(rng *rand.Rand) int64 {
+			return int64(math.Round(rng.ExpFloat64() / rate))
+		}, nil
+	}
+
+	return nil, bad
+}
+
+// generateProcesses builds count processes with IDs 1..count given:
+// • the distribution of gaps between consecutive arrivals
+// • the distributions of burst durations and priorities
+// • a seed, so the same arguments always generate the same workload
+// Bursts are at least 1, so a distribution that can draw 0, such as normal or const:0, still
+// generates a workload that can be scheduled.
+func generateProcesses(count int, arrival, burst, priority distribution, seed int64) []Process {
+	var (
+		rng       = rand.New(rand.NewSource(seed))
+		now       int64
+		processes = make([]Process, count)
+	)
+	for i := range processes {
+		if i > 0 {
+			now += arrival(rng)
+		}
+		b := burst(rng)
+		if b < 1 {
+			b = 1
+		}
+		processes[i] = Process{
+			ProcessID:     int64(i + 1),
+			ArrivalTime:   now,
+			BurstDuration: b,
+			Priority:      priority(rng),
+		}
+	}
+
+	return processes
+}
+
+// The defaults of the generate flags, which the gRPC GenerateWorkload call shares.
+const (
+	defaultGenerateCount = 10
+	defaultArrival       = "poisson:0.5"
+	defaultBurst         = "exp:5"
+	defaultPriority      = "uniform:1-5"
+)
+
+// generateWorkload parses the distribution specs and generates count processes from them, like
+// generateProcesses does. The count must lie within [0, maxTemplateProcesses], the limit of a
+// templated workload.
+func generateWorkload(count int, arrival, burst, priority string, seed int64) ([]Process, error) {
+	if count < 0 || count > maxTemplateProcesses {
+		return nil, fmt.Errorf("%w: count %d is not within [0, %d]", ErrInvalidArgs, count, maxTemplateProcesses)
+	}
+	arrivalDist, err := parseDistribution(arrival)
+	if err != nil {
+		return nil, err
+	}
+	burstDist, err := parseDistribution(burst)
+	if err != nil {
+		return nil, err
+	}
+	priorityDist, err := parseDistribution(priority)
+	if err != nil {
+		return nil, err
+	}
+
+	return generateProcesses(count, arrivalDist, burstDist, priorityDist, seed), nil
+}
+
+// generateCommand writes a randomly generated workload CSV to w.
+func generateCommand(w io.Writer, args []string) error {
+	fs := newFlagSet("generate")
+	count := fs.Int("count", defaultGenerateCount, "number of processes")
+	arrival := fs.String("arrival", defaultArrival, "distribution of the gaps between arrivals")
+	burst := fs.String("burst", defaultBurst, "distribution of burst durations")
+	priority := fs.String("priority", defaultPriority, "distribution of priorities")
+	seed := fs.Int64("seed", 1, "random seed")
+	logging := addLogFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := logging(); err != nil {
+		return err
+	}
+
+	processes, err := generateWorkload(*count, *arrival, *burst, *priority, *seed)
+	if err != nil {
+		return err
+	}
+
+	return writeProcesses(w, processes)
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func Test_parseDistribution(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		spec    string
+		min     int64
+		max     int64
+		wantErr error
+	}{
+		{name: "const", spec: "const:4", min: 4, max: 4},
+		{name: "uniform", spec: "uniform:1-5", min: 1, max: 5},
+		{name: "exp", spec: "exp:8", min: 1, max: 1 << 20},
+		{name: "normal", spec: "normal:10,2", min: 0, max: 1 << 20},
+		{name: "poisson", spec: "poisson:0.2", min: 0, max: 1 << 20},
+		{name: "unknown", spec: "zipf:2", wantErr: ErrInvalidArgs},
+		{name: "bad range", spec: "uniform:5-1", wantErr: ErrInvalidArgs},
+		{name: "bad rate", spec: "poisson:0", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dist, err := parseDistribution(tt.spec)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			rng := rand.New(rand.NewSource(1))
+			for i := 0; i < 1000; i++ {
+				if got := dist(rng); got < tt.min || got > tt.max {
+					t.Fatalf("sample %d = %d, want within [%d, %d]", i, got, tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
+
+func Test_generateProcesses(t *testing.T) {
+	t.Parallel()
+	arrival, _ := parseDistribution("poisson:0.2")
+	burst, _ := parseDistribution("exp:8")
+	priority, _ := parseDistribution("uniform:1-5")
+
+	got := generateProcesses(50, arrival, burst, priority, 42)
+	if again := generateProcesses(50, arrival, burst, priority, 42); !reflect.DeepEqual(got, again) {
+		t.Fatal("same seed generated different workloads")
+	}
+	for i := range got {
+		if got[i].ProcessID != int64(i+1) {
+			t.Errorf("process %d has ID %d", i, got[i].ProcessID)
+		}
+		if i > 0 && got[i].ArrivalTime < got[i-1].ArrivalTime {
+			t.Errorf("process %d arrives before its predecessor", i)
+		}
+	}
+}
+
+func Test_generateCommand_valid(t *testing.T) {
+	t.Parallel()
+	for _, burst := range []string{"normal:1,5", "const:0", "uniform:0-2"} {
+		var w bytes.Buffer
+		if err := generateCommand(&w, []string{"-count", "200", "-burst", burst}); err != nil {
+			t.Fatalf("-burst %s: %v", burst, err)
+		}
+		processes, err := loadProcesses(&w)
+		if err == nil {
+			err = validateProcesses(processes)
+		}
+		if err != nil {
+			t.Errorf("-burst %s generated an invalid workload: %v", burst, err)
+		}
+	}
+}
+
+func Test_generateCommand_count(t *testing.T) {
+	t.Parallel()
+	for _, count := range []string{"-1", "1000001", "100000000000000"} {
+		if err := generateCommand(new(bytes.Buffer), []string{"-count", count}); !errors.Is(err, ErrInvalidArgs) {
+			t.Errorf("-count %s: error = %v, want %v", count, err, ErrInvalidArgs)
+		}
+	}
+}
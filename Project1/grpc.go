@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/Hasti0013/CSCE4600/Project1/proto/schedulerpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// grpcServer serves the Scheduler service of proto/scheduler.proto, sharing the simulation timeout
+// and metrics of the HTTP server it is attached to.
+type grpcServer struct {
+	schedulerpb.UnimplementedSchedulerServer
+	sv *server
+}
+
+// newGRPCServer returns a gRPC server serving the Scheduler service with sv, accepting requests no
+// larger than the HTTP API does.
+func newGRPCServer(sv *server) *grpc.Server {
+	gs := grpc.NewServer(grpc.MaxRecvMsgSize(maxRequestBody))
+	schedulerpb.RegisterSchedulerServer(gs, grpcServer{sv: sv})
+
+	return gs
+}
+
+// Simulate schedules the processes of the request with each requested algorithm, like POST /simulate.
+func (g grpcServer) Simulate(ctx context.Context, req *schedulerpb.SimulateRequest) (*schedulerpb.SimulateResponse, error) {
+	selected, results, err := g.simulate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &schedulerpb.SimulateResponse{Results: newPBResults(selected, results)}, nil
+}
+
+// Compare schedules the processes of the request with each requested algorithm and names the one
+// with the best value of each metric of the compare subcommand's table.
+func (g grpcServer) Compare(ctx context.Context, req *schedulerpb.SimulateRequest) (*schedulerpb.CompareResponse, error) {
+	selected, results, err := g.simulate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	best := make(map[string]string)
+	for _, m := range resultMetrics(results) {
+		best[strings.ReplaceAll(strings.ToLower(m.header), " ", "_")] = selected[m.best(results)].name
+	}
+
+	return &schedulerpb.CompareResponse{Results: newPBResults(selected, results), Best: best}, nil
+}
+
+// GenerateWorkload draws a random workload like the generate subcommand, with its defaults for the
+// fields left unset.
+func (g grpcServer) GenerateWorkload(_ context.Context, req *schedulerpb.GenerateWorkloadRequest) (*schedulerpb.GenerateWorkloadResponse, error) {
+	count, arrival, burst, priority, seed := defaultGenerateCount, defaultArrival, defaultBurst, defaultPriority, int64(1)
+	if req.Count != nil {
+		count = int(req.GetCount())
+	}
+	for _, field := range []struct {
+		spec  *string
+		value string
+	}{{&arrival, req.GetArrival()}, {&burst, req.GetBurst()}, {&priority, req.GetPriority()}} {
+		if field.value != "" {
+			*field.spec = field.value
+		}
+	}
+	if req.Seed != nil {
+		seed = req.GetSeed()
+	}
+	processes, err := generateWorkload(count, arrival, burst, priority, seed)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	resp := &schedulerpb.GenerateWorkloadResponse{Processes: make([]*schedulerpb.Process, len(processes))}
+	for i, p := range processes {
+		resp.Processes[i] = &schedulerpb.Process{Id: p.ProcessID, Burst: p.BurstDuration, Arrival: p.ArrivalTime, Priority: p.Priority}
+	}
+
+	return resp, nil
+}
+
+// simulate checks the request as POST /simulate does and runs it, answering an invalid request with
+// InvalidArgument and a cancelled simulation with the status of ctx's error.
+func (g grpcServer) simulate(ctx context.Context, req *schedulerpb.SimulateRequest) ([]algorithm, []result, error) {
+	remote := "unknown"
+	if p, ok := peer.FromContext(ctx); ok {
+		remote = p.Addr.String()
+	}
+	body := newSimulateRequest()
+	body.Algorithms = req.GetAlgorithms()
+	if req.GetTieBreak() != "" {
+		body.TieBreak = req.GetTieBreak()
+	}
+	if req.Seed != nil {
+		body.Seed = req.GetSeed()
+	}
+	body.Processes = make([]apiProcess, len(req.GetProcesses()))
+	for i, p := range req.GetProcesses() {
+		body.Processes[i] = apiProcess{
+			ID:       p.GetId(),
+			Burst:    apiTime(strconv.FormatInt(p.GetBurst(), 10)),
+			Arrival:  apiTime(strconv.FormatInt(p.GetArrival(), 10)),
+			Priority: p.GetPriority(),
+		}
+	}
+	processes, selected, opts, err := body.parse()
+	if err != nil {
+		g.sv.metrics.request(true)
+		logs.Info("bad grpc simulate request", "remote", remote, "err", err)
+		return nil, nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	results, err := g.sv.simulate(ctx, remote, processes, selected, opts)
+	if err != nil {
+		return nil, nil, status.FromContextError(err).Err()
+	}
+	logs.Info("grpc simulate", "remote", remote, "processes", len(processes), "algorithms", len(selected))
+
+	return selected, results, nil
+}
+
+// newPBResults converts the results of the selected algorithms into their protobuf form, from the same
+// fields as the JSON API's.
+func newPBResults(selected []algorithm, results []result) []*schedulerpb.Result {
+	out := make([]*schedulerpb.Result, len(results))
+	for i, res := range newSimulateResponse(selected, results).Results {
+		sum := res.Summary
+		out[i] = &schedulerpb.Result{
+			Algorithm: res.Algorithm,
+			Summary: &schedulerpb.AlgorithmSummary{
+				Algorithm:         sum.Algorithm,
+				Processes:         int32(sum.Processes),
+				AverageWait:       sum.AverageWait,
+				AverageTurnaround: sum.AverageTurnaround,
+				AverageResponse:   sum.AverageResponse,
+				Makespan:          int64(sum.Makespan),
+				Throughput:        sum.Throughput,
+				ContextSwitches:   int32(sum.ContextSwitches),
+			},
+			Gantt:     make([]*schedulerpb.TimeSlice, len(res.Gantt)),
+			Processes: make([]*schedulerpb.ProcessTiming, len(res.Processes)),
+		}
+		for j, ts := range res.Gantt {
+			out[i].Gantt[j] = &schedulerpb.TimeSlice{Pid: ts.PID, Start: ts.Start, Stop: ts.Stop}
+		}
+		for j, p := range res.Processes {
+			out[i].Processes[j] = &schedulerpb.ProcessTiming{
+				Id:         p.ID,
+				Wait:       p.Wait,
+				Turnaround: p.Turnaround,
+				Response:   p.Response,
+				Completion: p.Completion,
+			}
+		}
+	}
+
+	return out
+}
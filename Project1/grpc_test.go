@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Hasti0013/CSCE4600/Project1/proto/schedulerpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+// newGRPCClient serves the Scheduler service of sv in memory and returns a client of it.
+func newGRPCClient(t *testing.T, sv *server) schedulerpb.SchedulerClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	gs := newGRPCServer(sv)
+	go func() { _ = gs.Serve(lis) }()
+	t.Cleanup(gs.Stop)
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return schedulerpb.NewSchedulerClient(conn)
+}
+
+func Test_grpcServer_Simulate(t *testing.T) {
+	t.Parallel()
+	client := newGRPCClient(t, &server{metrics: newServerMetrics()})
+	processes := []*schedulerpb.Process{{Id: 1, Burst: 5, Arrival: 0, Priority: 2}, {Id: 2, Burst: 9, Arrival: 3, Priority: 1}}
+	tests := []struct {
+		name           string
+		req            *schedulerpb.SimulateRequest
+		wantCode       codes.Code
+		wantAlgorithms []string
+		wantCompletion []int64
+	}{
+		{
+			name:           "fcfs",
+			req:            &schedulerpb.SimulateRequest{Processes: processes, Algorithms: []string{"fcfs"}},
+			wantAlgorithms: []string{"fcfs"},
+			wantCompletion: []int64{5, 14},
+		},
+		{
+			name:           "every algorithm",
+			req:            &schedulerpb.SimulateRequest{Processes: processes, TieBreak: "pid", Seed: proto.Int64(7)},
+			wantAlgorithms: []string{"fcfs", "sjf", "priority", "rr", "feedback"},
+		},
+		{
+			name:           "real-time algorithms",
+			req:            &schedulerpb.SimulateRequest{Processes: processes, Algorithms: []string{"edf", "llf"}},
+			wantAlgorithms: []string{"edf", "llf"},
+		},
+		{name: "no processes", req: &schedulerpb.SimulateRequest{}, wantCode: codes.InvalidArgument},
+		{
+			name:     "unknown algorithm",
+			req:      &schedulerpb.SimulateRequest{Processes: processes, Algorithms: []string{"lottery"}},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "zero burst",
+			req:      &schedulerpb.SimulateRequest{Processes: []*schedulerpb.Process{{Id: 1}}},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			resp, err := client.Simulate(context.Background(), tt.req)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("code = %v, want %v (%v)", got, tt.wantCode, err)
+			}
+			if err != nil {
+				return
+			}
+			var algorithms []string
+			for _, res := range resp.GetResults() {
+				algorithms = append(algorithms, res.GetAlgorithm())
+				if got := len(res.GetProcesses()); got != len(tt.req.GetProcesses()) {
+					t.Errorf("%s: %d process timings, want %d", res.GetAlgorithm(), got, len(tt.req.GetProcesses()))
+				}
+			}
+			if !reflect.DeepEqual(algorithms, tt.wantAlgorithms) {
+				t.Errorf("algorithms = %v, want %v", algorithms, tt.wantAlgorithms)
+			}
+			if tt.wantCompletion != nil {
+				var completion []int64
+				for _, p := range resp.GetResults()[0].GetProcesses() {
+					completion = append(completion, p.GetCompletion())
+				}
+				if !reflect.DeepEqual(completion, tt.wantCompletion) {
+					t.Errorf("completion = %v, want %v", completion, tt.wantCompletion)
+				}
+			}
+		})
+	}
+}
+
+func Test_grpcServer_Compare(t *testing.T) {
+	t.Parallel()
+	client := newGRPCClient(t, &server{metrics: newServerMetrics()})
+	resp, err := client.Compare(context.Background(), &schedulerpb.SimulateRequest{
+		Processes:  []*schedulerpb.Process{{Id: 1, Burst: 10}, {Id: 2, Burst: 1, Arrival: 1}},
+		Algorithms: []string{"fcfs", "sjf"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(resp.GetResults()); got != 2 {
+		t.Errorf("%d results, want 2", got)
+	}
+	for metric, want := range map[string]string{"average_wait": "sjf", "makespan": "fcfs"} {
+		if got := resp.GetBest()[metric]; got != want {
+			t.Errorf("best %s = %q, want %q", metric, got, want)
+		}
+	}
+}
+
+func Test_grpcServer_GenerateWorkload(t *testing.T) {
+	t.Parallel()
+	client := newGRPCClient(t, &server{metrics: newServerMetrics()})
+	tests := []struct {
+		name      string
+		req       *schedulerpb.GenerateWorkloadRequest
+		wantCode  codes.Code
+		wantCount int
+	}{
+		{name: "defaults", req: &schedulerpb.GenerateWorkloadRequest{}, wantCount: defaultGenerateCount},
+		{name: "count", req: &schedulerpb.GenerateWorkloadRequest{Count: proto.Int32(3), Burst: "const:2", Seed: proto.Int64(5)}, wantCount: 3},
+		{name: "no processes", req: &schedulerpb.GenerateWorkloadRequest{Count: proto.Int32(0)}},
+		{name: "negative count", req: &schedulerpb.GenerateWorkloadRequest{Count: proto.Int32(-1)}, wantCode: codes.InvalidArgument},
+		{name: "count too large", req: &schedulerpb.GenerateWorkloadRequest{Count: proto.Int32(maxTemplateProcesses + 1)}, wantCode: codes.InvalidArgument},
+		{name: "bad distribution", req: &schedulerpb.GenerateWorkloadRequest{Arrival: "zipf:2"}, wantCode: codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			resp, err := client.GenerateWorkload(context.Background(), tt.req)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("code = %v, want %v (%v)", got, tt.wantCode, err)
+			}
+			if got := len(resp.GetProcesses()); got != tt.wantCount {
+				t.Errorf("%d processes, want %d", got, tt.wantCount)
+			}
+			for _, p := range resp.GetProcesses() {
+				if p.GetBurst() < 1 {
+					t.Errorf("process %d has burst %d", p.GetId(), p.GetBurst())
+				}
+			}
+		})
+	}
+}
+
+func Test_grpcServer_timeout(t *testing.T) {
+	t.Parallel()
+	client := newGRPCClient(t, &server{metrics: newServerMetrics(), timeout: time.Nanosecond})
+	_, err := client.Simulate(context.Background(), &schedulerpb.SimulateRequest{Processes: []*schedulerpb.Process{{Id: 1, Burst: 5}}})
+	if got := status.Code(err); got != codes.DeadlineExceeded {
+		t.Errorf("code = %v, want %v (%v)", got, codes.DeadlineExceeded, err)
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// histogramWidth is the number of characters of the longest histogram bar.
+const histogramWidth = 40
+
+// histogramBucket counts the bursts from Low up to, not including, High ticks.
+type histogramBucket struct {
+	Low   int64
+	High  int64
+	Count int
+}
+
+// burstHistogram counts the burst lengths of the processes in at most n equally wide buckets from
+// the shortest burst to the longest, fewer when the bursts span fewer ticks.
+func burstHistogram(processes []Process, n int) []histogramBucket {
+	if len(processes) == 0 || n <= 0 {
+		return nil
+	}
+	shortest, longest := processes[0].BurstDuration, processes[0].BurstDuration
+	for _, p := range processes {
+		if p.BurstDuration < shortest {
+			shortest = p.BurstDuration
+		}
+		if p.BurstDuration > longest {
+			longest = p.BurstDuration
+		}
+	}
+	span := longest - shortest + 1
+	width := (span + int64(n) - 1) / int64(n)
+	buckets := make([]histogramBucket, (span+width-1)/width)
+	for i := range buckets {
+		buckets[i] = histogramBucket{Low: shortest + int64(i)*width, High: shortest + int64(i+1)*width}
+	}
+	for _, p := range processes {
+		buckets[(p.BurstDuration-shortest)/width].Count++
+	}
+
+	return buckets
+}
+
+// histogramLines formats the histogram of the report's number of buckets of the schedule's bursts, a
+// line per bucket with its range in time units, a bar scaled to the fullest bucket and its count.
+func (s Schedule) histogramLines(r Report) []string {
+	buckets := burstHistogram(s.Processes, r.BurstHistogram)
+	labels := make([]string, len(buckets))
+	most, widest := 0, 0
+	for i, b := range buckets {
+		labels[i] = s.formatTime(b.Low)
+		if b.High-b.Low > 1 {
+			labels[i] += "-" + s.formatTime(b.High-1)
+		}
+		if b.Count > most {
+			most = b.Count
+		}
+		if len(labels[i]) > widest {
+			widest = len(labels[i])
+		}
+	}
+	lines := make([]string, len(buckets))
+	for i, b := range buckets {
+		bar := strings.Repeat("#", (b.Count*histogramWidth+most-1)/most)
+		lines[i] = fmt.Sprintf("%*s | %s", widest, labels[i], strings.TrimLeft(fmt.Sprintf("%s %d", bar, b.Count), " "))
+	}
+
+	return lines
+}
+
+// outputBurstHistogram outputs the histogram of the burst lengths of the workload.
+func outputBurstHistogram(w io.Writer, s Schedule, r Report) {
+	_, _ = fmt.Fprintln(w, "Burst lengths")
+	for _, line := range s.histogramLines(r) {
+		_, _ = fmt.Fprintln(w, line)
+	}
+	_, _ = fmt.Fprintln(w)
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_burstHistogram(t *testing.T) {
+	t.Parallel()
+	bursts := func(lengths ...int64) []Process {
+		processes := make([]Process, len(lengths))
+		for i, l := range lengths {
+			processes[i] = Process{ProcessID: int64(i + 1), BurstDuration: l}
+		}
+		return processes
+	}
+	tests := []struct {
+		name      string
+		processes []Process
+		n         int
+		want      []histogramBucket
+	}{
+		{
+			name:      "buckets",
+			processes: bursts(1, 2, 2, 5, 9),
+			n:         3,
+			want:      []histogramBucket{{Low: 1, High: 4, Count: 3}, {Low: 4, High: 7, Count: 1}, {Low: 7, High: 10, Count: 1}},
+		},
+		{
+			name:      "narrow span",
+			processes: bursts(4, 5, 5),
+			n:         10,
+			want:      []histogramBucket{{Low: 4, High: 5, Count: 1}, {Low: 5, High: 6, Count: 2}},
+		},
+		{name: "disabled", processes: bursts(4), n: 0, want: nil},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := burstHistogram(tt.processes, tt.n); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("burstHistogram() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Schedule_histogramLines(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 1},
+		{ProcessID: 2, BurstDuration: 2},
+		{ProcessID: 3, BurstDuration: 12},
+	}
+	want := []string{
+		" 1-4 | ######################################## 2",
+		" 5-8 | 0",
+		"9-12 | #################### 1",
+	}
+	if got := fcfs(processes, Options{}).histogramLines(Report{BurstHistogram: 3}); !reflect.DeepEqual(got, want) {
+		t.Errorf("histogramLines() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+)
+
+// pidPalette holds the colors assigned to process IDs in graphical outputs.
+var pidPalette = []string{
+	"#4e79a7", "#f28e2b", "#e15759", "#76b7b2", "#59a14f", "#edc948",
+	"#b07aa1", "#ff9da7", "#9c755f", "#bab0ac", "#86bcb6", "#d37295",
+}
+
+// idleColor is the color of idle periods in graphical outputs.
+const idleColor = "#e8e8e8"
+
+// pidColor returns the color of a process ID, the same in every chart and every run.
+func pidColor(pid int64) string {
+	if pid == idlePID || pid == switchPID {
+		return idleColor
+	}
+	if pid < 0 {
+		pid = -pid
+	}
+
+	return pidPalette[pid%int64(len(pidPalette))]
+}
+
+type (
+	htmlSlice struct {
+		Label string
+		Color string
+		Left  float64 // percent of the timeline
+		Width float64 // percent of the timeline
+		Title string
+	}
+	htmlTable struct {
+		Header []string
+		Rows   [][]string
+		Footer []string
+	}
+	htmlResult struct {
+		Title      string
+		Timeline   []htmlSlice
+		Ticks      []htmlSlice
+		Schedule   htmlTable
+		Summary    []string
+		Stats      *htmlTable
+		Starvation []starvation
+	}
+	htmlBar struct {
+		Label string
+		Value string
+		Width float64 // percent of the widest bar
+		Best  bool
+	}
+	htmlChart struct {
+		Title string
+		Bars  []htmlBar
+	}
+	htmlReport struct {
+		Results    []htmlResult
+		Charts     []htmlChart
+		Comparison *htmlTable
+	}
+)
+
+// htmlTimeline lays out a timeline's slices as percentages of its length, with about ten ticks, labeled
+// in time units of a time base.
+func htmlTimeline(timeline []TimeSlice, base timeBase) (slices, ticks []htmlSlice) {
+	if len(timeline) == 0 {
+		return nil, nil
+	}
+	start, stop := timeline[0].Start, timeline[len(timeline)-1].Stop
+	length := float64(stop - start)
+	if length <= 0 {
+		length = 1
+	}
+	for _, ts := range timeline {
+		label := sliceLabel(ts.PID)
+		slices = append(slices, htmlSlice{
+			Label: label,
+			Color: pidColor(ts.PID),
+			Left:  float64(ts.Start-start) / length * 100,
+			Width: float64(ts.Stop-ts.Start) / length * 100,
+			Title: fmt.Sprintf("%s: %s–%s (%s)", label, formatTicks(ts.Start, base), formatTicks(ts.Stop, base),
+				formatTicks(ts.Stop-ts.Start, base)),
+		})
+	}
+	for _, t := range ganttTicks(start, stop) {
+		ticks = append(ticks, htmlSlice{Label: formatTicks(t, base), Left: float64(t-start) / length * 100})
+	}
+
+	return slices, ticks
+}
+
+// outputHTML outputs a self-contained HTML report with each result's interactive GANTT timeline and
+// tables, and bar charts of the compared metrics when there is more than one result.
+func outputHTML(w io.Writer, results []result, r Report) error {
+	var report htmlReport
+	for _, res := range results {
+		s := res.schedule
+		hr := htmlResult{
+			Title:      res.title,
+			Schedule:   htmlTable{Header: scheduleHeader(r), Rows: s.rows(r), Footer: s.footer(r)},
+			Summary:    s.summary(),
+			Starvation: s.Starvation(r),
+		}
+		hr.Timeline, hr.Ticks = htmlTimeline(s.timeline(), s.timeBase())
+		if r.Stats {
+			hr.Stats = &htmlTable{Header: statsHeader, Rows: s.statsRows()}
+		}
+		report.Results = append(report.Results, hr)
+	}
+
+	if len(results) > 1 {
+		_, rows := comparisonRows(results)
+		for m, metric := range resultMetrics(results) {
+			chart := htmlChart{Title: metric.header}
+			var max float64
+			for _, res := range results {
+				if v := metric.value(res.schedule); v > max {
+					max = v
+				}
+			}
+			for i, res := range results {
+				v := metric.value(res.schedule)
+				bar := htmlBar{
+					Label: res.title,
+					Value: fmt.Sprintf(metric.format, v),
+					Best:  strings.HasSuffix(rows[i][m+1], "*"),
+				}
+				if max > 0 {
+					bar.Width = v / max * 100
+				}
+				chart.Bars = append(chart.Bars, bar)
+			}
+			report.Charts = append(report.Charts, chart)
+		}
+	}
+	if r.Compare {
+		header, rows := comparisonRows(results)
+		report.Comparison = &htmlTable{Header: header, Rows: rows}
+	}
+
+	return htmlReportTemplate.Execute(w, report)
+}
+
+var htmlReportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Scheduling report</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; margin: 1em 0; }
+th, td { border: 1px solid #ccc; padding: 0.25em 0.6em; text-align: right; }
+th:first-child, td:first-child { text-align: left; }
+tfoot td { font-weight: bold; white-space: pre-line; }
+.timeline { position: relative; height: 2.2em; border: 1px solid #999; margin-top: 0.5em; }
+.slice { position: absolute; top: 0; bottom: 0; box-sizing: border-box; border-right: 1px solid #fff;
+  color: #fff; font-size: 0.8em; line-height: 2.7em; text-align: center; overflow: hidden; cursor: default; }
+.slice.idle { color: #666; }
+.slice.dim { opacity: 0.25; }
+.axis { position: relative; height: 1.4em; font-size: 0.75em; color: #666; }
+.axis span { position: absolute; transform: translateX(-50%); }
+.chart { display: inline-block; vertical-align: top; width: 22em; margin: 0 2em 1em 0; }
+.bar { display: flex; align-items: center; margin: 0.2em 0; font-size: 0.85em; }
+.bar .label { width: 11em; }
+.bar .fill { background: #4e79a7; height: 1em; margin-right: 0.4em; }
+.bar.best .fill { background: #59a14f; }
+.warning { color: #b00; }
+</style>
+</head>
+<body>
+<h1>Scheduling report</h1>
+{{- if .Charts}}
+<section>
+<h2>Averages</h2>
+{{- range .Charts}}
+<div class="chart"><h3>{{.Title}}</h3>
+{{- range .Bars}}
+<div class="bar{{if .Best}} best{{end}}"><span class="label">{{.Label}}</span><span class="fill" style="width: {{printf "%.2f" .Width}}%"></span>{{.Value}}</div>
+{{- end}}
+</div>
+{{- end}}
+</section>
+{{- end}}
+{{- range .Results}}
+<section>
+<h2>{{.Title}}</h2>
+<div class="timeline">
+{{- range .Timeline}}
+<div class="slice{{if eq .Label "IDLE"}} idle{{end}}" data-pid="{{.Label}}" title="{{.Title}}" style="left: {{printf "%.4f" .Left}}%; width: {{printf "%.4f" .Width}}%; background: {{.Color}}">{{.Label}}</div>
+{{- end}}
+</div>
+<div class="axis">
+{{- range .Ticks}}<span style="left: {{printf "%.4f" .Left}}%">{{.Label}}</span>{{end}}
+</div>
+{{template "table" .Schedule}}
+<ul>
+{{- range .Summary}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- with .Stats}}{{template "table" .}}{{end}}
+{{- if .Starvation}}
+<h3 class="warning">Starvation warnings</h3>
+<ul class="warning">
+{{- range .Starvation}}
+<li>process {{.PID}} {{.Reason}}</li>
+{{- end}}
+</ul>
+{{- end}}
+</section>
+{{- end}}
+{{- with .Comparison}}
+<section>
+<h2>Comparison</h2>
+{{template "table" .}}
+<p>* best value</p>
+</section>
+{{- end}}
+<script>
+document.querySelectorAll('.slice').forEach(function (slice) {
+  var timeline = slice.parentNode;
+  slice.addEventListener('mouseenter', function () {
+    timeline.querySelectorAll('.slice').forEach(function (other) {
+      other.classList.toggle('dim', other.dataset.pid !== slice.dataset.pid);
+    });
+  });
+  slice.addEventListener('mouseleave', function () {
+    timeline.querySelectorAll('.slice').forEach(function (other) { other.classList.remove('dim'); });
+  });
+});
+</script>
+</body>
+</html>
+{{define "table"}}<table>
+<thead><tr>{{range .Header}}<th>{{.}}</th>{{end}}</tr></thead>
+<tbody>
+{{- range .Rows}}
+<tr>{{range .}}<td>{{.}}</td>{{end}}</tr>
+{{- end}}
+</tbody>
+{{- if .Footer}}
+<tfoot><tr>{{range .Footer}}<td>{{.}}</td>{{end}}</tr></tfoot>
+{{- end}}
+</table>{{end}}
+`))
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_outputHTML(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	}
+	results := []result{
+		{title: "First-come, first-serve", schedule: fcfs(processes, Options{})},
+		{title: "Shortest-job-first", schedule: sjf(processes, Options{})},
+	}
+
+	var w bytes.Buffer
+	if err := outputHTML(&w, results, Report{SlowdownBound: defaultSlowdownBound, Compare: true}); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"<h2>First-come, first-serve</h2>",
+		`data-pid="2" title="2: 5–14 (9)" style="left: 25.0000%; width: 45.0000%; background: #e15759"`,
+		"<tr><td>2</td><td>1</td><td>9</td><td>3</td><td>2</td><td>11</td>",
+		"<h3>Average wait</h3>",
+		`<div class="bar best"><span class="label">Shortest-job-first</span>`,
+		"<h2>Comparison</h2>",
+	} {
+		if got := w.String(); !strings.Contains(got, want) {
+			t.Errorf("outputHTML() = %v, want it to contain %q", got, want)
+		}
+	}
+}
+
+func Test_outputResults_file(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2}}
+	path := filepath.Join(t.TempDir(), "report.html")
+
+	var w bytes.Buffer
+	r := Report{SlowdownBound: defaultSlowdownBound, Format: "html", Output: path}
+	if err := outputResults(&w, []result{{title: "Priority", schedule: fcfs(processes, Options{})}}, r); err != nil {
+		t.Fatal(err)
+	}
+	if w.Len() != 0 {
+		t.Errorf("outputResults() wrote %q to w, want nothing", w.String())
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(got), "<!DOCTYPE html>") {
+		t.Errorf("report file = %s, want an HTML document", got)
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+var influxTagEscaper = strings.NewReplacer(",", `\,`, " ", `\ `, "=", `\=`)
+
+// outputInflux outputs the results as InfluxDB line protocol, all stamped with the current time.
+func outputInflux(w io.Writer, results []result, r Report) error {
+	return writeInflux(w, results, r, time.Now().UnixNano())
+}
+
+// writeInflux writes a scheduler_process point per process and a scheduler_run point per result,
+// tagged with the algorithm and stamped with timestamp nanoseconds.
+func writeInflux(w io.Writer, results []result, r Report, timestamp int64) error {
+	for _, res := range results {
+		s := res.schedule
+		algorithm := influxTagEscaper.Replace(res.title)
+		normalized, slowdown, response := s.NormalizedTurnaround(), s.BoundedSlowdown(r.SlowdownBound), s.Response()
+		preemptions := s.Preemptions()
+		for i, p := range s.Processes {
+			_, err := fmt.Fprintf(w, "scheduler_process,algorithm=%s,pid=%d arrival=%s,burst=%s,priority=%di,wait=%s,turnaround=%s,completion=%s,response=%s,normalized_turnaround=%g,bounded_slowdown=%g,preemptions=%di %d\n",
+				algorithm, p.ProcessID, influxTime(s, p.ArrivalTime), influxTime(s, p.BurstDuration), p.Priority,
+				influxTime(s, s.Wait[i]), influxTime(s, s.Turnaround[i]), influxTime(s, s.Completion[i]), influxTime(s, response[i]),
+				normalized[i], slowdown[i], preemptions[i], timestamp)
+			if err != nil {
+				return err
+			}
+		}
+		_, err := fmt.Fprintf(w, "scheduler_run,algorithm=%s processes=%di,average_wait=%g,average_turnaround=%g,average_response=%g,throughput=%g,makespan=%s,utilization=%g,context_switches=%di %d\n",
+			algorithm, len(s.Processes), s.AverageWait(), s.AverageTurnaround(), s.AverageResponse(),
+			s.Throughput(), influxTime(s, s.Makespan()), s.Utilization(), s.ContextSwitches(), timestamp)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// influxTime formats a time field of a schedule: an integer in whole time units, or a float in time
+// units when the schedule's times may be fractional or durations.
+func influxTime(s Schedule, t int64) string {
+	if s.Unit == 0 && s.ticksPerUnit() == 1 {
+		return fmt.Sprintf("%di", t)
+	}
+
+	return s.formatTime(t)
+}
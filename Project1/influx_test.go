@@ -0,0 +1,27 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_writeInflux(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+	}
+	results := []result{{title: "First-come, first-serve", schedule: fcfs(processes, Options{})}}
+
+	var w bytes.Buffer
+	if err := writeInflux(&w, results, Report{SlowdownBound: defaultSlowdownBound}, 1700000000000000000); err != nil {
+		t.Fatal(err)
+	}
+	want := `scheduler_process,algorithm=First-come\,\ first-serve,pid=1 arrival=0i,burst=5i,priority=2i,wait=0i,turnaround=5i,completion=5i,response=0i,normalized_turnaround=1,bounded_slowdown=1,preemptions=0i 1700000000000000000
+scheduler_process,algorithm=First-come\,\ first-serve,pid=2 arrival=3i,burst=9i,priority=1i,wait=2i,turnaround=11i,completion=14i,response=2i,normalized_turnaround=1.2222222222222223,bounded_slowdown=1.1,preemptions=0i 1700000000000000000
+scheduler_run,algorithm=First-come\,\ first-serve processes=2i,average_wait=1,average_turnaround=8,average_response=1,throughput=0.14285714285714285,makespan=14i,utilization=1,context_switches=1i 1700000000000000000
+`
+	if got := w.String(); got != want {
+		t.Errorf("writeInflux() = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// jitterAmount bounds how far a time of a process is perturbed either way: by a time, or by a
+// fraction of the time itself.
+type jitterAmount struct {
+	ticks    int64
+	fraction float64
+}
+
+// bound returns the largest perturbation of the time v, in ticks.
+func (a jitterAmount) bound(v int64) int64 {
+	return a.ticks + int64(math.Round(a.fraction*float64(v)))
+}
+
+// Jitter perturbs the arrivals and bursts of a workload, to see how sensitive the schedules are to
+// small changes of it.
+type Jitter struct {
+	Arrival jitterAmount
+	Burst   jitterAmount
+}
+
+// enabled reports whether the jitter perturbs anything.
+func (j Jitter) enabled() bool {
+	return j != Jitter{}
+}
+
+// parseJitter parses a comma separated list of "arrival=±2" and "burst=±10%" perturbations. The
+// amount is a time in the workload's time base or a percentage of each time; its sign may be
+// written ±, +- or left out.
+func parseJitter(spec string, base timeBase) (Jitter, error) {
+	var j Jitter
+	if strings.TrimSpace(spec) == "" {
+		return j, nil
+	}
+	for _, field := range strings.Split(spec, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(field), "=")
+		if !ok {
+			return Jitter{}, fmt.Errorf("%w: jitter %q must be arrival=±time or burst=±percent%%", ErrInvalidArgs, field)
+		}
+		var amount *jitterAmount
+		switch strings.TrimSpace(key) {
+		case "arrival":
+			amount = &j.Arrival
+		case "burst":
+			amount = &j.Burst
+		default:
+			return Jitter{}, fmt.Errorf("%w: unknown jitter %q (want arrival or burst)", ErrInvalidArgs, key)
+		}
+		value = strings.TrimSpace(value)
+		for _, sign := range []string{"±", "+-", "+/-"} {
+			value = strings.TrimPrefix(value, sign)
+		}
+		if strings.HasSuffix(value, "%") {
+			f, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+			if err != nil || f < 0 {
+				return Jitter{}, fmt.Errorf("%w: jitter %q is not a percentage", ErrInvalidArgs, field)
+			}
+			amount.fraction = f / 100
+			continue
+		}
+		ticks, err := parseTime(value, base)
+		if err != nil || ticks < 0 {
+			return Jitter{}, fmt.Errorf("%w: jitter %q is not a time", ErrInvalidArgs, field)
+		}
+		amount.ticks = ticks
+	}
+
+	return j, nil
+}
+
+// perturb returns the processes with each arrival and burst moved by a uniformly random amount
+// within the jitter's bounds, drawn from seed. Arrivals stay at 0 or later and bursts a tick or more.
+func (j Jitter) perturb(processes []Process, seed int64) []Process {
+	rng := rand.New(rand.NewSource(seed))
+	shift := func(a jitterAmount, v int64) int64 {
+		bound := a.bound(v)
+		if bound <= 0 {
+			return 0
+		}
+		return rng.Int63n(2*bound+1) - bound
+	}
+	perturbed := append([]Process(nil), processes...)
+	for i := range perturbed {
+		p := &perturbed[i]
+		if p.ArrivalTime += shift(j.Arrival, p.ArrivalTime); p.ArrivalTime < 0 {
+			p.ArrivalTime = 0
+		}
+		if p.BurstDuration += shift(j.Burst, p.BurstDuration); p.BurstDuration < 1 {
+			p.BurstDuration = 1
+		}
+	}
+
+	return perturbed
+}
+
+// sensitivityMetrics are the averages whose spread over perturbed runs the sensitivity analysis
+// reports.
+var sensitivityMetrics = []struct {
+	name  string
+	value func(s Schedule) float64
+}{
+	{name: "Average wait", value: Schedule.AverageWait},
+	{name: "Average turnaround", value: Schedule.AverageTurnaround},
+	{name: "Average response", value: Schedule.AverageResponse},
+}
+
+// sensitivityHeader names the sensitivity table columns.
+var sensitivityHeader = []string{"Algorithm", "Metric", "Mean", "Stddev", "Min", "Max", "CV"}
+
+// sensitivity schedules runs perturbations of the workload, seeded from the options' seed onwards,
+// with each algorithm and returns each algorithm's values of the sensitivity metrics, by run.
+func sensitivity(processes []Process, opts Options, algs []algorithm, runs int) [][][]float64 {
+	values := make([][][]float64, len(algs))
+	for i := range algs {
+		values[i] = make([][]float64, len(sensitivityMetrics))
+	}
+	samples, _ := monteCarlo(processes, opts, algs, runs, nil) // without a checkpoint it cannot fail
+	for _, results := range samples {
+		for i, res := range results {
+			for m, metric := range sensitivityMetrics {
+				values[i][m] = append(values[i][m], metric.value(res.schedule))
+			}
+		}
+	}
+
+	return values
+}
+
+// outputSensitivity writes how much each algorithm's averages vary over the perturbed runs. The
+// coefficient of variation, stddev ÷ mean, compares the spread of metrics of different sizes.
+func outputSensitivity(w io.Writer, algs []algorithm, values [][][]float64, runs int, style string) {
+	outputTitle(w, fmt.Sprintf("Sensitivity over %d perturbed runs", runs))
+	rows := make([][]string, 0, len(algs)*len(sensitivityMetrics))
+	for i, a := range algs {
+		for m, metric := range sensitivityMetrics {
+			d := describe(values[i][m])
+			cv := 0.0
+			if d.Mean != 0 {
+				cv = d.Stddev / d.Mean
+			}
+			rows = append(rows, []string{
+				a.title,
+				metric.name,
+				fmt.Sprintf("%.2f", d.Mean),
+				fmt.Sprintf("%.2f", d.Stddev),
+				fmt.Sprintf("%.2f", d.Min),
+				fmt.Sprintf("%.2f", d.Max),
+				fmt.Sprintf("%.2f", cv),
+			})
+		}
+	}
+	outputTable(w, style, sensitivityHeader, rows, nil, nil)
+}
+
+// sensitivityCommand schedules perturbed copies of a workload and reports how stable the averages of
+// each algorithm are.
+func sensitivityCommand(w io.Writer, args []string) error {
+	fs := newFlagSet("sensitivity")
+	names := fs.String("algorithms", "all", "comma separated algorithms to analyze")
+	runs := fs.Int("runs", 20, "number of perturbed runs, seeded from -seed onwards")
+	tableStyle := fs.String("table-style", "ascii", "text table style: "+strings.Join(tableStyleNames(), ", "))
+	options := addOptionFlags(fs)
+	logging := addLogFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := logging(); err != nil {
+		return err
+	}
+	opts, err := options()
+	if err != nil {
+		return err
+	}
+	if !opts.Jitter.enabled() {
+		return fmt.Errorf("%w: must give a -jitter to perturb the workload with", ErrInvalidArgs)
+	}
+	if *runs <= 0 {
+		return fmt.Errorf("%w: runs must be positive", ErrInvalidArgs)
+	}
+	if _, ok := tableStyles[*tableStyle]; !ok && *tableStyle != tsvStyle {
+		return fmt.Errorf("%w: unknown table style %q", ErrInvalidArgs, *tableStyle)
+	}
+	selected, err := selectAlgorithms(*names)
+	if err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: must give a scheduling file", ErrInvalidArgs)
+	}
+	processes, err := loadWorkload(fs.Arg(0), opts.timeBase())
+	if err != nil {
+		return err
+	}
+	if err := validateJobs(processes, opts); err != nil {
+		return err
+	}
+
+	outputSensitivity(w, selected, sensitivity(processes, opts, selected, *runs), *runs, *tableStyle)
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_parseJitter(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		spec    string
+		base    timeBase
+		want    Jitter
+		wantErr bool
+	}{
+		{spec: "", want: Jitter{}},
+		{spec: "arrival=±2,burst=±10%", want: Jitter{Arrival: jitterAmount{ticks: 2}, Burst: jitterAmount{fraction: 0.1}}},
+		{spec: "arrival=+-1.5", base: timeBase{resolution: 10}, want: Jitter{Arrival: jitterAmount{ticks: 15}}},
+		{spec: "burst=3", want: Jitter{Burst: jitterAmount{ticks: 3}}},
+		{spec: "arrival", wantErr: true},
+		{spec: "priority=±1", wantErr: true},
+		{spec: "burst=±ten%", wantErr: true},
+		{spec: "arrival=-2", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseJitter(tt.spec, tt.base)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidArgs) {
+				t.Errorf("parseJitter(%q) error = %v, want %v", tt.spec, err, ErrInvalidArgs)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("parseJitter(%q) = %+v, %v, want %+v", tt.spec, got, err, tt.want)
+		}
+	}
+}
+
+func TestJitter_perturb(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 10},
+		{ProcessID: 2, ArrivalTime: 5, BurstDuration: 1},
+		{ProcessID: 3, ArrivalTime: 9, BurstDuration: 40},
+	}
+	j := Jitter{Arrival: jitterAmount{ticks: 2}, Burst: jitterAmount{fraction: 0.5}}
+	got := j.perturb(processes, 3)
+	if !reflect.DeepEqual(got, j.perturb(processes, 3)) {
+		t.Errorf("perturb() differs for the same seed")
+	}
+	for i, p := range got {
+		orig := processes[i]
+		if p.ArrivalTime < 0 || p.ArrivalTime < orig.ArrivalTime-2 || p.ArrivalTime > orig.ArrivalTime+2 {
+			t.Errorf("process %d: arrival %d outside %d±2", p.ProcessID, p.ArrivalTime, orig.ArrivalTime)
+		}
+		bound := orig.BurstDuration / 2
+		if p.BurstDuration < 1 || p.BurstDuration < orig.BurstDuration-bound || p.BurstDuration > orig.BurstDuration+bound+1 {
+			t.Errorf("process %d: burst %d outside %d±50%%", p.ProcessID, p.BurstDuration, orig.BurstDuration)
+		}
+	}
+	if processes[2].BurstDuration != 40 {
+		t.Errorf("perturb() changed the workload")
+	}
+	if got := (Jitter{}).perturb(processes, 3); !reflect.DeepEqual(got, processes) {
+		t.Errorf("no jitter perturbed the workload: %v", got)
+	}
+}
+
+func Test_sensitivityCommand(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "workload.csv")
+	if err := os.WriteFile(path, []byte("1,5,0,2\n2,9,3,1\n3,6,6,3\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		args    []string
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "jittered",
+			args: []string{"-algorithms", "fcfs,rr", "-runs", "5", "-jitter", "arrival=±2,burst=±20%", path},
+			want: []string{"Sensitivity over 5 perturbed runs", "First-come, first-serve", "Round-robin", "Average turnaround"},
+		},
+		{name: "no jitter", args: []string{path}, wantErr: ErrInvalidArgs},
+		{name: "no runs", args: []string{"-jitter", "burst=1", "-runs", "0", path}, wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			err := sensitivityCommand(&w, tt.args)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("sensitivityCommand() error = %v, want %v", err, tt.wantErr)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(w.String(), want) {
+					t.Errorf("sensitivityCommand() = %s, want it to contain %q", w.String(), want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// latexGanttWidth is the width of a TikZ GANTT chart in centimeters.
+const latexGanttWidth = 14.0
+
+var latexEscaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`, "&", `\&`, "%", `\%`, "$", `\$`, "#", `\#`, "_", `\_`,
+	"{", `\{`, "}", `\}`, "~", `\textasciitilde{}`, "^", `\textasciicircum{}`,
+)
+
+// outputLaTeX outputs each result as a LaTeX fragment to \input into a report: a TikZ GANTT chart and
+// a booktabs schedule table. It needs the booktabs, tikz and xcolor packages.
+func outputLaTeX(w io.Writer, results []result, r Report) error {
+	_, _ = fmt.Fprintln(w, `% Requires \usepackage{booktabs,tikz,xcolor}`)
+	pids := make(map[int64]bool)
+	for _, res := range results {
+		for _, ts := range res.schedule.timeline() {
+			pids[ts.PID] = true
+		}
+	}
+	sorted := make([]int64, 0, len(pids))
+	for pid := range pids {
+		sorted = append(sorted, pid)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	for _, pid := range sorted {
+		_, _ = fmt.Fprintf(w, "\\definecolor{%s}{HTML}{%s}\n", latexColor(pid), strings.ToUpper(strings.TrimPrefix(pidColor(pid), "#")))
+	}
+
+	for _, res := range results {
+		s := res.schedule
+		_, _ = fmt.Fprintf(w, "\n\\subsection*{%s}\n\n", latexEscaper.Replace(res.title))
+		outputTikZGantt(w, s.timeline(), s.timeBase())
+
+		footer := s.footer(r)
+		for i := range footer {
+			footer[i] = strings.ReplaceAll(footer[i], "\n", " ")
+		}
+		outputLaTeXTable(w, scheduleHeader(r), s.rows(r), footer)
+	}
+	if r.Compare {
+		_, _ = fmt.Fprint(w, "\n\\subsection*{Comparison}\n\n")
+		header, rows := comparisonRows(results)
+		outputLaTeXTable(w, header, rows, nil)
+		_, _ = fmt.Fprintln(w, `* best value`)
+	}
+
+	return nil
+}
+
+// latexColor names the color defined for a process ID.
+func latexColor(pid int64) string {
+	if pid == idlePID || pid == switchPID {
+		return "pidIdle"
+	}
+
+	return fmt.Sprintf("pid%d", pid)
+}
+
+// outputTikZGantt outputs a timeline as a TikZ picture latexGanttWidth centimeters wide, its axis
+// labeled in time units of a time base.
+func outputTikZGantt(w io.Writer, timeline []TimeSlice, base timeBase) {
+	if len(timeline) == 0 {
+		return
+	}
+	start, stop := timeline[0].Start, timeline[len(timeline)-1].Stop
+	scale := latexGanttWidth
+	if stop > start {
+		scale /= float64(stop - start)
+	}
+	_, _ = fmt.Fprintf(w, "\\begin{tikzpicture}[x=%.4fcm, y=0.8cm]\n", scale)
+	for _, ts := range timeline {
+		label := sliceLabel(ts.PID)
+		_, _ = fmt.Fprintf(w, "  \\draw[fill=%s, draw=white] (%d,0) rectangle (%d,1) node[midway] {%s};\n",
+			latexColor(ts.PID), ts.Start, ts.Stop, label)
+	}
+	_, _ = fmt.Fprintf(w, "  \\draw (%d,0) -- (%d,0);\n", start, stop)
+	for _, t := range ganttTicks(start, stop) {
+		_, _ = fmt.Fprintf(w, "  \\draw (%d,0) -- (%d,-0.1) node[below] {\\small %s};\n", t, t, formatTicks(t, base))
+	}
+	_, _ = fmt.Fprint(w, "\\end{tikzpicture}\n\n")
+}
+
+// outputLaTeXTable outputs a booktabs tabular, right-aligning every column but the first. A nil
+// footer is left out.
+func outputLaTeXTable(w io.Writer, header []string, rows [][]string, footer []string) {
+	line := func(cells []string) string {
+		escaped := make([]string, len(cells))
+		for i, c := range cells {
+			escaped[i] = latexEscaper.Replace(c)
+		}
+		return "  " + strings.Join(escaped, " & ") + ` \\`
+	}
+
+	_, _ = fmt.Fprintf(w, "\\begin{tabular}{l%s}\n", strings.Repeat("r", len(header)-1))
+	_, _ = fmt.Fprintln(w, `  \toprule`)
+	_, _ = fmt.Fprintln(w, line(header))
+	_, _ = fmt.Fprintln(w, `  \midrule`)
+	for _, row := range rows {
+		_, _ = fmt.Fprintln(w, line(row))
+	}
+	if footer != nil {
+		_, _ = fmt.Fprintln(w, `  \midrule`)
+		_, _ = fmt.Fprintln(w, line(footer))
+	}
+	_, _ = fmt.Fprintln(w, `  \bottomrule`)
+	_, _ = fmt.Fprintln(w, `\end{tabular}`)
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_outputLaTeX(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 4, BurstDuration: 2, Priority: 1},
+	}
+	results := []result{{title: "Shortest-job-first", schedule: sjf(processes, Options{})}}
+
+	var w bytes.Buffer
+	if err := outputLaTeX(&w, results, Report{SlowdownBound: defaultSlowdownBound, Compare: true}); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`\definecolor{pid1}{HTML}{F28E2B}`,
+		`\definecolor{pidIdle}{HTML}{E8E8E8}`,
+		`\begin{tikzpicture}[x=2.3333cm, y=0.8cm]`,
+		`\draw[fill=pidIdle, draw=white] (2,0) rectangle (4,1) node[midway] {IDLE};`,
+		"\\begin{tabular}{lrrrrrrrrrr}\n  \\toprule\n",
+		`  2 & 1 & 2 & 4 & 0 & 2 & 1.00 & 1.00 & 0 & 1 & 6 \\`,
+		`Average 0.00 p95=0.00 p99=0.00 & Average 2.00`,
+		`CPU utilization & Fairness`,
+		`66.67\% *`,
+	} {
+		if got := w.String(); !strings.Contains(got, want) {
+			t.Errorf("outputLaTeX() = %v, want it to contain %q", got, want)
+		}
+	}
+}
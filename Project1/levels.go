@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// priorityHeader names the per-priority table columns.
+var priorityHeader = []string{"Priority", "Processes", "Avg wait", "Avg turnaround", "Avg response", "Max wait", "CPU share"}
+
+// priorityRows formats the schedule's metrics grouped by priority level, one row per level in
+// numeric order: the average wait, turnaround and response, the longest wait and the level's share of
+// the CPU's busy time, which makes starvation of the less important levels visible at a glance.
+func (s Schedule) priorityRows() [][]string {
+	byLevel := make(map[int64][]int)
+	for i, p := range s.Processes {
+		byLevel[p.Priority] = append(byLevel[p.Priority], i)
+	}
+	levels := make([]int64, 0, len(byLevel))
+	for level := range byLevel {
+		levels = append(levels, level)
+	}
+	sort.Slice(levels, func(i, j int) bool { return levels[i] < levels[j] })
+
+	response := s.Response()
+	busy := s.BusyTime()
+	rows := make([][]string, len(levels))
+	for row, level := range levels {
+		var (
+			wait, turnaround, resp []int64
+			longest, work          int64
+		)
+		for _, i := range byLevel[level] {
+			wait = append(wait, s.Wait[i])
+			turnaround = append(turnaround, s.Turnaround[i])
+			resp = append(resp, response[i])
+			if s.Wait[i] > longest {
+				longest = s.Wait[i]
+			}
+			work += s.Processes[i].BurstDuration
+		}
+		share := 0.0
+		if busy > 0 {
+			share = float64(work) / float64(busy) * 100
+		}
+		rows[row] = []string{
+			fmt.Sprint(level),
+			fmt.Sprint(len(wait)),
+			s.formatUnits(s.inUnits(average(wait))),
+			s.formatUnits(s.inUnits(average(turnaround))),
+			s.formatUnits(s.inUnits(average(resp))),
+			s.formatTime(longest),
+			fmt.Sprintf("%.2f%%", share),
+		}
+	}
+
+	return rows
+}
+
+// outputPriorityLevels outputs a table of the schedule's metrics per priority level.
+func outputPriorityLevels(w io.Writer, s Schedule, r Report) {
+	_, _ = fmt.Fprintln(w, "By priority level")
+	outputTable(w, r.TableStyle, priorityHeader, s.priorityRows(), nil, nil)
+	_, _ = fmt.Fprintln(w)
+}
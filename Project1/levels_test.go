@@ -0,0 +1,22 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSchedule_priorityRows(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 4, ArrivalTime: 0, Priority: 2},
+		{ProcessID: 2, BurstDuration: 2, ArrivalTime: 0, Priority: 1},
+		{ProcessID: 3, BurstDuration: 2, ArrivalTime: 1, Priority: 1},
+	}
+	want := [][]string{
+		{"1", "2", "0.50", "2.50", "0.50", "1", "50.00%"},
+		{"2", "1", "4.00", "8.00", "4.00", "4", "50.00%"},
+	}
+	if got := preemptivePriority(processes, Options{}).priorityRows(); !reflect.DeepEqual(got, want) {
+		t.Errorf("priorityRows() = %q, want %q", got, want)
+	}
+}
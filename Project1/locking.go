@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// lockingProtocol names how the resource simulation bounds priority inversion, a process blocking on
+// a resource held by one of lower priority.
+type lockingProtocol string
+
+const (
+	lockingNone        lockingProtocol = "none"        // processes keep their own priority
+	lockingInheritance lockingProtocol = "inheritance" // a holder runs at the highest priority it blocks
+	lockingCeiling     lockingProtocol = "ceiling"     // inheritance, and locks only above the system ceiling
+)
+
+// lockingProtocols are the locking protocols, the default first.
+var lockingProtocols = []lockingProtocol{lockingNone, lockingInheritance, lockingCeiling}
+
+// parseLockingProtocol validates a locking protocol name.
+func parseLockingProtocol(name string) (lockingProtocol, error) {
+	for _, protocol := range lockingProtocols {
+		if lockingProtocol(name) == protocol {
+			return protocol, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: unknown protocol %q (want %s)", ErrInvalidArgs, name, strings.Join(lockingProtocolNames(), ", "))
+}
+
+func lockingProtocolNames() []string {
+	names := make([]string, len(lockingProtocols))
+	for i, protocol := range lockingProtocols {
+		names[i] = string(protocol)
+	}
+
+	return names
+}
+
+// resourceCeilings returns the priority ceiling of every resource: the highest priority, the lowest
+// number, of the processes that request it.
+func resourceCeilings(processes []Process) map[string]int64 {
+	ceilings := make(map[string]int64)
+	for _, p := range processes {
+		for _, e := range p.Resources {
+			if c, ok := ceilings[e.Resource]; !e.Release && (!ok || p.Priority < c) {
+				ceilings[e.Resource] = p.Priority
+			}
+		}
+	}
+
+	return ceilings
+}
+
+// blockedBy returns the process that process i is blocked by, either as the holder of the resource
+// it waits for or as the holder of the ceiling it is below, or -1 if it is not blocked.
+func (sim *resourceSim) blockedBy(i int) int {
+	if resource := sim.waiting[i]; resource != "" {
+		return sim.holder[resource]
+	}
+
+	return sim.blocker[i]
+}
+
+// effective returns the priority each process runs at. Under inheritance and the ceiling protocol a
+// process blocking others runs at the highest priority among them, transitively.
+func (sim *resourceSim) effective() []int64 {
+	priority := make([]int64, len(sim.processes))
+	for i, p := range sim.processes {
+		priority[i] = p.Priority
+	}
+	if sim.protocol == lockingNone {
+		return priority
+	}
+	for changed := true; changed; {
+		changed = false
+		for i := range sim.processes {
+			if h := sim.blockedBy(i); h >= 0 && priority[i] < priority[h] {
+				priority[h] = priority[i]
+				changed = true
+			}
+		}
+	}
+
+	return priority
+}
+
+// prioritized returns the processes with their effective priorities, for the scheduler to pick by.
+func (sim *resourceSim) prioritized() []Process {
+	if sim.protocol == lockingNone {
+		return sim.processes
+	}
+	processes := append([]Process(nil), sim.processes...)
+	for i, priority := range sim.effective() {
+		processes[i].Priority = priority
+	}
+
+	return processes
+}
+
+// ceilingBlocker returns the process holding the system ceiling that keeps process i from locking a
+// free resource under the ceiling protocol, or -1 if i may lock it: i must have a higher priority than
+// the ceiling of every resource the other processes hold.
+func (sim *resourceSim) ceilingBlocker(i int) (blocker int, resource string) {
+	if sim.protocol != lockingCeiling {
+		return -1, ""
+	}
+	var (
+		priority = sim.effective()[i]
+		ceiling  = int64(math.MaxInt64)
+	)
+	blocker = -1
+	for r, h := range sim.holder {
+		if c := sim.ceilings[r]; h != i && c <= priority && (c < ceiling || c == ceiling && r < resource) {
+			blocker, resource, ceiling = h, r, c
+		}
+	}
+
+	return blocker, resource
+}
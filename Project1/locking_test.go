@@ -0,0 +1,88 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// inversion is the classic priority inversion: 1 blocks on S held by 3 while 2, of a priority in
+// between, keeps 3 from running.
+const inversion = `3,4,0,3
+resource: pid=3 at=0 request=S
+resource: pid=3 at=3 release=S
+1,3,1,1
+resource: pid=1 at=1 request=S
+resource: pid=1 at=2 release=S
+2,4,2,2
+`
+
+// nestedLocks deadlock under plain priority scheduling, 1 and 2 locking A and B in opposite orders.
+const nestedLocks = `2,4,0,2
+resource: pid=2 at=0 request=B
+resource: pid=2 at=2 request=A
+1,4,1,1
+resource: pid=1 at=0 request=A
+resource: pid=1 at=2 request=B
+`
+
+func Test_simulateResources_protocols(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		input          string
+		protocol       lockingProtocol
+		wantCompletion []int64
+		wantBlocked    []int64
+		wantDeadlock   bool
+	}{
+		{name: "inversion", input: inversion, protocol: lockingNone, wantCompletion: []int64{11, 10, 6}, wantBlocked: []int64{0, 6, 0}},
+		{name: "inversion with inheritance", input: inversion, protocol: lockingInheritance, wantCompletion: []int64{11, 6, 10}, wantBlocked: []int64{0, 2, 0}},
+		{name: "inversion with ceiling", input: inversion, protocol: lockingCeiling, wantCompletion: []int64{11, 6, 10}, wantBlocked: []int64{0, 2, 0}},
+		{name: "nested locks", input: nestedLocks, protocol: lockingNone, wantCompletion: []int64{0, 0}, wantBlocked: []int64{1, 2}, wantDeadlock: true},
+		{name: "nested locks with inheritance", input: nestedLocks, protocol: lockingInheritance, wantCompletion: []int64{0, 0}, wantBlocked: []int64{1, 2}, wantDeadlock: true},
+		{name: "nested locks with ceiling", input: nestedLocks, protocol: lockingCeiling, wantCompletion: []int64{4, 8}, wantBlocked: []int64{0, 3}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			processes, err := loadProcesses(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			run := simulateResources(processes, resourcePicks["priority"], tt.protocol)
+			if !reflect.DeepEqual(run.completion, tt.wantCompletion) {
+				t.Errorf("completion = %v, want %v", run.completion, tt.wantCompletion)
+			}
+			if !reflect.DeepEqual(run.blocked, tt.wantBlocked) {
+				t.Errorf("blocked = %v, want %v", run.blocked, tt.wantBlocked)
+			}
+			if got := run.deadlock != nil; got != tt.wantDeadlock {
+				t.Errorf("deadlocked = %v, want %v", got, tt.wantDeadlock)
+			}
+		})
+	}
+}
+
+func Test_resourceCeilings(t *testing.T) {
+	t.Parallel()
+	processes, err := loadProcesses(strings.NewReader(inversion))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := resourceCeilings(processes), map[string]int64{"S": 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("resourceCeilings() = %v, want %v", got, want)
+	}
+}
+
+func Test_parseLockingProtocol(t *testing.T) {
+	t.Parallel()
+	if got, err := parseLockingProtocol("ceiling"); err != nil || got != lockingCeiling {
+		t.Errorf("parseLockingProtocol(ceiling) = %q, %v", got, err)
+	}
+	if _, err := parseLockingProtocol("mutex"); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("parseLockingProtocol(mutex) error = %v, want ErrInvalidArgs", err)
+	}
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// logLevel orders log messages from the chattiest to the most severe.
+type logLevel int
+
+const (
+	levelDebug logLevel = iota
+	levelInfo
+	levelWarn
+	levelError
+)
+
+var logLevelNames = [...]string{
+	levelDebug: "debug",
+	levelInfo:  "info",
+	levelWarn:  "warn",
+	levelError: "error",
+}
+
+// logger writes leveled diagnostics with key/value attributes, either as a line of text or as one
+// JSON object per line. It is safe for concurrent use.
+type logger struct {
+	mu    sync.Mutex
+	w     io.Writer
+	level logLevel // the least severe level written
+	json  bool
+	now   func() time.Time
+}
+
+// logs is the logger of the command line, configured by the -verbose, -quiet and -log-format flags.
+var logs = newLogger(os.Stderr)
+
+func newLogger(w io.Writer) *logger {
+	return &logger{w: w, level: levelWarn, now: time.Now}
+}
+
+// configure sets the least severe level written and whether lines are JSON.
+func (l *logger) configure(level logLevel, asJSON bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.level = level
+	l.json = asJSON
+}
+
+func (l *logger) Debug(msg string, attrs ...any) { l.log(levelDebug, msg, attrs) }
+func (l *logger) Info(msg string, attrs ...any)  { l.log(levelInfo, msg, attrs) }
+func (l *logger) Warn(msg string, attrs ...any)  { l.log(levelWarn, msg, attrs) }
+func (l *logger) Error(msg string, attrs ...any) { l.log(levelError, msg, attrs) }
+
+// log writes msg and its attributes, alternating keys and values, if level is severe enough.
+func (l *logger) log(level logLevel, msg string, attrs []any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if level < l.level {
+		return
+	}
+
+	var b strings.Builder
+	if l.json {
+		b.WriteString(`{"time":`)
+		writeJSONValue(&b, l.now().UTC().Format(time.RFC3339))
+		b.WriteString(`,"level":`)
+		writeJSONValue(&b, logLevelNames[level])
+		b.WriteString(`,"msg":`)
+		writeJSONValue(&b, msg)
+		for i := 0; i+1 < len(attrs); i += 2 {
+			b.WriteByte(',')
+			writeJSONValue(&b, fmt.Sprint(attrs[i]))
+			b.WriteByte(':')
+			writeJSONValue(&b, attrs[i+1])
+		}
+		b.WriteString("}\n")
+	} else {
+		b.WriteString(strings.ToUpper(logLevelNames[level]))
+		b.WriteByte(' ')
+		b.WriteString(msg)
+		for i := 0; i+1 < len(attrs); i += 2 {
+			fmt.Fprintf(&b, " %v=%s", attrs[i], logText(attrs[i+1]))
+		}
+		b.WriteByte('\n')
+	}
+	_, _ = io.WriteString(l.w, b.String())
+}
+
+// writeJSONValue writes v as JSON, errors as their message.
+func writeJSONValue(b *strings.Builder, v any) {
+	if err, ok := v.(error); ok {
+		v = err.Error()
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		data, _ = json.Marshal(fmt.Sprint(v))
+	}
+	b.Write(data)
+}
+
+// logText formats a text attribute value, quoted when it would not read as one word.
+func logText(v any) string {
+	s := fmt.Sprint(v)
+	if s == "" || strings.ContainsAny(s, " \t\n\"=") {
+		return strconv.Quote(s)
+	}
+
+	return s
+}
+
+// addLogFlags adds the logging flags to fs. The returned function configures logs from them.
+func addLogFlags(fs *flag.FlagSet) func() error {
+	verbose := fs.Bool("verbose", false, "log what the simulator is doing to standard error")
+	quiet := fs.Bool("quiet", false, "log only errors")
+	format := fs.String("log-format", "text", "log format: text or json")
+
+	return func() error {
+		if *verbose && *quiet {
+			return fmt.Errorf("%w: -verbose and -quiet are mutually exclusive", ErrInvalidArgs)
+		}
+		if *format != "text" && *format != "json" {
+			return fmt.Errorf("%w: unknown log format %q (want text or json)", ErrInvalidArgs, *format)
+		}
+		level := levelWarn
+		switch {
+		case *verbose:
+			level = levelDebug
+		case *quiet:
+			level = levelError
+		}
+		logs.configure(level, *format == "json")
+
+		return nil
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"testing"
+	"time"
+)
+
+func Test_logger(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		level  logLevel
+		asJSON bool
+		want   string
+	}{
+		{
+			name:  "text",
+			level: levelInfo,
+			want: "INFO loaded workload file=\"my file.csv\" processes=3\n" +
+				"WARN closing err=\"disk gone\"\n",
+		},
+		{
+			name:  "quiet",
+			level: levelError,
+		},
+		{
+			name:   "json",
+			level:  levelDebug,
+			asJSON: true,
+			want: `{"time":"2026-01-02T03:04:05Z","level":"debug","msg":"scheduled","algorithm":"fcfs"}` + "\n" +
+				`{"time":"2026-01-02T03:04:05Z","level":"info","msg":"loaded workload","file":"my file.csv","processes":3}` + "\n" +
+				`{"time":"2026-01-02T03:04:05Z","level":"warn","msg":"closing","err":"disk gone"}` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			l := newLogger(&w)
+			l.now = func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) }
+			l.configure(tt.level, tt.asJSON)
+			l.Debug("scheduled", "algorithm", "fcfs")
+			l.Info("loaded workload", "file", "my file.csv", "processes", 3)
+			l.Warn("closing", "err", errors.New("disk gone"))
+			if got := w.String(); got != tt.want {
+				t.Errorf("log output =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_addLogFlags(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr error
+	}{
+		{name: "default"},
+		{name: "verbose and quiet", args: []string{"-verbose", "-quiet"}, wantErr: ErrInvalidArgs},
+		{name: "bad format", args: []string{"-log-format", "xml"}, wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			logging := addLogFlags(fs)
+			if err := fs.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			if err := logging(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,43 +1,268 @@
 package main
 
 import (
+	"bufio"
+	"context"
 	"encoding/csv"
 	"errors"
+	"flag"
 	"fmt"
 	"io"
-	"log"
+	"math"
 	"os"
+	"os/signal"
+	"sort"
 	"strconv"
 	"strings"
-	"github.com/olekukonko/tablewriter"
-	"math"
+	"time"
 )
 
+// command is a subcommand selectable as the first CLI argument.
+type command struct {
+	run     func(w io.Writer, args []string) error
+	summary string
+}
+
+// commands are the subcommands by name.
+var commands = map[string]command{
+	"run":            {run: runSubcommand, summary: "schedule a workload with every algorithm (the default)"},
+	"validate":       {run: validateCommand, summary: "check workload files without scheduling them"},
+	"compare":        {run: compareCommand, summary: "schedule a workload with some algorithms and compare them"},
+	"step":           {run: stepCommand, summary: "step through one algorithm's schedule"},
+	"generate":       {run: generateCommand, summary: "write a random workload"},
+	"import-borg":    {run: borgCommand, summary: "convert a Google Borg trace into a workload"},
+	"import-perf":    {run: perfCommand, summary: "replay a perf sched trace against the algorithms"},
+	"snapshot":       {run: snapshotCommand, summary: "capture the running processes as a workload"},
+	"bench":          {run: benchCommand, summary: "benchmark the algorithms on generated workloads"},
+	"serve":          {run: serveCommand, summary: "serve the dashboard, HTTP and JSON APIs"},
+	"disk":           {run: diskCommand, summary: "simulate disk head scheduling of cylinder requests"},
+	"paging":         {run: pagingCommand, summary: "simulate page replacement of a reference string"},
+	"memory":         {run: memoryCommand, summary: "simulate contiguous memory allocation of requests"},
+	"banker":         {run: bankerCommand, summary: "check a resource allocation state is safe with the banker's algorithm"},
+	"deadlock":       {run: deadlockCommand, summary: "simulate resource requests and detect deadlock"},
+	"multicore":      {run: multicoreCommand, summary: "schedule a workload on several CPUs of different speeds"},
+	"sensitivity":    {run: sensitivityCommand, summary: "check how stable each algorithm's averages are under a jittered workload"},
+	"aggregate":      {run: aggregateCommand, summary: "summarize each algorithm's metrics over several workloads"},
+	"diff":           {run: diffCommand, summary: "compare two saved result files, listing what differs and by how much"},
+	"verify":         {run: verifyCommand, summary: "check the schedules of a workload against an expected-results file"},
+	"schedulability": {run: schedulabilityCommand, summary: "check whether a periodic task set is schedulable under RM and EDF"},
+	"completion":     {run: completionCommand, summary: "write a bash, zsh or fish completion script"},
+}
+
+// platformMain, when set, replaces the command line, as in the WebAssembly build's JavaScript bindings.
+var platformMain func()
+
 func main() {
-	// CLI args
-	f, closeFile, err := openProcessingFile(os.Args...)
-	if err != nil {
-		log.Fatal(err)
+	if platformMain != nil {
+		platformMain()
+		return
+	}
+
+	if len(os.Args) < 2 {
+		usage(os.Stderr, os.Args[0])
+		exit(fmt.Errorf("%w: must give a command or a scheduling file", ErrInvalidArgs))
+		return
 	}
-	defer closeFile()
 
-	// Load and parse processes
-	processes, err := loadProcesses(f)
+	// Subcommands
+	if cmd, ok := commands[os.Args[1]]; ok {
+		exit(cmd.run(os.Stdout, os.Args[2:]))
+		return
+	}
+	switch os.Args[1] {
+	case "help":
+		usage(os.Stdout, os.Args[0])
+		return
+	case "__complete":
+		exit(completeCommand(os.Stdout, os.Args[2:]))
+		return
+	}
+
+	// A bare workload file is short for run.
+	exit(runCommand(os.Stdout, os.Args))
+}
+
+// usage lists the subcommands. Each one describes its own flags with -h.
+func usage(w io.Writer, program string) {
+	_, _ = fmt.Fprintf(w, "Usage: %s <command> [flags] [arguments]\n", program)
+	_, _ = fmt.Fprintf(w, "       %s [run flags] <workload.csv>\n\nCommands:\n", program)
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		_, _ = fmt.Fprintf(w, "  %-12s %s\n", name, commands[name].summary)
+	}
+	_, _ = fmt.Fprintf(w, "\nRun %s <command> -h for the flags of a command.\n", program)
+}
+
+// runSubcommand is runCommand as the run subcommand, whose args do not start with the program name.
+func runSubcommand(w io.Writer, args []string) error {
+	return runCommand(w, append([]string{"run"}, args...))
+}
+
+// runCommand schedules the workload file named in args with every algorithm. args starts with the
+// program name.
+func runCommand(w io.Writer, args []string) (err error) {
+	// CLI flags
+	fs := newFlagSet(args[0])
+	watch := fs.Bool("watch", false, "re-run every time the workload file changes, until interrupted")
+	runs := fs.Int("runs", 1, "schedule this many times, seeded from -seed onwards, and summarize the means with 95% confidence intervals")
+	checkpointPath := fs.String("checkpoint", "", "save each completed schedule to this file and resume from it when rerun after an interruption")
+	rubricPath := fs.String("assert", "", "check the schedules against the assertions of this rubric file, e.g. \"fcfs average wait == 12.33\", failing if any does not hold")
+	ganttStream := fs.String("gantt-stream", "", "stream the GANTT slices run-length encoded to this file instead of keeping them, for huge workloads")
+	options := addOptionFlags(fs)
+	report := addReportFlags(fs)
+	if err := parseFlags(fs, args[1:]); err != nil {
+		return err
+	}
+	r, err := report()
+	if err != nil {
+		return err
+	}
+	var results []result
+	defer func() { err = writeRunSummary(r, results, err) }()
+	opts, err := options()
 	if err != nil {
-		log.Fatal(err)
+		return err
+	}
+	if err := r.parseStarvation(opts.timeBase()); err != nil {
+		return err
+	}
+	if err := validateRuns(*runs, r); err != nil {
+		return err
+	}
+	var rubric []assertion
+	if *rubricPath != "" {
+		if *runs > 1 {
+			return fmt.Errorf("%w: -assert checks a single run", ErrInvalidArgs)
+		}
+		if rubric, err = loadRubric(*rubricPath); err != nil {
+			return err
+		}
+	}
+	if *ganttStream != "" {
+		if err := validateGanttStream(opts, r, *runs, *checkpointPath); err != nil {
+			return err
+		}
+	}
+
+	// CLI args
+	args = append([]string{args[0]}, fs.Args()...)
+	run := func() error {
+		f, closeFile, err := openProcessingFile(args...)
+		if err != nil {
+			return err
+		}
+		defer closeFile()
+
+		// Load and parse processes
+		processes, err := loadProcessesAt(f, opts.timeBase())
+		if err != nil {
+			return err
+		}
+		if err := validateProcesses(processes); err != nil {
+			return err
+		}
+		if err := validateJobs(processes, opts); err != nil {
+			return err
+		}
+		logs.Info("loaded workload", "file", f.Name(), "processes", len(processes))
+
+		if r.Format == "text" && len(periodicTasks(processes)) > 0 {
+			outputSchedulability(w, periodicTasks(processes), opts.timeBase(), r.TableStyle)
+		}
+		var cp *checkpointer
+		if *checkpointPath != "" {
+			if cp, err = openCheckpoint(*checkpointPath, processes, opts); err != nil {
+				return err
+			}
+		}
+		if *runs > 1 {
+			if !opts.randomized(processes) {
+				logs.Warn("nothing is random, so every run is the same; set -jitter or -tie-break random")
+			}
+			samples, err := monteCarlo(processes, opts, algorithms, *runs, cp)
+			if err != nil {
+				return err
+			}
+			results = samples[0]
+			outputMonteCarlo(w, samples, r)
+			return cp.finish()
+		}
+		if *ganttStream != "" {
+			return writeFile(*ganttStream, func(f io.Writer) error {
+				gantt := bufio.NewWriter(f)
+				opts := opts
+				opts.GanttStream = gantt
+				results = scheduleAll(processes, opts, algorithms)
+				if err := gantt.Flush(); err != nil {
+					return err
+				}
+				if err := outputResults(w, results, r); err != nil {
+					return err
+				}
+				if rubric != nil {
+					if err := checkRubric(w, rubric, results); err != nil {
+						return err
+					}
+				}
+				return missedDeadlines(results)
+			})
+		}
+		if results, err = cp.scheduleAll(0, processes, opts, algorithms); err != nil {
+			return err
+		}
+		if opts.ExactMetrics {
+			if err := inexactMetrics(results); err != nil {
+				return err
+			}
+		}
+		if err := outputResults(w, results, r); err != nil {
+			return err
+		}
+		if err := cp.finish(); err != nil {
+			return err
+		}
+		if rubric != nil {
+			if err := checkRubric(w, rubric, results); err != nil {
+				return err
+			}
+		}
+
+		return missedDeadlines(results)
+	}
+	if !*watch || len(args) != 2 {
+		return run()
 	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	return watchFile(ctx, args[1], watchInterval, w, isTerminal(os.Stdout), run)
+}
+
+// scheduleAll runs each algorithm over the processes.
+func scheduleAll(processes []Process, opts Options, algs []algorithm) []result {
+	results, _ := scheduleAllContext(context.Background(), processes, opts, algs) // a background context is never done
+
+	return results
+}
 
-	// First-come, first-serve scheduling
-	FCFSSchedule(os.Stdout, "First-come, first-serve", processes)
+// scheduleAllContext is scheduleAll stopping at the first schedule cancelled by ctx.
+func scheduleAllContext(ctx context.Context, processes []Process, opts Options, algs []algorithm) ([]result, error) {
+	results := make([]result, len(algs))
+	for i, a := range algs {
+		s, err := a.scheduleContext(ctx, processes, opts)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = result{title: a.title, schedule: s}
+		logs.Debug("scheduled", "algorithm", a.name, "makespan", results[i].schedule.Makespan(),
+			"context_switches", results[i].schedule.ContextSwitches())
+	}
 
-	// Shortest job first scheduling
-	SJFSchedule(os.Stdout, "Shortest-job-first", processes)
-	
-	// Priority scheduling
-	SJFPrioritySchedule(os.Stdout, "Priority", processes)
-	
-	// Round robin scheduling
-	RRSchedule(os.Stdout, "Round-robin", processes)
+	return results, nil
 }
 
 func openProcessingFile(args ...string) (*os.File, func(), error) {
@@ -51,27 +276,483 @@ func openProcessingFile(args ...string) (*os.File, func(), error) {
 	}
 	closeFn := func() {
 		if err := f.Close(); err != nil {
-			log.Fatalf("%v: error closing scheduling file", err)
+			logs.Warn("error closing scheduling file", "file", args[1], "err", err)
 		}
 	}
 
 	return f, closeFn, nil
 }
 
+// loadWorkload opens, parses and validates the workload file at path, whose times are read in ticks
+// of a time base.
+func loadWorkload(path string, base timeBase) ([]Process, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("%v: error opening scheduling file", err)
+	}
+	defer f.Close()
+
+	processes, err := loadProcessesAt(f, base)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateProcesses(processes); err != nil {
+		return nil, err
+	}
+	logs.Info("loaded workload", "file", path, "processes", len(processes))
+
+	return processes, nil
+}
+
+// validateCommand checks that each workload file parses and can be scheduled, writing a line per
+// valid file to w, and stops at the first one that is not.
+func validateCommand(w io.Writer, args []string) error {
+	fs := newFlagSet("validate")
+	times := addTimeFlags(fs)
+	logging := addLogFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := logging(); err != nil {
+		return err
+	}
+	base, err := times()
+	if err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("%w: must give scheduling files to validate", ErrInvalidArgs)
+	}
+	for _, path := range fs.Args() {
+		processes, err := loadWorkload(path, base)
+		if err != nil {
+			return fmt.Errorf("%w (%s)", err, path)
+		}
+		_, _ = fmt.Fprintf(w, "%s: ok, %d processes\n", path, len(processes))
+	}
+
+	return nil
+}
+
 type (
 	Process struct {
 		ProcessID     int64
 		ArrivalTime   int64
 		BurstDuration int64
 		Priority      int64
+		Resources     []ResourceEvent // requests and releases of resources, in the order made
+		Deps          []int64         // IDs of the processes that must complete before it is ready
+		Class         string          // job class, one of jobClasses, or empty when untagged
+		Period        int64           // time between job releases of a periodic task, 0 for a one-off process
+		Deadline      int64           // relative deadline, 0 for none or, for a periodic task, its period
+		Quantum       int64           // round-robin time quantum of the process, 0 for the global one
+		Threshold     int64           // preemption threshold, a priority at least as important as Priority
+		HasThreshold  bool            // Threshold was given, as 0 is a priority like any other
+		Sporadic      bool            // Period is only the least time between job releases, which are random
+		Task          int64           // ID of the periodic task that released the job, when Job is not 0
+		Job           int             // number of the job the periodic task released, counting from 1
+
+		input int // position in the workload, which input order follows once sorted by arrival
 	}
 	TimeSlice struct {
 		PID   int64
 		Start int64
 		Stop  int64
 	}
+	// Schedule is the outcome of one scheduling algorithm over a workload. The per-process
+	// slices are index-aligned with Processes.
+	Schedule struct {
+		Processes  []Process
+		Gantt      []TimeSlice
+		Wait       []int64
+		Turnaround []int64
+		Completion []int64
+		FirstRun   []int64       // when each process was first dispatched
+		Idle       []TimeSlice   // when no process was ready to run
+		Switching  []TimeSlice   // when the CPU was switching context, with a switch cost
+		Quantum    int64         // the time quantum, 0 when the scheduler has none
+		Resolution int64         // ticks per time unit of the workload, 0 or 1 for whole time units
+		Unit       time.Duration // length of a time unit of the workload, 0 for abstract time units
+		Energy     EnergyModel   // how to estimate the energy of the schedule, no governor for none
+
+		streamedSwitches []int // the switches dispatching each process, when the GANTT slices were streamed
+	}
+	// Options configure how the schedulers pick between processes.
+	Options struct {
+		TieBreak      TieBreak
+		Seed          int64
+		ClassPolicy   ClassPolicy   // how job classes share the CPU, shared when empty
+		Resolution    int64         // ticks per time unit of the workload, 0 or 1 for whole time units
+		Unit          time.Duration // length of a time unit of the workload, 0 for abstract time units
+		Horizon       int64         // ticks up to which periodic tasks release jobs, 0 for their hyperperiod
+		Energy        EnergyModel   // how to estimate the energy of each schedule, no governor for none
+		Quantum       int64         // round-robin time quantum in ticks, 0 for one time unit
+		SwitchCost    int64         // ticks each context switch takes, 0 for free switches
+		RRQueue       RRQueue       // how round-robin orders its ready queue, rotation when empty
+		Feedback      FeedbackConfig
+		Priority      PriorityOrder // which priority numbers are more important, low when empty
+		PreserveOrder bool          // schedule the processes in input order instead of sorting them by arrival
+		ExactMetrics  bool          // compute the metrics from the GANTT slices, without clamping negative waits
+		Jitter        Jitter        // how to perturb the workload before scheduling it, seeded by Seed
+		Progress      io.Writer     // where to report the progress of long simulations, nil for nowhere
+		GanttStream   io.Writer     // where to stream the GANTT slices instead of keeping them, nil to keep them
+
+		progress *progressMeter // the meter of the simulation running, set by algorithm.schedule
+		gantt    *ganttEncoder  // the encoder of the simulation running, set by algorithm.schedule
+		ctx      context.Context
+	}
+	// Report configures the analysis output alongside each schedule.
+	Report struct {
+		StarvationWait   int64 // warn about processes waiting longer than this many ticks, 0 to disable
+		StarvationCutoff int64 // warn about processes not yet dispatched by this tick, 0 to disable
+		Stats            bool  // output distribution statistics of the per-process metrics
+		ByPriority       bool  // output the metrics of each priority level
+		Convoys          bool  // output the convoys of short processes queued behind long ones
+		ThroughputWindow int64 // output the completions per window of this many time units, 0 to disable
+		BurstHistogram   int   // output a histogram of the burst lengths in this many buckets, 0 to disable
+		SlowdownBound    int64 // bursts shorter than this count as this long in the bounded slowdown
+		Format           string
+		Compare          bool    // end with a table comparing the schedules
+		Output           string  // file to write the report to, standard output when empty
+		PNGWidth         int     // width in pixels of PNG GANTT charts
+		GanttScale       float64 // characters per time unit of text GANTT bars, 0 for equal widths
+		GanttMinWidth    int     // minimum characters of a proportional text GANTT bar
+		Color            bool    // color process IDs in text output
+		RawSlices        bool    // keep contiguous GANTT slices of the same process apart
+		Trace            bool    // output every scheduling event
+		Ticks            bool    // output what ran and what was ready at every time unit
+		QueueOutput      string  // file to write the ready queue lengths to, as JSON if it ends in .json
+		GanttCSV         string  // file to write the GANTT slices to as CSV
+		JSONSummary      bool    // end with a one-line JSON summary of the run
+		SummaryFD        int     // file descriptor to write the JSON summary to
+		SortBy           string  // "column[:asc|desc]" to sort the schedule table by, input order when empty
+		Columns          []int   // indexes in scheduleColumns of the schedule table columns, all when nil
+		TableStyle       string  // style of text tables, one of tableStyleNames
+		Split            bool    // write each result to its own file named after the output file
+
+		starvationWait, starvationCutoff string // the starvation flags, until parseStarvation parses them
+	}
 )
 
+func newSchedule(processes []Process) Schedule {
+	return Schedule{
+		Processes:  processes,
+		Gantt:      make([]TimeSlice, 0),
+		Wait:       make([]int64, len(processes)),
+		Turnaround: make([]int64, len(processes)),
+		Completion: make([]int64, len(processes)),
+		FirstRun:   make([]int64, len(processes)),
+	}
+}
+
+// addOptionFlags registers the scheduler option flags on fs. Call the returned func after parsing.
+func addOptionFlags(fs *flag.FlagSet) func() (Options, error) {
+	tieBreak := fs.String("tie-break", string(TieBreakInput), "how to break ties: input, pid, arrival or random")
+	seed := fs.Int64("seed", 1, "random seed for the random tie-break, sporadic job releases and -jitter")
+	classPolicy := fs.String("class-policy", string(ClassPolicyShared), "how job classes share the CPU: shared, or strict to run a class only when no higher class is ready")
+	quantum := fs.String("quantum", "1", "round-robin time quantum of processes without a quantum: column")
+	switchCost := fs.String("switch-cost", "0", "time each context switch takes, delaying the rest of the schedule")
+	rrQueue := fs.String("rr-queue", string(RRQueueRotation), "round-robin ready queue: rotation over the processes in input order, or fifo in arrival order")
+	priorityOrder := addPriorityOrderFlag(fs)
+	jitter := fs.String("jitter", "", `perturb the workload before scheduling, seeded by -seed, e.g. "arrival=±2,burst=±10%"`)
+	progress := fs.Bool("progress", false, "report the progress of long simulations to standard error")
+	exactMetrics := fs.Bool("exact-metrics", false, "compute the metrics exactly from the GANTT slices and fail on a negative wait instead of clamping it")
+	preserveOrder := fs.Bool("preserve-order", false, "keep the input order of the processes instead of sorting them by arrival, then process ID")
+	horizon := fs.String("horizon", "", "time up to which periodic tasks release jobs (one hyperperiod when empty)")
+	governor := fs.String("governor", "", "estimate energy under a DVFS governor: "+strings.Join(governorNames(), ", ")+" (none when empty)")
+	frequencies := fs.String("frequencies", "0.4,0.6,0.8,1", "comma separated CPU frequency levels for -governor, as fractions of the maximum")
+	var params paramFlag
+	fs.Var(&params, "opt", "algorithm parameter algorithm.key=value, repeatable: "+strings.Join(algorithmParamNames(), ", "))
+	times := addTimeFlags(fs)
+
+	return func() (Options, error) {
+		policy, err := parseTieBreak(*tieBreak)
+		if err != nil {
+			return Options{}, err
+		}
+		classes, err := parseClassPolicy(*classPolicy)
+		if err != nil {
+			return Options{}, err
+		}
+		base, err := times()
+		if err != nil {
+			return Options{}, err
+		}
+		energy := EnergyModel{}
+		if energy.Governor, err = parseGovernor(*governor); err != nil {
+			return Options{}, err
+		}
+		if energy.Frequencies, err = parseFrequencies(*frequencies); err != nil {
+			return Options{}, err
+		}
+		queue, err := parseRRQueue(*rrQueue)
+		if err != nil {
+			return Options{}, err
+		}
+		order, err := priorityOrder()
+		if err != nil {
+			return Options{}, err
+		}
+		slice, err := parseTime(*quantum, base)
+		if err != nil || slice <= 0 {
+			return Options{}, fmt.Errorf("%w: quantum %q must be a positive time", ErrInvalidArgs, *quantum)
+		}
+		cost, err := parseTime(*switchCost, base)
+		if err != nil || cost < 0 {
+			return Options{}, fmt.Errorf("%w: switch cost %q must be a time of 0 or more", ErrInvalidArgs, *switchCost)
+		}
+		perturbation, err := parseJitter(*jitter, base)
+		if err != nil {
+			return Options{}, err
+		}
+		var until int64
+		if *horizon != "" {
+			if until, err = parseTime(*horizon, base); err != nil || until <= 0 {
+				return Options{}, fmt.Errorf("%w: horizon %q must be a positive time", ErrInvalidArgs, *horizon)
+			}
+		}
+
+		var meter io.Writer
+		if *progress {
+			meter = os.Stderr
+		}
+
+		opts := Options{TieBreak: policy, Seed: *seed, ClassPolicy: classes, Resolution: base.resolution, Unit: base.unit, Horizon: until, Energy: energy, Quantum: slice, SwitchCost: cost, RRQueue: queue, Priority: order, PreserveOrder: *preserveOrder, ExactMetrics: *exactMetrics, Jitter: perturbation, Progress: meter}
+		if err := setParams(&opts, params, base); err != nil {
+			return Options{}, err
+		}
+
+		return opts, nil
+	}
+}
+
+// defaultSlowdownBound is the customary threshold of the bounded slowdown metric.
+const defaultSlowdownBound = 10
+
+// addReportFlags registers the report flags on fs. Call the returned func after parsing.
+func addReportFlags(fs *flag.FlagSet) func() (Report, error) {
+	starvationWait := fs.String("starvation-wait", "0", "warn about processes waiting longer than this time (0 disables)")
+	starvationCutoff := fs.String("starvation-cutoff", "0", "warn about processes not dispatched by this time (0 disables)")
+	stats := fs.Bool("stats", false, "output stddev, median, p95 and max of wait, turnaround and response")
+	convoys := fs.Bool("convoy", false, "output the convoys of short processes queued behind a long one and the wait they caused")
+	byPriority := fs.Bool("by-priority", false, "output average wait, turnaround and response and CPU share per priority level")
+	burstHistogram := fs.Int("burst-histogram", 0, "output a histogram of the workload's burst lengths in this many buckets (0 disables)")
+	throughputWindow := fs.Int64("throughput-window", 0, "output the completions in every window of this many time units (0 disables)")
+	slowdownBound := fs.Int64("slowdown-bound", defaultSlowdownBound, "minimum burst counted by the bounded slowdown")
+	format := fs.String("format", "text", "output format: "+strings.Join(formatNames(), ", "))
+	output := fs.String("o", "", "write the report to this file, or one file per algorithm into this directory/, instead of standard output")
+	split := fs.Bool("split", false, "with -o FILE, write one file per algorithm named after FILE")
+	pngWidth := fs.Int("png-width", defaultPNGWidth, "width in pixels of -format png charts")
+	ganttScale := fs.Float64("gantt-scale", 0, "characters per time unit of GANTT bars (0 draws every bar equally wide)")
+	ganttMinWidth := fs.Int("gantt-min-width", 1, "minimum characters of a GANTT bar drawn to -gantt-scale")
+	trace := fs.Bool("trace", false, "output a line per arrival, dispatch, preemption, quantum expiry and completion")
+	ticks := fs.Bool("ticks", false, "output a row per time unit with the running process and the ready queue")
+	queueOutput := fs.String("queue-out", "", "write the ready queue length at every event to this CSV (or .json) file")
+	ganttCSV := fs.String("gantt-csv", "", "write every GANTT slice to this CSV file, as algorithm,pid,start,stop")
+	jsonSummary := fs.Bool("json-summary", false, "end with a one-line JSON summary of the outcome")
+	summaryFD := fs.Int("summary-fd", 1, "file descriptor to write the -json-summary line to")
+	columns := fs.String("columns", "all", "comma separated schedule table columns, e.g. id,arrival,wait,turnaround")
+	tableStyle := fs.String("table-style", "ascii", "text table style: "+strings.Join(tableStyleNames(), ", "))
+	sortBy := fs.String("sort-by", "", "sort the schedule table by a column, e.g. wait:desc")
+	rawSlices := fs.Bool("raw-slices", false, "keep contiguous GANTT slices of the same process apart instead of merging them")
+	noColor := fs.Bool("no-color", false, "never color the text output (it is only colored on a terminal anyway)")
+	logging := addLogFlags(fs)
+
+	return func() (Report, error) {
+		if err := logging(); err != nil {
+			return Report{}, err
+		}
+		if _, ok := formats[*format]; !ok {
+			return Report{}, fmt.Errorf("%w: unknown format %q", ErrInvalidArgs, *format)
+		}
+		if *burstHistogram < 0 {
+			return Report{}, fmt.Errorf("%w: burst histogram buckets must not be negative", ErrInvalidArgs)
+		}
+		if *throughputWindow < 0 {
+			return Report{}, fmt.Errorf("%w: throughput window must not be negative", ErrInvalidArgs)
+		}
+		if *slowdownBound < 0 {
+			return Report{}, fmt.Errorf("%w: slowdown bound must not be negative", ErrInvalidArgs)
+		}
+		if *ganttScale < 0 {
+			return Report{}, fmt.Errorf("%w: GANTT scale must not be negative", ErrInvalidArgs)
+		}
+		if _, ok := tableStyles[*tableStyle]; !ok && *tableStyle != tsvStyle {
+			return Report{}, fmt.Errorf("%w: unknown table style %q", ErrInvalidArgs, *tableStyle)
+		}
+		selected, err := parseColumns(*columns)
+		if err != nil {
+			return Report{}, err
+		}
+		if *sortBy != "" {
+			if _, _, err := parseSortBy(*sortBy); err != nil {
+				return Report{}, err
+			}
+		}
+		if *pngWidth <= 0 {
+			return Report{}, fmt.Errorf("%w: PNG width must be positive", ErrInvalidArgs)
+		}
+
+		return Report{
+			starvationWait:   *starvationWait,
+			starvationCutoff: *starvationCutoff,
+			Stats:            *stats,
+			ByPriority:       *byPriority,
+			Convoys:          *convoys,
+			ThroughputWindow: *throughputWindow,
+			BurstHistogram:   *burstHistogram,
+			SlowdownBound:    *slowdownBound,
+			Format:           *format,
+			Output:           *output,
+			PNGWidth:         *pngWidth,
+			GanttScale:       *ganttScale,
+			GanttMinWidth:    *ganttMinWidth,
+			RawSlices:        *rawSlices,
+			Trace:            *trace,
+			Ticks:            *ticks,
+			QueueOutput:      *queueOutput,
+			GanttCSV:         *ganttCSV,
+			JSONSummary:      *jsonSummary,
+			SummaryFD:        *summaryFD,
+			SortBy:           *sortBy,
+			Columns:          selected,
+			TableStyle:       *tableStyle,
+			Split:            *split,
+			Color:            !*noColor && os.Getenv("NO_COLOR") == "" && *format == "text" && *output == "" && isTerminal(os.Stdout),
+		}, nil
+	}
+}
+
+// algorithm is a scheduling algorithm selectable by name.
+type algorithm struct {
+	name  string
+	title string
+	run   func(processes []Process, opts Options) Schedule
+}
+
+// schedule runs the algorithm over the processes, whose times are in ticks of the options' time base.
+// The processes are perturbed by the options' jitter, if any, and sorted by arrival unless the options
+// preserve their order, then periodic tasks are released as jobs up to the options' horizon, or over
+// their hyperperiod.
+func (a algorithm) schedule(processes []Process, opts Options) Schedule {
+	s, _ := a.scheduleContext(context.Background(), processes, opts) // a background context is never done
+
+	return s
+}
+
+// scheduleContext is schedule stopping when ctx is done, such as a workload that never completes, and
+// returning the context's error with the schedule as far as it got.
+func (a algorithm) scheduleContext(ctx context.Context, processes []Process, opts Options) (Schedule, error) {
+	if opts.Jitter.enabled() {
+		processes = opts.Jitter.perturb(processes, opts.Seed)
+	}
+	processes = numbered(processes)
+	if !opts.PreserveOrder {
+		processes = byArrival(processes)
+	}
+	horizon, ok := opts.releaseHorizon(processes)
+	if !ok {
+		logs.Warn("hyperperiod too long to simulate, releasing jobs over the longest first period instead; set -horizon",
+			"algorithm", a.name)
+	}
+	jobs, err := releaseJobs(ctx, processes, horizon, opts.Seed)
+	if err != nil {
+		return Schedule{}, fmt.Errorf("%s: %w", a.name, err)
+	}
+	opts.ctx = ctx
+	opts.progress = newProgressMeter(opts.Progress, a.name, len(jobs))
+	opts.gantt = newGanttEncoder(opts.GanttStream, a.name, opts.timeBase())
+	s := a.run(jobs, opts)
+	opts.progress.finish()
+	s.streamedSwitches = opts.gantt.finish(s.Processes)
+	s.Resolution, s.Unit, s.Energy = opts.Resolution, opts.Unit, opts.Energy
+	if err := ctx.Err(); err != nil {
+		return s, fmt.Errorf("%s: %w", a.name, err)
+	}
+	if opts.SwitchCost > 0 {
+		s.chargeSwitches(opts.SwitchCost)
+	} else if opts.ExactMetrics {
+		s.exactMetrics()
+	}
+
+	return s, nil
+}
+
+// cancelled reports whether the simulation the options are running should stop, its context being
+// done. Schedulers check it every round of their main loop.
+func (opts Options) cancelled() bool {
+	return opts.ctx != nil && opts.ctx.Err() != nil
+}
+
+// numbered returns a copy of the processes, each knowing its input position, so the input tie-break
+// and round-robin rotation keep following the workload's order after byArrival.
+func numbered(processes []Process) []Process {
+	numbered := append([]Process(nil), processes...)
+	for i := range numbered {
+		numbered[i].input = i
+	}
+
+	return numbered
+}
+
+// byArrival returns the processes stably sorted by arrival time, ties by process ID, so the schedulers
+// that take processes in input order see them as they arrive whatever order the workload lists them in.
+func byArrival(processes []Process) []Process {
+	sorted := append([]Process(nil), processes...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].ArrivalTime != sorted[j].ArrivalTime {
+			return sorted[i].ArrivalTime < sorted[j].ArrivalTime
+		}
+		return sorted[i].ProcessID < sorted[j].ProcessID
+	})
+
+	return sorted
+}
+
+// algorithms are the scheduling algorithms run by default, in output order.
+var algorithms = []algorithm{
+	{name: "fcfs", title: "First-come, first-serve", run: fcfs},
+	{name: "sjf", title: "Shortest-job-first", run: sjf},
+	{name: "priority", title: "Priority", run: preemptivePriority},
+	{name: "rr", title: "Round-robin", run: roundRobin},
+	{name: "feedback", title: "Feedback (q = 2^i)", run: feedback},
+}
+
+// realtimeAlgorithms are the deadline-driven scheduling algorithms, which only run when selected by
+// name so they do not change the output of a workload without deadlines.
+var realtimeAlgorithms = []algorithm{
+	{name: "edf", title: "Earliest deadline first", run: edf},
+	{name: "llf", title: "Least laxity first", run: llf},
+}
+
+// allAlgorithms returns the algorithms and then the real-time ones.
+func allAlgorithms() []algorithm {
+	return append(append([]algorithm(nil), algorithms...), realtimeAlgorithms...)
+}
+
+// selectAlgorithms looks up a comma separated list of algorithm names; "all" selects every one run by
+// default, and the real-time ones must be named.
+func selectAlgorithms(names string) ([]algorithm, error) {
+	if names == "all" {
+		return algorithms, nil
+	}
+	selected := make([]algorithm, 0)
+	for _, name := range strings.Split(names, ",") {
+		found := false
+		for _, a := range allAlgorithms() {
+			if a.name == strings.TrimSpace(name) {
+				selected = append(selected, a)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: unknown algorithm %q", ErrInvalidArgs, name)
+		}
+	}
+
+	return selected, nil
+}
+
 //region Schedulers
 
 // FCFSSchedule outputs a schedule of processes in a GANTT chart and a table of timing given:
@@ -79,118 +760,143 @@ type (
 // • a title for the chart
 // • a slice of processes
 func FCFSSchedule(w io.Writer, title string, processes []Process) {
-	var (
-		serviceTime     int64
-		totalWait       float64
-		totalTurnaround float64
-		lastCompletion  float64
-		waitingTime     int64
-		schedule        = make([][]string, len(processes))
-		gantt           = make([]TimeSlice, 0)
-	)
-	for i := range processes {
-		if processes[i].ArrivalTime > 0 {
-			waitingTime = serviceTime - processes[i].ArrivalTime
-		}
-		totalWait += float64(waitingTime)
+	outputResult(w, title, fcfs(processes, Options{}), Report{SlowdownBound: defaultSlowdownBound})
+}
 
-		start := waitingTime + processes[i].ArrivalTime
+// SJFPrioritySchedule outputs a preemptive priority schedule (lower numbers first unless the options
+// say otherwise) given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+// • the options, whose tie-break orders equal priorities
+func SJFPrioritySchedule(w io.Writer, title string, processes []Process, opts Options) {
+	outputResult(w, title, preemptivePriority(processes, opts), Report{SlowdownBound: defaultSlowdownBound})
+}
 
-		turnaround := processes[i].BurstDuration + waitingTime
-		totalTurnaround += float64(turnaround)
+// SJFSchedule outputs a preemptive shortest-remaining-time-first schedule given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+// • the options, whose tie-break orders equal remaining times
+func SJFSchedule(w io.Writer, title string, processes []Process, opts Options) {
+	outputResult(w, title, sjf(processes, opts), Report{SlowdownBound: defaultSlowdownBound})
+}
 
-		completion := processes[i].BurstDuration + processes[i].ArrivalTime + waitingTime
-		lastCompletion = float64(completion)
+// RRSchedule outputs a round-robin schedule with a time quantum of 1 given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+func RRSchedule(w io.Writer, title string, processes []Process) {
+	outputResult(w, title, roundRobin(processes, Options{}).merged(), Report{SlowdownBound: defaultSlowdownBound})
+}
 
-		schedule[i] = []string{
-			fmt.Sprint(processes[i].ProcessID),
-			fmt.Sprint(processes[i].Priority),
-			fmt.Sprint(processes[i].BurstDuration),
-			fmt.Sprint(processes[i].ArrivalTime),
-			fmt.Sprint(waitingTime),
-			fmt.Sprint(turnaround),
-			fmt.Sprint(completion),
+func fcfs(processes []Process, opts Options) Schedule {
+	var (
+		serviceTime int64
+		s           = newSchedule(processes)
+		queue       = newFCFSQueue(processes, opts.ClassPolicy)
+	)
+	for i := queue.next(serviceTime); i >= 0 && !opts.cancelled(); i = queue.next(serviceTime) {
+		start := serviceTime
+		if ready := queue.readyAt[i]; ready > start { // the CPU idles until the next process arrives
+			s.addIdle(start, ready)
+			start = ready
 		}
-		serviceTime += processes[i].BurstDuration
+		s.FirstRun[i] = start
+		s.Wait[i] = start - processes[i].ArrivalTime
+
+		serviceTime = start + processes[i].BurstDuration
+		s.Completion[i] = serviceTime
+		s.Turnaround[i] = serviceTime - processes[i].ArrivalTime
+		queue.complete(i, serviceTime)
+		opts.progress.complete()
 
-		gantt = append(gantt, TimeSlice{
+		s.Gantt = opts.gantt.append(s.Gantt, TimeSlice{
 			PID:   processes[i].ProcessID,
 			Start: start,
 			Stop:  serviceTime,
 		})
 	}
 
-	count := float64(len(processes))
-	aveWait := totalWait / count
-	aveTurnaround := totalTurnaround / count
-	aveThroughput := count / lastCompletion
-
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
+	return s
 }
 
-func SJFPrioritySchedule(w io.Writer, title string, processes []Process) {
+func preemptivePriority(processes []Process, opts Options) Schedule {
 	var (
-		serviceTime     int64
-		minPriority 	int64
-		lastStart		int64
-		totalWait       float64
-		totalTurnaround float64
-		lastCompletion  float64
-		waitingTime     = make([]int64, len(processes))
-		turnAroundTime  = make([]int64, len(processes))
-		remTime			= make([]int64, len(processes))
-		completion		= make([]int64, len(processes))  
-		schedule        = make([][]string, len(processes))
-		gantt           = make([]TimeSlice, 0)
+		serviceTime int64
+		minPriority int64
+		lastStart   int64
+		remTime     = make([]int64, len(processes))
+		s           = newSchedule(processes)
+		tb          = newTieBreaker(opts.TieBreak, opts.Seed, processes)
+		ready       = newReadiness(processes, s.Completion, opts.ClassPolicy)
+		ranked      = opts.Priority.ranked(processes) // priorities to compare, lowest first
 	)
 	completed := 0
 	minPriority = math.MaxInt64 // Tracks the value of the lowest priority
-	priority := 0 // Tracks the index of the process with the lowest priority
-	lastPriority := 0 // Tracks the index of priority of the previous iteration
+	priority := 0               // Tracks the index of the process with the lowest priority
+	lastPriority := -1          // Tracks the index of priority of the previous iteration, -1 while idle
 	check := false
 	count := len(processes)
-	
+
 	for i := range processes { // Populating the remaining time array that will be updated along the way
 		remTime[i] = processes[i].BurstDuration
 	}
 
-	for completed != count {
+	for completed != count && !opts.cancelled() {
+		running := -1 // the running process keeps the CPU on ties
+		if check {
+			running = priority
+		}
 		for j := 0; j < count; j++ {
-			if processes[j].ArrivalTime <= serviceTime && processes[j].Priority < minPriority && remTime[j] > 0 {
-				minPriority = processes[j].Priority
+			limit := minPriority // the running process is only preempted from below its threshold
+			if priority == running {
+				limit = preemptionThreshold(ranked[running])
+			}
+			if ready.ready(j, serviceTime) && remTime[j] > 0 && (ranked[j].Priority < limit ||
+				ranked[j].Priority == minPriority && priority != running && tb.prefer(j, priority)) {
+				minPriority = ranked[j].Priority
 				priority = j
 				check = true
 			}
 		}
 
-		// Every preemption, update Gantt schedule with the preempted process
-		if priority != lastPriority {
-			gantt = append(gantt, TimeSlice{
-				PID:   processes[lastPriority].ProcessID,
-				Start: lastStart,
-				Stop:  serviceTime,
-			})
+		// Every preemption or idle period, update Gantt schedule with the preempted process
+		current := priority
+		if !check {
+			current = -1
+		}
+		if current != lastPriority {
+			if lastPriority >= 0 && serviceTime > lastStart { // the initial pick may be replaced before it ever ran
+				s.Gantt = opts.gantt.append(s.Gantt, TimeSlice{
+					PID:   processes[lastPriority].ProcessID,
+					Start: lastStart,
+					Stop:  serviceTime,
+				})
+			}
 			lastStart = serviceTime
-			lastPriority = priority
+			lastPriority = current
 		}
 
-		if check == false {
+		if !check {
+			s.addIdle(serviceTime, serviceTime+1)
 			serviceTime++
 			continue
 		}
 
+		if remTime[priority] == processes[priority].BurstDuration {
+			s.FirstRun[priority] = serviceTime
+		}
 		remTime[priority]--
 
 		if remTime[priority] == 0 {
 			completed++
+			opts.progress.complete()
 			check = false
-			completion[priority] = serviceTime + 1
-			lastCompletion = float64(completion[priority])
-			waitingTime[priority] = completion[priority] - processes[priority].BurstDuration - processes[priority].ArrivalTime
-			if waitingTime[priority] < 0 {
-				waitingTime[priority] = 0
+			s.Completion[priority] = serviceTime + 1
+			s.Wait[priority] = s.Completion[priority] - processes[priority].BurstDuration - processes[priority].ArrivalTime
+			if s.Wait[priority] < 0 {
+				s.Wait[priority] = 0
 			}
 			minPriority = math.MaxInt64
 		}
@@ -198,307 +904,596 @@ func SJFPrioritySchedule(w io.Writer, title string, processes []Process) {
 		serviceTime++
 	}
 
-	for i := range waitingTime {
-		totalWait += float64(waitingTime[i])
-		turnAroundTime[i] = processes[i].BurstDuration + waitingTime[i]
-		totalTurnaround += float64(turnAroundTime[i])
-	}
-	aveWait := totalWait / float64(count)
-	aveTurnaround := totalTurnaround / float64(count)
-	aveThroughput := float64(count) / lastCompletion
-
-	for i := range processes {
-		schedule[i] = []string{
-			fmt.Sprint(processes[i].ProcessID),
-			fmt.Sprint(processes[i].Priority),
-			fmt.Sprint(processes[i].BurstDuration),
-			fmt.Sprint(processes[i].ArrivalTime),
-			fmt.Sprint(waitingTime[i]),
-			fmt.Sprint(turnAroundTime[i]),
-			fmt.Sprint(completion[i]),
-		}
+	for i := range s.Wait {
+		s.Turnaround[i] = processes[i].BurstDuration + s.Wait[i]
 	}
 
 	// Adding the last entry of the Gantt schedule
-	gantt = append(gantt, TimeSlice{
-		PID:   processes[lastPriority].ProcessID,
-		Start: lastStart,
-		Stop:  serviceTime,
-	})
+	if lastPriority >= 0 {
+		s.Gantt = opts.gantt.append(s.Gantt, TimeSlice{
+			PID:   processes[lastPriority].ProcessID,
+			Start: lastStart,
+			Stop:  serviceTime,
+		})
+	}
 
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
+	return s
 }
 
-func SJFSchedule(w io.Writer, title string, processes []Process) {
+func sjf(processes []Process, opts Options) Schedule {
 	var (
-		serviceTime     int64
-		minTime 		int64
-		lastStart		int64
-		totalWait       float64
-		totalTurnaround float64
-		lastCompletion  float64
-		waitingTime     = make([]int64, len(processes))
-		turnAroundTime  = make([]int64, len(processes))
-		remTime			= make([]int64, len(processes))
-		completion		= make([]int64, len(processes))  
-		schedule        = make([][]string, len(processes))
-		gantt           = make([]TimeSlice, 0)
+		serviceTime int64
+		minTime     int64
+		lastStart   int64
+		remTime     = make([]int64, len(processes))
+		s           = newSchedule(processes)
+		tb          = newTieBreaker(opts.TieBreak, opts.Seed, processes)
+		ready       = newReadiness(processes, s.Completion, opts.ClassPolicy)
 	)
 	completed := 0
 	minTime = math.MaxInt64
 	shortest := 0
-	lastShortest := 0
+	lastShortest := -1 // -1 while idle
 	check := false
 	count := len(processes)
-	
+
 	for i := range processes { // Populating the remaining time array that will be updated along the way
 		remTime[i] = processes[i].BurstDuration
 	}
 
-	for completed != count {
+	for completed != count && !opts.cancelled() {
+		running := -1 // the running process keeps the CPU on ties
+		if check {
+			running = shortest
+		}
 		for j := 0; j < count; j++ {
-			if processes[j].ArrivalTime <= serviceTime && remTime[j] < minTime && remTime[j] > 0 {
+			if ready.ready(j, serviceTime) && remTime[j] > 0 && (remTime[j] < minTime ||
+				remTime[j] == minTime && shortest != running && tb.prefer(j, shortest)) {
 				minTime = remTime[j]
 				shortest = j
 				check = true
 			}
 		}
 
-		// Every preemption, update Gantt schedule with the preempted process
-		if shortest != lastShortest {
-			gantt = append(gantt, TimeSlice{
-				PID:   processes[lastShortest].ProcessID,
-				Start: lastStart,
-				Stop:  serviceTime,
-			})
+		// Every preemption or idle period, update Gantt schedule with the preempted process
+		current := shortest
+		if !check {
+			current = -1
+		}
+		if current != lastShortest {
+			if lastShortest >= 0 && serviceTime > lastStart { // the initial pick may be replaced before it ever ran
+				s.Gantt = opts.gantt.append(s.Gantt, TimeSlice{
+					PID:   processes[lastShortest].ProcessID,
+					Start: lastStart,
+					Stop:  serviceTime,
+				})
+			}
 			lastStart = serviceTime
-			lastShortest = shortest
+			lastShortest = current
 		}
 
-		if check == false {
-			serviceTime++
+		if !check {
+			idle := ready.idleUntil(serviceTime)
+			s.addIdle(serviceTime, idle)
+			serviceTime = idle
 			continue
 		}
 
-		remTime[shortest]--
+		if remTime[shortest] == processes[shortest].BurstDuration {
+			s.FirstRun[shortest] = serviceTime
+		}
+		// The shortest keeps the CPU until a process is released or it completes, as the others ready
+		// have no less time left, so skip ahead to that rather than going tick by tick.
+		run := remTime[shortest]
+		if next := ready.nextRelease(serviceTime); next-serviceTime < run {
+			run = next - serviceTime
+		}
+		remTime[shortest] -= run
 
 		minTime = remTime[shortest]
-		if (minTime == 0) {
+		if minTime == 0 {
 			minTime = math.MaxInt64
 		}
 
 		if remTime[shortest] == 0 {
 			completed++
+			opts.progress.complete()
 			check = false
-			completion[shortest] = serviceTime + 1
-			lastCompletion = float64(completion[shortest])
-			waitingTime[shortest] = completion[shortest] - processes[shortest].BurstDuration - processes[shortest].ArrivalTime
-			if waitingTime[shortest] < 0 {
-				waitingTime[shortest] = 0
+			s.Completion[shortest] = serviceTime + run
+			s.Wait[shortest] = s.Completion[shortest] - processes[shortest].BurstDuration - processes[shortest].ArrivalTime
+			if s.Wait[shortest] < 0 {
+				s.Wait[shortest] = 0
 			}
 		}
 
-		serviceTime++
-	}
-
-	for i := range waitingTime {
-		totalWait += float64(waitingTime[i])
-		turnAroundTime[i] = processes[i].BurstDuration + waitingTime[i]
-		totalTurnaround += float64(turnAroundTime[i])
+		serviceTime += run
 	}
-	aveWait := totalWait / float64(count)
-	aveTurnaround := totalTurnaround / float64(count)
-	aveThroughput := float64(count) / lastCompletion
 
-	for i := range processes {
-		schedule[i] = []string{
-			fmt.Sprint(processes[i].ProcessID),
-			fmt.Sprint(processes[i].Priority),
-			fmt.Sprint(processes[i].BurstDuration),
-			fmt.Sprint(processes[i].ArrivalTime),
-			fmt.Sprint(waitingTime[i]),
-			fmt.Sprint(turnAroundTime[i]),
-			fmt.Sprint(completion[i]),
-		}
+	for i := range s.Wait {
+		s.Turnaround[i] = processes[i].BurstDuration + s.Wait[i]
 	}
 
 	// Adding the last entry of the Gantt schedule
-	gantt = append(gantt, TimeSlice{
-		PID:   processes[lastShortest].ProcessID,
-		Start: lastStart,
-		Stop:  serviceTime,
-	})
+	if lastShortest >= 0 {
+		s.Gantt = opts.gantt.append(s.Gantt, TimeSlice{
+			PID:   processes[lastShortest].ProcessID,
+			Start: lastStart,
+			Stop:  serviceTime,
+		})
+	}
 
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
+	return s
 }
 
-func RRSchedule(w io.Writer, title string, processes []Process) {
+// roundRobin schedules the processes in turn, each for up to its quantum at a time. Turns rotate over
+// the processes in input order, as numbered before sorting by arrival, unless the options ask for a
+// FIFO ready queue.
+func roundRobin(processes []Process, opts Options) Schedule {
+	if opts.RRQueue == RRQueueFIFO {
+		return roundRobinFIFO(processes, opts)
+	}
+
 	var (
-		serviceTime     int64
-		lastStart		int64
-		timeQuantum		int64
-		totalWait       float64
-		totalTurnaround float64
-		lastCompletion  float64
-		waitingTime     = make([]int64, len(processes))
-		turnAroundTime  = make([]int64, len(processes))
-		remTime			= make([]int64, len(processes))
-		completion		= make([]int64, len(processes))  
-		schedule        = make([][]string, len(processes))
-		gantt           = make([]TimeSlice, 0)
+		serviceTime int64
+		lastStart   int64
+		timeQuantum = opts.quantum()
+		remTime     = make([]int64, len(processes))
+		s           = newSchedule(processes)
+		ready       = newReadiness(processes, s.Completion, opts.ClassPolicy)
 	)
-	timeQuantum = 1
+	s.Quantum = timeQuantum
 	completed := 0
 	count := len(processes)
+	rotation, next := inputRotation(processes)
 	turn := 0
+	if count > 0 {
+		turn = rotation[0]
+	}
 	check := false // boolean to check if we're trying to find the next available process
-	stuck := 0 // variable that tracks the stuck process
-	
+	stuck := 0     // variable that tracks the stuck process
+
 	for i := range processes { // Populating the remaining time array that will be updated along the way
 		remTime[i] = processes[i].BurstDuration
 	}
 
-	for completed != count {
-		if processes[turn].ArrivalTime > serviceTime || remTime[turn] == 0 {
-			turn = (turn + 1) % count
-			if check == false { // encountering invalid process for the first time
+	for completed != count && !opts.cancelled() {
+		if !ready.ready(turn, serviceTime) || remTime[turn] == 0 {
+			turn = next[turn]
+			if !check { // encountering invalid process for the first time
 				check = true
 				stuck = turn
 			} else if stuck == turn { // meeting the invalid process that we were stuck with the first time
-				serviceTime++
-				lastStart = serviceTime 
+				idle := ready.idleUntil(serviceTime)
+				s.addIdle(serviceTime, idle)
+				serviceTime = idle
+				lastStart = serviceTime
 				check = false
-				turn = 0
+				turn = rotation[0]
 			}
 			continue
 		}
 		check = false // found a process that's valid to process
-		if remTime[turn] > timeQuantum {
-			serviceTime += timeQuantum
-			remTime[turn] -= timeQuantum 
+		if remTime[turn] == processes[turn].BurstDuration {
+			s.FirstRun[turn] = serviceTime
+		}
+		q := quantumOf(processes[turn], timeQuantum)
+		if !ready.othersReady(turn, serviceTime, remTime) { // alone, it gets quantum after quantum
+			q = aloneRun(remTime[turn], q, serviceTime, ready.nextRelease(serviceTime))
+		}
+		if remTime[turn] > q {
+			serviceTime += q
+			remTime[turn] -= q
 		} else {
 			serviceTime += remTime[turn]
 			remTime[turn] = 0
 			completed++
-			completion[turn] = serviceTime
-			lastCompletion = float64(completion[turn])
-			waitingTime[turn] = completion[turn] - processes[turn].BurstDuration - processes[turn].ArrivalTime
-			if waitingTime[turn] < 0 {
-				waitingTime[turn] = 0
-			}	
-		}
-		gantt = append(gantt, TimeSlice{
+			opts.progress.complete()
+			s.Completion[turn] = serviceTime
+			s.Wait[turn] = s.Completion[turn] - processes[turn].BurstDuration - processes[turn].ArrivalTime
+			if s.Wait[turn] < 0 {
+				s.Wait[turn] = 0
+			}
+		}
+		s.Gantt = opts.gantt.append(s.Gantt, TimeSlice{
 			PID:   processes[turn].ProcessID,
 			Start: lastStart,
 			Stop:  serviceTime,
 		})
 		lastStart = serviceTime
-		turn = (turn + 1) % count
+		turn = next[turn]
 	}
 
-	for i := range waitingTime {
-		totalWait += float64(waitingTime[i])
-		turnAroundTime[i] = processes[i].BurstDuration + waitingTime[i]
-		totalTurnaround += float64(turnAroundTime[i])
+	for i := range s.Wait {
+		s.Turnaround[i] = processes[i].BurstDuration + s.Wait[i]
 	}
-	aveWait := totalWait / float64(count)
-	aveTurnaround := totalTurnaround / float64(count)
-	aveThroughput := float64(count) / lastCompletion
 
-	for i := range processes {
-		schedule[i] = []string{
-			fmt.Sprint(processes[i].ProcessID),
-			fmt.Sprint(processes[i].Priority),
-			fmt.Sprint(processes[i].BurstDuration),
-			fmt.Sprint(processes[i].ArrivalTime),
-			fmt.Sprint(waitingTime[i]),
-			fmt.Sprint(turnAroundTime[i]),
-			fmt.Sprint(completion[i]),
-		}
-	}
-
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)	
+	return s
 }
 
 //endregion
 
 //region Output helpers
 
+// outputResult outputs a schedule's GANTT chart, table of timing and analysis under a title.
+func outputResult(w io.Writer, title string, s Schedule, r Report) {
+	outputTitle(w, title)
+	if !s.streamed() {
+		outputGantt(w, s.timeline(), s.timeBase(), r)
+	}
+	if r.Trace {
+		outputEvents(w, s)
+	}
+	if r.Ticks {
+		outputTicks(w, s, r)
+	}
+	outputSchedule(w, s, r)
+	outputSummary(w, s)
+	outputDeadlines(w, s, r)
+	if r.Stats {
+		outputStats(w, s, r)
+	}
+	if r.ByPriority {
+		outputPriorityLevels(w, s, r)
+	}
+	if r.ThroughputWindow > 0 {
+		outputThroughput(w, s, r)
+	}
+	if r.Convoys {
+		outputConvoys(w, s)
+	}
+	outputStarvation(w, s, r)
+}
+
 func outputTitle(w io.Writer, title string) {
 	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
 	_, _ = fmt.Fprintln(w, strings.Repeat(" ", len(title)/2), title)
 	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
 }
 
-func outputGantt(w io.Writer, gantt []TimeSlice) {
+func outputGantt(w io.Writer, gantt []TimeSlice, base timeBase, r Report) {
 	_, _ = fmt.Fprintln(w, "Gantt schedule")
+	outputGanttChart(w, gantt, base, r)
+	_, _ = fmt.Fprintln(w)
+}
+
+// outputGanttChart outputs the bars of a GANTT chart over a line of their start times, in time units
+// of a time base. Bars are equally wide unless the report sets a GANTT scale.
+func outputGanttChart(w io.Writer, gantt []TimeSlice, base timeBase, r Report) {
+	if r.GanttScale > 0 {
+		outputProportionalGanttChart(w, gantt, r.GanttScale, r.GanttMinWidth, r.Color, base)
+		return
+	}
 	_, _ = fmt.Fprint(w, "|")
 	for i := range gantt {
-		pid := fmt.Sprint(gantt[i].PID)
+		pid := sliceLabel(gantt[i].PID)
 		padding := strings.Repeat(" ", (8-len(pid))/2)
+		if r.Color {
+			pid = colorPID(gantt[i].PID, pid)
+		}
 		_, _ = fmt.Fprint(w, padding, pid, padding, "|")
 	}
 	_, _ = fmt.Fprintln(w)
 	for i := range gantt {
-		_, _ = fmt.Fprint(w, fmt.Sprint(gantt[i].Start), "\t")
+		_, _ = fmt.Fprint(w, formatTicks(gantt[i].Start, base), "\t")
 		if len(gantt)-1 == i {
-			_, _ = fmt.Fprint(w, fmt.Sprint(gantt[i].Stop))
+			_, _ = fmt.Fprint(w, formatTicks(gantt[i].Stop, base))
 		}
 	}
-	_, _ = fmt.Fprintf(w, "\n\n")
+	_, _ = fmt.Fprintln(w)
+}
+
+// outputProportionalGanttChart outputs the bars of a GANTT chart scale characters wide per time unit
+// of a time base, but at least minWidth and wide enough for the label, over their start times.
+func outputProportionalGanttChart(w io.Writer, gantt []TimeSlice, scale float64, minWidth int, color bool, base timeBase) {
+	scale /= float64(base.ticksPerUnit())
+	var (
+		bars   = []byte("|")
+		column = 0 // of the last bar, not counting escape codes
+		times  []byte
+	)
+	mark := func(t int64) {
+		label := formatTicks(t, base)
+		if len(times) > 0 && column <= len(times) {
+			return // no room after the previous time
+		}
+		times = append(times, strings.Repeat(" ", column-len(times))...)
+		times = append(times, label...)
+	}
+	for _, ts := range gantt {
+		label := sliceLabel(ts.PID)
+		width := int(math.Round(float64(ts.Stop-ts.Start) * scale))
+		if width < minWidth {
+			width = minWidth
+		}
+		if width < len(label) {
+			width = len(label)
+		}
+		mark(ts.Start)
+		left, right := (width-len(label))/2, width-(width-len(label))/2-len(label)
+		if color {
+			label = colorPID(ts.PID, label)
+		}
+		bars = append(bars, strings.Repeat(" ", left)+label+strings.Repeat(" ", right)+"|"...)
+		column += width + 1
+	}
+	if len(gantt) > 0 {
+		mark(gantt[len(gantt)-1].Stop)
+	}
+	_, _ = fmt.Fprintln(w, string(bars))
+	_, _ = fmt.Fprintln(w, string(times))
 }
 
-func outputSchedule(w io.Writer, rows [][]string, wait, turnaround, throughput float64) {
+func outputSchedule(w io.Writer, s Schedule, r Report) {
 	_, _ = fmt.Fprintln(w, "Schedule table")
-	table := tablewriter.NewWriter(w)
-	table.SetHeader([]string{"ID", "Priority", "Burst", "Arrival", "Wait", "Turnaround", "Exit"})
-	table.AppendBulk(rows)
-	table.SetFooter([]string{"", "", "", "",
-		fmt.Sprintf("Average\n%.2f", wait),
-		fmt.Sprintf("Average\n%.2f", turnaround),
-		fmt.Sprintf("Throughput\n%.2f/t", throughput)})
-	table.Render()
+	rows := s.rows(r)
+	if r.Color {
+		for row, i := range s.rowOrder(r) {
+			rows[row][0] = colorPID(s.Processes[i].ProcessID, rows[row][0])
+		}
+	}
+	outputTable(w, r.TableStyle, scheduleHeader(r), rows, s.footer(r), nil)
+}
+
+// outputSummary outputs the schedule-wide metrics that do not fit under a table column.
+func outputSummary(w io.Writer, s Schedule) {
+	for _, line := range s.summary() {
+		_, _ = fmt.Fprintln(w, line)
+	}
+	_, _ = fmt.Fprintln(w)
 }
 
 //endregion
 
 //region Loading processes.
 
-var ErrInvalidArgs = errors.New("invalid args")
+var (
+	ErrInvalidArgs  = errors.New("invalid args")
+	ErrValidation   = errors.New("invalid workload")
+	ErrDeadlineMiss = errors.New("deadline missed")
+	ErrMismatch     = errors.New("schedule differs from the expected results")
+)
 
+// loadProcesses reads a workload CSV whose times are whole time units.
 func loadProcesses(r io.Reader) ([]Process, error) {
-	rows, err := csv.NewReader(r).ReadAll()
+	return loadProcessesAt(r, timeBase{})
+}
+
+// loadProcessesAt reads a workload CSV, converting its burst, arrival and resource event times into
+// ticks of a time base.
+func loadProcessesAt(r io.Reader, base timeBase) ([]Process, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	rows, err := cr.ReadAll()
 	if err != nil {
 		return nil, fmt.Errorf("%w: reading CSV", err)
 	}
 
-	processes := make([]Process, len(rows))
+	processes := make([]Process, 0, len(rows))
 	for i := range rows {
-		processes[i].ProcessID = mustStrToInt(rows[i][0])
-		processes[i].BurstDuration = mustStrToInt(rows[i][1])
-		processes[i].ArrivalTime = mustStrToInt(rows[i][2])
-		if len(rows[i]) == 4 {
-			processes[i].Priority = mustStrToInt(rows[i][3])
+		if strings.HasPrefix(strings.TrimSpace(rows[i][0]), templatePrefix) {
+			expanded, err := expandTemplate(strings.Join(rows[i], " "), processes, base)
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d", err, i+1)
+			}
+			processes = append(processes, expanded...)
+			continue
+		}
+		if strings.HasPrefix(strings.TrimSpace(rows[i][0]), resourcePrefix) {
+			if err := addResourceEvent(strings.Join(rows[i], " "), processes, base); err != nil {
+				return nil, fmt.Errorf("%w: line %d", err, i+1)
+			}
+			continue
+		}
+		if len(rows[i]) < 3 {
+			return nil, fmt.Errorf("%w: line %d: expected ID, burst and arrival", ErrInvalidArgs, i+1)
+		}
+
+		var p Process
+		if p.ProcessID, err = strconv.ParseInt(rows[i][0], 10, 64); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidArgs, i+1, err)
+		}
+		if p.BurstDuration, err = parseTime(rows[i][1], base); err != nil {
+			return nil, fmt.Errorf("%w: line %d: burst: %v", ErrInvalidArgs, i+1, err)
+		}
+		if p.ArrivalTime, err = parseTime(rows[i][2], base); err != nil {
+			return nil, fmt.Errorf("%w: line %d: arrival: %v", ErrInvalidArgs, i+1, err)
+		}
+		rest := rows[i][3:]
+		if len(rest) > 0 && !isTagged(rest[0]) {
+			if p.Priority, err = strconv.ParseInt(rest[0], 10, 64); err != nil {
+				return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidArgs, i+1, err)
+			}
+			rest = rest[1:]
 		}
+		for j := 0; j < len(rest); j++ {
+			field := strings.TrimSpace(rest[j])
+			switch {
+			case strings.HasPrefix(field, classPrefix):
+				if p.Class, err = parseClass(strings.TrimPrefix(field, classPrefix)); err != nil {
+					return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidArgs, i+1, err)
+				}
+			case strings.HasPrefix(field, periodPrefix), strings.HasPrefix(field, sporadicPrefix):
+				if p.Period > 0 {
+					return nil, fmt.Errorf("%w: line %d: more than one period or sporadic column", ErrInvalidArgs, i+1)
+				}
+				p.Sporadic = strings.HasPrefix(field, sporadicPrefix)
+				name, value, _ := strings.Cut(field, ":")
+				if p.Period, err = parseTime(value, base); err != nil {
+					return nil, fmt.Errorf("%w: line %d: %s: %v", ErrInvalidArgs, i+1, name, err)
+				}
+			case strings.HasPrefix(field, quantumPrefix):
+				if p.Quantum, err = parseTime(strings.TrimPrefix(field, quantumPrefix), base); err != nil {
+					return nil, fmt.Errorf("%w: line %d: quantum: %v", ErrInvalidArgs, i+1, err)
+				}
+			case strings.HasPrefix(field, thresholdPrefix):
+				if p.Threshold, err = strconv.ParseInt(strings.TrimPrefix(field, thresholdPrefix), 10, 64); err != nil {
+					return nil, fmt.Errorf("%w: line %d: threshold: %v", ErrInvalidArgs, i+1, err)
+				}
+				p.HasThreshold = true
+			case strings.HasPrefix(field, deadlinePrefix):
+				if p.Deadline, err = parseTime(strings.TrimPrefix(field, deadlinePrefix), base); err != nil {
+					return nil, fmt.Errorf("%w: line %d: deadline: %v", ErrInvalidArgs, i+1, err)
+				}
+			case strings.HasPrefix(field, depsPrefix):
+				end := j + 1
+				for end < len(rest) && !isTagged(rest[end]) {
+					end++
+				}
+				if p.Deps, err = parseDeps(rest[j:end]); err != nil {
+					return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidArgs, i+1, err)
+				}
+				j = end - 1
+			}
+		}
+		processes = append(processes, p)
 	}
 
 	return processes, nil
 }
 
-func mustStrToInt(s string) int64 {
-	i, err := strconv.ParseInt(s, 10, 64)
-	if err != nil {
-		_, _ = fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+// taggedPrefixes start the optional columns of a workload row, which follow its priority in any order.
+var taggedPrefixes = []string{depsPrefix, classPrefix, periodPrefix, sporadicPrefix, deadlinePrefix, thresholdPrefix, quantumPrefix}
+
+// isTagged reports whether a workload field is one of the tagged optional columns.
+func isTagged(field string) bool {
+	field = strings.TrimSpace(field)
+	for _, prefix := range taggedPrefixes {
+		if strings.HasPrefix(field, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateProcesses checks that a loaded workload can be scheduled: it needs at least one process,
+// and every process needs a unique ID, a positive burst, an arrival that is not negative, a period,
+// deadline and quantum that are not negative, resource events that fit its burst and dependencies
+// on other processes without a cycle.
+func validateProcesses(processes []Process) error {
+	if len(processes) == 0 {
+		return fmt.Errorf("%w: no processes to schedule", ErrInvalidArgs)
+	}
+	seen := make(map[int64]bool, len(processes))
+	for _, p := range processes {
+		switch {
+		case seen[p.ProcessID]:
+			return fmt.Errorf("%w: process %d: duplicate ID", ErrValidation, p.ProcessID)
+		case p.BurstDuration <= 0:
+			return fmt.Errorf("%w: process %d: burst must be positive", ErrValidation, p.ProcessID)
+		case p.ArrivalTime < 0:
+			return fmt.Errorf("%w: process %d: arrival must not be negative", ErrValidation, p.ProcessID)
+		case p.Period < 0 || p.Deadline < 0:
+			return fmt.Errorf("%w: process %d: period and deadline must not be negative", ErrValidation, p.ProcessID)
+		case p.Quantum < 0:
+			return fmt.Errorf("%w: process %d: quantum must not be negative", ErrValidation, p.ProcessID)
+		case p.Period > 0 && p.Deadline > p.Period:
+			return fmt.Errorf("%w: process %d: deadline must not be beyond its period", ErrValidation, p.ProcessID)
+		}
+		if err := validateResourceEvents(p); err != nil {
+			return err
+		}
+		seen[p.ProcessID] = true
+	}
+
+	return validateDependencies(processes)
+}
+
+const templatePrefix = "template:"
+
+// maxTemplateProcesses caps the processes templates can add to a workload, so a huge count is an
+// error rather than a slice too large to allocate.
+const maxTemplateProcesses = 1_000_000
+
+// expandTemplate expands a "template: burst=5 arrival=+2 count=100" line into count processes.
+// Each of id, burst, arrival and priority is either an absolute value or, prefixed with "+",
+// an increment over the process before it (the last one loaded, for the first copy).
+// IDs default to "+1" and the rest to "+0"; count defaults to 1, and at most what is left of
+// maxTemplateProcesses after the processes loaded. Burst and arrival are times, read in ticks of a
+// time base.
+func expandTemplate(line string, loaded []Process, base timeBase) ([]Process, error) {
+	var (
+		count  int64 = 1
+		fields       = map[string]string{"id": "+1"}
+	)
+	for _, kv := range strings.Fields(strings.TrimPrefix(strings.TrimSpace(line), templatePrefix)) {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: template field %q is not key=value", ErrInvalidArgs, kv)
+		}
+		switch key {
+		case "count":
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("%w: template count %q", ErrInvalidArgs, value)
+			}
+			if budget := int64(maxTemplateProcesses - len(loaded)); n > budget {
+				return nil, fmt.Errorf("%w: template count %d makes the workload longer than %d processes", ErrInvalidArgs, n, maxTemplateProcesses)
+			}
+			count = n
+		case "id", "burst", "arrival", "priority":
+			fields[key] = value
+		case "class":
+			if _, err := parseClass(value); err != nil {
+				return nil, fmt.Errorf("%w: template %v", ErrInvalidArgs, err)
+			}
+			fields[key] = value
+		default:
+			return nil, fmt.Errorf("%w: unknown template field %q", ErrInvalidArgs, key)
+		}
 	}
 
-	return i
+	var prev Process
+	if len(loaded) > 0 {
+		prev = loaded[len(loaded)-1]
+	}
+	next := func(key string, last int64) (int64, error) {
+		value, ok := fields[key]
+		if !ok {
+			return last, nil
+		}
+		parse := func(s string) (int64, error) { return strconv.ParseInt(s, 10, 64) }
+		if key == "burst" || key == "arrival" {
+			parse = func(s string) (int64, error) { return parseTime(s, base) }
+		}
+		if strings.HasPrefix(value, "+") {
+			n, err := parse(strings.TrimPrefix(value, "+"))
+			if err != nil {
+				return 0, fmt.Errorf("%w: template %s %q", ErrInvalidArgs, key, value)
+			}
+			return last + n, nil
+		}
+		n, err := parse(value)
+		if err != nil {
+			return 0, fmt.Errorf("%w: template %s %q", ErrInvalidArgs, key, value)
+		}
+		return n, nil
+	}
+
+	processes := make([]Process, count)
+	for i := range processes {
+		var err error
+		p := &processes[i]
+		if p.ProcessID, err = next("id", prev.ProcessID); err != nil {
+			return nil, err
+		}
+		if p.BurstDuration, err = next("burst", prev.BurstDuration); err != nil {
+			return nil, err
+		}
+		if p.ArrivalTime, err = next("arrival", prev.ArrivalTime); err != nil {
+			return nil, err
+		}
+		if p.Priority, err = next("priority", prev.Priority); err != nil {
+			return nil, err
+		}
+		p.Class = prev.Class
+		if class, ok := fields["class"]; ok {
+			p.Class = class
+		}
+		prev = *p
+	}
+
+	return processes, nil
 }
 
 //endregion
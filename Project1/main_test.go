@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io"
 	"os"
@@ -64,6 +65,114 @@ func TestFCFSSchedule(t *testing.T) {
 	}
 }
 
+func Test_fcfs_arrivalGaps(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		workload       string
+		wantWait       []int64
+		wantCompletion []int64
+		wantIdle       int64
+	}{
+		{name: "no gap", workload: "1,3,0,1\n2,2,1,1", wantWait: []int64{0, 2}, wantCompletion: []int64{3, 5}},
+		{name: "gap", workload: "1,2,0,1\n2,3,5,1", wantWait: []int64{0, 0}, wantCompletion: []int64{2, 8}, wantIdle: 3},
+		{name: "first arrival late", workload: "1,1,2,1", wantWait: []int64{0}, wantCompletion: []int64{3}},
+		{name: "arrived runs first", workload: "1,2,4,1\n2,3,0,1", wantWait: []int64{0, 0}, wantCompletion: []int64{6, 3}, wantIdle: 1},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			processes, err := loadProcesses(strings.NewReader(tt.workload))
+			if err != nil {
+				t.Fatal(err)
+			}
+			s := fcfs(processes, Options{})
+			if !reflect.DeepEqual(s.Wait, tt.wantWait) {
+				t.Errorf("Wait = %v, want %v", s.Wait, tt.wantWait)
+			}
+			if !reflect.DeepEqual(s.Completion, tt.wantCompletion) {
+				t.Errorf("Completion = %v, want %v", s.Completion, tt.wantCompletion)
+			}
+			if got := s.IdleTime(); got != tt.wantIdle {
+				t.Errorf("IdleTime() = %d, want %d", got, tt.wantIdle)
+			}
+		})
+	}
+}
+
+func Test_algorithm_schedule_inputTies(t *testing.T) {
+	t.Parallel()
+	processes, err := loadProcesses(strings.NewReader("5,3,0,1\n3,3,0,1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		algorithm string
+		opts      Options
+		wantFirst int64
+	}{
+		{name: "sjf", algorithm: "sjf", wantFirst: 5},
+		{name: "sjf by pid", algorithm: "sjf", opts: Options{TieBreak: TieBreakPID}, wantFirst: 3},
+		{name: "sjf preserving order", algorithm: "sjf", opts: Options{PreserveOrder: true}, wantFirst: 5},
+		{name: "priority", algorithm: "priority", wantFirst: 5},
+		{name: "rr", algorithm: "rr", wantFirst: 5},
+		{name: "rr preserving order", algorithm: "rr", opts: Options{PreserveOrder: true}, wantFirst: 5},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			selected, err := selectAlgorithms(tt.algorithm)
+			if err != nil {
+				t.Fatal(err)
+			}
+			s := selected[0].schedule(processes, tt.opts)
+			if got := s.Gantt[0].PID; got != tt.wantFirst {
+				t.Errorf("first to run = %d, want %d", got, tt.wantFirst)
+			}
+		})
+	}
+}
+
+func Test_algorithm_schedule_order(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		workload string
+		preserve bool
+		wantIDs  []int64
+	}{
+		{name: "sorted", workload: "1,2,0,1\n2,3,1,1", wantIDs: []int64{1, 2}},
+		{name: "by arrival", workload: "1,2,4,1\n2,3,0,1\n3,1,2,1", wantIDs: []int64{2, 3, 1}},
+		{name: "ties by ID", workload: "5,1,0,1\n3,1,0,1\n4,1,1,1", wantIDs: []int64{3, 5, 4}},
+		{name: "preserve order", workload: "1,2,4,1\n2,3,0,1\n3,1,2,1", preserve: true, wantIDs: []int64{1, 2, 3}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			processes, err := loadProcesses(strings.NewReader(tt.workload))
+			if err != nil {
+				t.Fatal(err)
+			}
+			first := processes[0].ProcessID
+			s := algorithms[0].schedule(processes, Options{PreserveOrder: tt.preserve})
+			ids := make([]int64, len(s.Processes))
+			for i, p := range s.Processes {
+				ids[i] = p.ProcessID
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("process order = %v, want %v", ids, tt.wantIDs)
+			}
+			if processes[0].ProcessID != first {
+				t.Errorf("schedule sorted the workload in place")
+			}
+		})
+	}
+}
+
 func Test_loadProcesses(t *testing.T) {
 	t.Parallel()
 	type args struct {
@@ -82,6 +191,13 @@ func Test_loadProcesses(t *testing.T) {
 			},
 			wantErr: io.ErrUnexpectedEOF,
 		},
+		{
+			name: "bad number",
+			args: args{
+				r: strings.NewReader("1,5,0,2\n2,nine,3,1"),
+			},
+			wantErr: ErrInvalidArgs,
+		},
 		{
 			name: "success",
 			args: args{
@@ -110,6 +226,61 @@ func Test_loadProcesses(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "template",
+			args: args{
+				r: strings.NewReader(`1,5,0,2
+template: burst=3 arrival=+2 count=2
+template: id=10 priority=7`),
+			},
+			want: []Process{
+				{
+					ProcessID:     1,
+					ArrivalTime:   0,
+					BurstDuration: 5,
+					Priority:      2,
+				},
+				{
+					ProcessID:     2,
+					ArrivalTime:   2,
+					BurstDuration: 3,
+					Priority:      2,
+				},
+				{
+					ProcessID:     3,
+					ArrivalTime:   4,
+					BurstDuration: 3,
+					Priority:      2,
+				},
+				{
+					ProcessID:     10,
+					ArrivalTime:   4,
+					BurstDuration: 3,
+					Priority:      7,
+				},
+			},
+		},
+		{
+			name: "bad template",
+			args: args{
+				r: strings.NewReader(`template: burst=3 speed=2`),
+			},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name: "template count too large",
+			args: args{
+				r: strings.NewReader(`template: burst=1 count=9000000000000000000`),
+			},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name: "template count over the budget",
+			args: args{
+				r: strings.NewReader("1,5,0,2\ntemplate: burst=1 count=1000000"),
+			},
+			wantErr: ErrInvalidArgs,
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -132,7 +303,7 @@ func loadFixture(t *testing.T, p ...string) string {
 		t.Fail()
 	}
 
-	return string(b)
+	return strings.ReplaceAll(string(b), "\r\n", "\n") // fixtures may be checked out with CRLF endings
 }
 
 func Test_openProcessingFile1(t *testing.T) {
@@ -205,3 +376,179 @@ func Test_openProcessingFile1(t *testing.T) {
 		})
 	}
 }
+
+func Test_outputProportionalGanttChart(t *testing.T) {
+	t.Parallel()
+	gantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 8},
+		{PID: idlePID, Start: 8, Stop: 9},
+		{PID: 12, Start: 9, Stop: 10},
+	}
+	tests := []struct {
+		name     string
+		scale    float64
+		minWidth int
+		color    bool
+		want     string
+	}{
+		{
+			name:  "one character per unit",
+			scale: 1,
+			want:  "|   1    |IDLE|12|\n0        8    9  10\n",
+		},
+		{
+			name:     "minimum width",
+			scale:    0.5,
+			minWidth: 6,
+			want:     "|  1   | IDLE |  12  |\n0      8      9      10\n",
+		},
+		{
+			name:  "color",
+			scale: 1,
+			color: true,
+			want:  "|   \x1b[1;33m1\x1b[0m    |\x1b[2mIDLE\x1b[0m|\x1b[1;34m12\x1b[0m|\n0        8    9  10\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			outputProportionalGanttChart(&w, gantt, tt.scale, tt.minWidth, tt.color, timeBase{})
+			if got := w.String(); got != tt.want {
+				t.Errorf("outputProportionalGanttChart() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_validateProcesses(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		wantErr   error
+	}{
+		{name: "valid", processes: []Process{{ProcessID: 1, BurstDuration: 1}, {ProcessID: 2, ArrivalTime: 4, BurstDuration: 3}}},
+		{name: "no processes", wantErr: ErrInvalidArgs},
+		{name: "duplicate ID", processes: []Process{{ProcessID: 1, BurstDuration: 1}, {ProcessID: 1, BurstDuration: 2}}, wantErr: ErrValidation},
+		{name: "zero burst", processes: []Process{{ProcessID: 1}}, wantErr: ErrValidation},
+		{name: "negative arrival", processes: []Process{{ProcessID: 1, ArrivalTime: -1, BurstDuration: 1}}, wantErr: ErrValidation},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := validateProcesses(tt.processes); !errors.Is(err, tt.wantErr) {
+				t.Errorf("validateProcesses() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_validateCommand(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		p := path.Join(dir, name)
+		if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	good := write("good.csv", "1,5,0,2\n2,9,3,1\n")
+	badBurst := write("burst.csv", "1,0,0,2\n")
+	badNumber := write("number.csv", "1,five,0,2\n")
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr error
+	}{
+		{name: "valid", args: []string{good}, want: good + ": ok, 2 processes\n"},
+		{name: "no files", wantErr: ErrInvalidArgs},
+		{name: "invalid", args: []string{good, badBurst}, want: good + ": ok, 2 processes\n", wantErr: ErrValidation},
+		{name: "unparsable", args: []string{badNumber}, wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			err := validateCommand(&w, tt.args)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			if got := w.String(); got != tt.want {
+				t.Errorf("validateCommand() wrote %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_addReportFlags(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr error
+	}{
+		{name: "defaults"},
+		{name: "slowdown bound", args: []string{"-slowdown-bound", "0"}},
+		{name: "negative slowdown bound", args: []string{"-slowdown-bound", "-1"}, wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fs := newFlagSet("run")
+			report := addReportFlags(fs)
+			if err := parseFlags(fs, tt.args); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := report(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("report() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_usage(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	usage(&w, "schedsim")
+	for name, cmd := range commands {
+		if !strings.Contains(w.String(), name) || !strings.Contains(w.String(), cmd.summary) {
+			t.Errorf("usage() = %s, want it to list %s", w.String(), name)
+		}
+	}
+}
+
+func Test_algorithm_scheduleContext(t *testing.T) {
+	t.Parallel()
+	processes, err := loadProcesses(strings.NewReader("1,5,0,2\n2,9,3,1\n3,6,6,3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, a := range algorithms {
+		a := a
+		t.Run(a.name, func(t *testing.T) {
+			t.Parallel()
+			if _, err := a.scheduleContext(context.Background(), processes, Options{}); err != nil {
+				t.Errorf("scheduleContext() error = %v, want none", err)
+			}
+			s, err := a.scheduleContext(cancelled, processes, Options{})
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("scheduleContext() error = %v, want %v", err, context.Canceled)
+			}
+			for i, c := range s.Completion {
+				if c != 0 {
+					t.Errorf("process %d completed at %d, want the simulation stopped before", s.Processes[i].ProcessID, c)
+				}
+			}
+		})
+	}
+}
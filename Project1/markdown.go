@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// outputMarkdown outputs each result as a GitHub-flavored markdown section with a fenced GANTT
+// chart and tables, ready to paste into a README or pull request.
+func outputMarkdown(w io.Writer, results []result, r Report) error {
+	if r.BurstHistogram > 0 && len(results) > 0 {
+		_, _ = fmt.Fprint(w, "## Burst lengths\n\n```text\n")
+		for _, line := range results[0].schedule.histogramLines(r) {
+			_, _ = fmt.Fprintln(w, line)
+		}
+		_, _ = fmt.Fprint(w, "```\n\n")
+	}
+	for _, res := range results {
+		s := res.schedule
+		_, _ = fmt.Fprintf(w, "## %s\n\n", res.title)
+
+		_, _ = fmt.Fprintln(w, "```text")
+		outputGanttChart(w, s.timeline(), s.timeBase(), r)
+		_, _ = fmt.Fprint(w, "```\n\n")
+
+		footer := s.footer(r)
+		for i := range footer {
+			if footer[i] != "" {
+				footer[i] = "**" + strings.ReplaceAll(strings.Replace(footer[i], "\n", ": ", 1), "\n", ", ") + "**"
+			}
+		}
+		outputMarkdownTable(w, scheduleHeader(r), append(s.rows(r), footer))
+
+		for _, line := range s.summary() {
+			_, _ = fmt.Fprintf(w, "- %s\n", line)
+		}
+		_, _ = fmt.Fprintln(w)
+
+		if r.Stats {
+			outputMarkdownTable(w, statsHeader, s.statsRows())
+		}
+		if r.ByPriority {
+			outputMarkdownTable(w, priorityHeader, s.priorityRows())
+		}
+		if r.ThroughputWindow > 0 {
+			outputMarkdownTable(w, throughputHeader, s.throughputRows(r))
+		}
+		if lines := s.convoyLines(); r.Convoys && lines != nil {
+			_, _ = fmt.Fprintln(w, "**Convoy effect**")
+			_, _ = fmt.Fprintln(w)
+			for _, line := range lines {
+				_, _ = fmt.Fprintf(w, "- %s\n", line)
+			}
+			_, _ = fmt.Fprintln(w)
+		}
+		if starved := s.Starvation(r); len(starved) > 0 {
+			_, _ = fmt.Fprintln(w, "**Starvation warnings**")
+			_, _ = fmt.Fprintln(w)
+			for _, st := range starved {
+				_, _ = fmt.Fprintf(w, "- process %d %s\n", st.PID, st.Reason)
+			}
+			_, _ = fmt.Fprintln(w)
+		}
+	}
+	if r.Compare {
+		_, _ = fmt.Fprint(w, "## Comparison\n\n")
+		header, rows := comparisonRows(results)
+		outputMarkdownTable(w, header, rows)
+		_, _ = fmt.Fprint(w, "\\* best value\n")
+	}
+
+	return nil
+}
+
+// outputMarkdownTable outputs a GitHub-flavored markdown table, right-aligning every column but
+// the first.
+func outputMarkdownTable(w io.Writer, header []string, rows [][]string) {
+	escape := func(cells []string) string {
+		escaped := make([]string, len(cells))
+		for i, c := range cells {
+			if !strings.HasPrefix(c, "**") {
+				c = strings.ReplaceAll(c, "*", `\*`)
+			}
+			escaped[i] = strings.ReplaceAll(c, "|", `\|`)
+		}
+		return "| " + strings.Join(escaped, " | ") + " |"
+	}
+
+	align := make([]string, len(header))
+	for i := range align {
+		align[i] = "---:"
+	}
+	align[0] = ":---"
+
+	_, _ = fmt.Fprintln(w, escape(header))
+	_, _ = fmt.Fprintln(w, "|"+strings.Join(align, "|")+"|")
+	for _, row := range rows {
+		_, _ = fmt.Fprintln(w, escape(row))
+	}
+	_, _ = fmt.Fprintln(w)
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_outputMarkdown(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	}
+	results := []result{{title: "First-come, first-serve", schedule: fcfs(processes, Options{})}}
+
+	var w bytes.Buffer
+	if err := outputMarkdown(&w, results, Report{SlowdownBound: defaultSlowdownBound, Compare: true}); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"## First-come, first-serve\n\n```text\n|   1   |   2   |   3   |\n0\t5\t14\t20\n```\n",
+		"|:---|---:|---:|",
+		"| 2 | 1 | 9 | 3 | 2 | 11 | 1.22 | 1.10 | 2 | 1 | 14 |\n",
+		"| **Average: 3.33, p95=7.40, p99=7.88** | **Average: 10.00** |",
+		"- Makespan: 20 (from 0 to 20)\n",
+		"## Comparison",
+		"| 3.33 \\* |",
+	} {
+		if got := w.String(); !strings.Contains(got, want) {
+			t.Errorf("outputMarkdown() = %v, want it to contain %q", got, want)
+		}
+	}
+}
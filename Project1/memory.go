@@ -0,0 +1,421 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// fitAlgorithm is a contiguous memory allocation algorithm selectable with -algorithms. choose
+// returns the index of the hole among the blocks to place a request of size in, or -1 when none fits.
+type fitAlgorithm struct {
+	name   string
+	title  string
+	choose func(blocks []memoryBlock, size int64) int
+}
+
+var fitAlgorithms = []fitAlgorithm{
+	{name: "first", title: "First fit", choose: firstFit},
+	{name: "best", title: "Best fit", choose: bestFit},
+	{name: "worst", title: "Worst fit", choose: worstFit},
+}
+
+// firstFit places a request in the first hole big enough.
+func firstFit(blocks []memoryBlock, size int64) int {
+	for i, b := range blocks {
+		if b.hole() && b.size >= size {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// bestFit places a request in the smallest hole big enough; the first such hole wins a tie.
+func bestFit(blocks []memoryBlock, size int64) int {
+	best := -1
+	for i, b := range blocks {
+		if b.hole() && b.size >= size && (best < 0 || b.size < blocks[best].size) {
+			best = i
+		}
+	}
+
+	return best
+}
+
+// worstFit places a request in the largest hole; the first such hole wins a tie.
+func worstFit(blocks []memoryBlock, size int64) int {
+	worst := -1
+	for i, b := range blocks {
+		if b.hole() && b.size >= size && (worst < 0 || b.size > blocks[worst].size) {
+			worst = i
+		}
+	}
+
+	return worst
+}
+
+// memoryBlock is a run of memory, either allocated to an owner or a hole. Blocks of different
+// regions are never merged, so the initial holes stay apart as if allocated memory lay between them.
+type memoryBlock struct {
+	start, size int64
+	region      int
+	owner       string // empty for a hole
+}
+
+func (b memoryBlock) hole() bool {
+	return b.owner == ""
+}
+
+// memoryRequest is one line of a memory request file: allocating size to an owner, or freeing what
+// the owner holds.
+type memoryRequest struct {
+	free  bool
+	owner string
+	size  int64
+}
+
+func (r memoryRequest) String() string {
+	if r.free {
+		return "free " + r.owner
+	}
+
+	return fmt.Sprintf("alloc %s %d", r.owner, r.size)
+}
+
+// memoryStep is the state of memory after one request. start is where an allocation was placed, or
+// -1 when it failed or the request was a free.
+type memoryStep struct {
+	request memoryRequest
+	start   int64
+	failed  bool
+	holes   []int64
+}
+
+// free returns the total size of the holes.
+func (s memoryStep) free() int64 {
+	var total int64
+	for _, h := range s.holes {
+		total += h
+	}
+
+	return total
+}
+
+// largest returns the size of the largest hole.
+func (s memoryStep) largest() int64 {
+	var largest int64
+	for _, h := range s.holes {
+		if h > largest {
+			largest = h
+		}
+	}
+
+	return largest
+}
+
+// fragmentation returns the external fragmentation in percent: the share of free memory outside the
+// largest hole, which no request bigger than that hole can use.
+func (s memoryStep) fragmentation() float64 {
+	free := s.free()
+	if free == 0 {
+		return 0
+	}
+
+	return 100 * float64(free-s.largest()) / float64(free)
+}
+
+// newMemory lays out the initial holes one after the other from address 0, each in its own region.
+func newMemory(holes []int64) []memoryBlock {
+	blocks := make([]memoryBlock, len(holes))
+	var start int64
+	for i, size := range holes {
+		blocks[i] = memoryBlock{start: start, size: size, region: i}
+		start += size
+	}
+
+	return blocks
+}
+
+// simulateMemory runs the requests against the initial holes with a fit algorithm. An allocation
+// takes the start of the chosen hole; a free turns the owner's block back into a hole, merged with
+// the holes next to it in its region. Freeing an owner whose allocation failed does nothing.
+func simulateMemory(holes []int64, requests []memoryRequest, choose func([]memoryBlock, int64) int) []memoryStep {
+	var (
+		blocks = newMemory(holes)
+		steps  = make([]memoryStep, len(requests))
+	)
+	for i, req := range requests {
+		step := memoryStep{request: req, start: -1}
+		if req.free {
+			blocks = freeBlock(blocks, req.owner)
+		} else if h := choose(blocks, req.size); h < 0 {
+			step.failed = true
+		} else {
+			hole := blocks[h]
+			step.start = hole.start
+			placed := []memoryBlock{{start: hole.start, size: req.size, region: hole.region, owner: req.owner}}
+			if hole.size > req.size {
+				placed = append(placed, memoryBlock{start: hole.start + req.size, size: hole.size - req.size, region: hole.region})
+			}
+			blocks = append(blocks[:h], append(placed, blocks[h+1:]...)...)
+		}
+		for _, b := range blocks {
+			if b.hole() {
+				step.holes = append(step.holes, b.size)
+			}
+		}
+		steps[i] = step
+	}
+
+	return steps
+}
+
+// freeBlock turns the block of an owner into a hole and merges it with neighbouring holes of its region.
+func freeBlock(blocks []memoryBlock, owner string) []memoryBlock {
+	i := -1
+	for j, b := range blocks {
+		if b.owner == owner {
+			i = j
+		}
+	}
+	if i < 0 {
+		return blocks
+	}
+	blocks[i].owner = ""
+	if i+1 < len(blocks) && blocks[i+1].hole() && blocks[i+1].region == blocks[i].region {
+		blocks[i].size += blocks[i+1].size
+		blocks = append(blocks[:i+1], blocks[i+2:]...)
+	}
+	if i > 0 && blocks[i-1].hole() && blocks[i-1].region == blocks[i].region {
+		blocks[i-1].size += blocks[i].size
+		blocks = append(blocks[:i], blocks[i+1:]...)
+	}
+
+	return blocks
+}
+
+// loadMemoryRequests reads a CSV of "alloc,owner,size" and "free,owner" lines; lines starting with #
+// are ignored. An owner may not allocate again before it frees, nor free what it did not allocate.
+func loadMemoryRequests(r io.Reader) ([]memoryRequest, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	cr.Comment = '#'
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading memory requests", err)
+	}
+	var (
+		requests = make([]memoryRequest, 0, len(rows))
+		live     = make(map[string]bool)
+	)
+	for i, row := range rows {
+		for j := range row {
+			row[j] = strings.TrimSpace(row[j])
+		}
+		switch {
+		case row[0] == "alloc" && len(row) == 3:
+			size, err := strconv.ParseInt(row[2], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidArgs, i+1, err)
+			}
+			if size <= 0 {
+				return nil, fmt.Errorf("%w: line %d: size must be positive", ErrValidation, i+1)
+			}
+			if live[row[1]] {
+				return nil, fmt.Errorf("%w: line %d: %s allocates again before freeing", ErrValidation, i+1, row[1])
+			}
+			live[row[1]] = true
+			requests = append(requests, memoryRequest{owner: row[1], size: size})
+		case row[0] == "free" && len(row) == 2:
+			if !live[row[1]] {
+				return nil, fmt.Errorf("%w: line %d: %s frees without allocating", ErrValidation, i+1, row[1])
+			}
+			delete(live, row[1])
+			requests = append(requests, memoryRequest{free: true, owner: row[1]})
+		default:
+			return nil, fmt.Errorf("%w: line %d: want alloc,owner,size or free,owner", ErrInvalidArgs, i+1)
+		}
+		if row[1] == "" {
+			return nil, fmt.Errorf("%w: line %d: missing owner", ErrInvalidArgs, i+1)
+		}
+	}
+
+	return requests, nil
+}
+
+// parseHoles reads a comma separated list of hole sizes.
+func parseHoles(list string) ([]int64, error) {
+	holes := make([]int64, 0)
+	for _, field := range strings.Split(list, ",") {
+		size, err := strconv.ParseInt(strings.TrimSpace(field), 10, 64)
+		if err != nil || size <= 0 {
+			return nil, fmt.Errorf("%w: hole size %q must be a positive number", ErrInvalidArgs, field)
+		}
+		holes = append(holes, size)
+	}
+
+	return holes, nil
+}
+
+// outputMemoryResult outputs the holes after every request of one algorithm and its fragmentation.
+func outputMemoryResult(w io.Writer, title string, steps []memoryStep, style string) {
+	outputTitle(w, title)
+	rows := make([][]string, len(steps))
+	for i, s := range steps {
+		placed := ""
+		switch {
+		case s.failed:
+			placed = "failed"
+		case s.start >= 0:
+			placed = fmt.Sprint(s.start)
+		}
+		holes := make([]string, len(s.holes))
+		for j, h := range s.holes {
+			holes[j] = fmt.Sprint(h)
+		}
+		rows[i] = []string{
+			fmt.Sprint(i + 1),
+			s.request.String(),
+			placed,
+			strings.Join(holes, " "),
+			fmt.Sprint(s.free()),
+			fmt.Sprint(s.largest()),
+			fmt.Sprintf("%.2f%%", s.fragmentation()),
+		}
+	}
+	header := []string{"Step", "Request", "Placed at", "Holes", "Free", "Largest hole", "Fragmentation"}
+	outputTable(w, style, header, rows, nil, nil)
+
+	failed, average := memoryTotals(steps)
+	_, _ = fmt.Fprintf(w, "Failed allocations: %d\n", failed)
+	_, _ = fmt.Fprintf(w, "Average external fragmentation: %.2f%%\n", average)
+	_, _ = fmt.Fprintln(w)
+}
+
+// memoryTotals returns the number of failed allocations and the average fragmentation over the steps.
+func memoryTotals(steps []memoryStep) (failed int, average float64) {
+	for _, s := range steps {
+		if s.failed {
+			failed++
+		}
+		average += s.fragmentation()
+	}
+
+	return failed, average / float64(len(steps))
+}
+
+// outputMemoryComparison outputs a table of each algorithm's failures and fragmentation, marking the
+// fewest failures.
+func outputMemoryComparison(w io.Writer, titles []string, failed []int, fragmentation []float64, style string) {
+	_, _ = fmt.Fprintln(w, "Comparison")
+	best := failed[0]
+	for _, f := range failed {
+		if f < best {
+			best = f
+		}
+	}
+	rows := make([][]string, len(titles))
+	for i := range titles {
+		mark := ""
+		if failed[i] == best {
+			mark = " *"
+		}
+		rows[i] = []string{titles[i], fmt.Sprint(failed[i]) + mark, fmt.Sprintf("%.2f%%", fragmentation[i])}
+	}
+	alignment := []int{tablewriter.ALIGN_LEFT, tablewriter.ALIGN_RIGHT, tablewriter.ALIGN_RIGHT}
+	outputTable(w, style, []string{"Algorithm", "Failed allocations", "Average fragmentation"}, rows, nil, alignment)
+	_, _ = fmt.Fprintln(w, "* fewest failed allocations")
+}
+
+// selectFitAlgorithms looks up a comma separated list of fit algorithm names; "all" selects every one.
+func selectFitAlgorithms(names string) ([]fitAlgorithm, error) {
+	if names == "all" {
+		return fitAlgorithms, nil
+	}
+	selected := make([]fitAlgorithm, 0)
+	for _, name := range strings.Split(names, ",") {
+		found := false
+		for _, a := range fitAlgorithms {
+			if a.name == strings.TrimSpace(name) {
+				selected = append(selected, a)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: unknown fit algorithm %q", ErrInvalidArgs, name)
+		}
+	}
+
+	return selected, nil
+}
+
+// memoryCommand simulates contiguous memory allocation of the requests in a CSV file.
+func memoryCommand(w io.Writer, args []string) error {
+	fs := newFlagSet("memory")
+	names := fs.String("algorithms", "all", "comma separated fit algorithms: first, best, worst")
+	size := fs.Int64("size", 1024, "size of memory, one hole, when -holes is not given")
+	holeList := fs.String("holes", "", "comma separated sizes of separate initial holes, e.g. \"100,500,200\"")
+	tableStyle := fs.String("table-style", "ascii", "text table style: "+strings.Join(tableStyleNames(), ", "))
+	logging := addLogFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := logging(); err != nil {
+		return err
+	}
+	if _, ok := tableStyles[*tableStyle]; !ok && *tableStyle != tsvStyle {
+		return fmt.Errorf("%w: unknown table style %q", ErrInvalidArgs, *tableStyle)
+	}
+	selected, err := selectFitAlgorithms(*names)
+	if err != nil {
+		return err
+	}
+	holes := []int64{*size}
+	if *holeList != "" {
+		if holes, err = parseHoles(*holeList); err != nil {
+			return err
+		}
+	} else if *size <= 0 {
+		return fmt.Errorf("%w: size must be positive", ErrInvalidArgs)
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: must give a memory request file", ErrInvalidArgs)
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("%v: error opening memory request file", err)
+	}
+	defer f.Close()
+	requests, err := loadMemoryRequests(f)
+	if err != nil {
+		return err
+	}
+	if len(requests) == 0 {
+		return fmt.Errorf("%w: no memory requests", ErrValidation)
+	}
+
+	var (
+		titles        = make([]string, len(selected))
+		failed        = make([]int, len(selected))
+		fragmentation = make([]float64, len(selected))
+	)
+	for i, a := range selected {
+		steps := simulateMemory(holes, requests, a.choose)
+		outputMemoryResult(w, a.title, steps, *tableStyle)
+		titles[i] = a.title
+		failed[i], fragmentation[i] = memoryTotals(steps)
+	}
+	if len(selected) > 1 {
+		outputMemoryComparison(w, titles, failed, fragmentation, *tableStyle)
+	}
+
+	return nil
+}
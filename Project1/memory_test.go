@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_fitAlgorithms(t *testing.T) {
+	t.Parallel()
+	// The holes and requests of the textbook example.
+	holes := []int64{100, 500, 200, 300, 600}
+	requests := []memoryRequest{{owner: "P1", size: 212}, {owner: "P2", size: 417}, {owner: "P3", size: 112}, {owner: "P4", size: 426}}
+	tests := []struct {
+		name       string
+		choose     func([]memoryBlock, int64) int
+		wantStarts []int64
+		wantHoles  []int64
+	}{
+		{name: "first", choose: firstFit, wantStarts: []int64{100, 1100, 312, -1}, wantHoles: []int64{100, 176, 200, 300, 183}},
+		{name: "best", choose: bestFit, wantStarts: []int64{800, 100, 600, 1100}, wantHoles: []int64{100, 83, 88, 88, 174}},
+		{name: "worst", choose: worstFit, wantStarts: []int64{1100, 100, 1312, -1}, wantHoles: []int64{100, 83, 200, 300, 276}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			steps := simulateMemory(holes, requests, tt.choose)
+			starts := make([]int64, len(steps))
+			for i, s := range steps {
+				starts[i] = s.start
+				if s.failed != (tt.wantStarts[i] < 0) {
+					t.Errorf("step %d failed = %v", i+1, s.failed)
+				}
+			}
+			if !reflect.DeepEqual(starts, tt.wantStarts) {
+				t.Errorf("starts = %v, want %v", starts, tt.wantStarts)
+			}
+			if got := steps[len(steps)-1].holes; !reflect.DeepEqual(got, tt.wantHoles) {
+				t.Errorf("holes = %v, want %v", got, tt.wantHoles)
+			}
+		})
+	}
+}
+
+func Test_simulateMemory_free(t *testing.T) {
+	t.Parallel()
+	requests := []memoryRequest{
+		{owner: "A", size: 100},
+		{owner: "B", size: 100},
+		{owner: "C", size: 100},
+		{free: true, owner: "A"},
+		{free: true, owner: "C"},
+		{free: true, owner: "B"},
+	}
+	steps := simulateMemory([]int64{400}, requests, firstFit)
+	want := [][]int64{{300}, {200}, {100}, {100, 100}, {100, 200}, {400}}
+	for i, s := range steps {
+		if !reflect.DeepEqual(s.holes, want[i]) {
+			t.Errorf("step %d holes = %v, want %v", i+1, s.holes, want[i])
+		}
+	}
+	if got := steps[4].fragmentation(); got < 33.33 || got > 33.34 {
+		t.Errorf("fragmentation() = %.2f, want 33.33", got)
+	}
+}
+
+func Test_loadMemoryRequests(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		input   string
+		want    []memoryRequest
+		wantErr error
+	}{
+		{
+			name:  "alloc and free",
+			input: "# owner, size\nalloc, A, 100\nfree, A\nalloc,A,50\n",
+			want:  []memoryRequest{{owner: "A", size: 100}, {free: true, owner: "A"}, {owner: "A", size: 50}},
+		},
+		{name: "bad size", input: "alloc,A,x\n", wantErr: ErrInvalidArgs},
+		{name: "zero size", input: "alloc,A,0\n", wantErr: ErrValidation},
+		{name: "unknown operation", input: "grow,A,10\n", wantErr: ErrInvalidArgs},
+		{name: "allocates twice", input: "alloc,A,10\nalloc,A,10\n", wantErr: ErrValidation},
+		{name: "frees unallocated", input: "free,A\n", wantErr: ErrValidation},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := loadMemoryRequests(strings.NewReader(tt.input))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadMemoryRequests() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_memoryCommand(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "requests.csv")
+	if err := os.WriteFile(path, []byte("alloc,P1,212\nalloc,P2,417\nalloc,P3,112\nalloc,P4,426\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		args    []string
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "compare",
+			args: []string{"-holes", "100,500,200,300,600", path},
+			want: []string{"| alloc P4 426 | failed", "Failed allocations: 1", "| Best fit  |                0 * |"},
+		},
+		{name: "one hole", args: []string{"-algorithms", "first", path}, want: []string{"Failed allocations: 1"}},
+		{name: "bad holes", args: []string{"-holes", "100,x", path}, wantErr: ErrInvalidArgs},
+		{name: "bad algorithm", args: []string{"-algorithms", "next", path}, wantErr: ErrInvalidArgs},
+		{name: "no file", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			err := memoryCommand(&w, tt.args)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(w.String(), want) {
+					t.Errorf("memoryCommand() = %s, want it to contain %q", w.String(), want)
+				}
+			}
+		})
+	}
+}
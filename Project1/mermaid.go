@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// outputMermaid outputs each result's GANTT chart as a fenced Mermaid gantt block, one section per
+// process, that renders in GitHub and GitLab markdown.
+func outputMermaid(w io.Writer, results []result, _ Report) error {
+	for i, res := range results {
+		if i > 0 {
+			_, _ = fmt.Fprintln(w)
+		}
+		_, _ = fmt.Fprintln(w, "```mermaid")
+		_, _ = fmt.Fprintln(w, "gantt")
+		_, _ = fmt.Fprintln(w, "    title", res.title)
+		_, _ = fmt.Fprintln(w, "    dateFormat X")
+		_, _ = io.WriteString(w, "    axisFormat %s\n")
+
+		var (
+			lanes    = make(map[int64][]TimeSlice)
+			sections []int64
+		)
+		for _, ts := range res.schedule.timeline() {
+			if _, ok := lanes[ts.PID]; !ok {
+				sections = append(sections, ts.PID)
+			}
+			lanes[ts.PID] = append(lanes[ts.PID], ts)
+		}
+		sort.SliceStable(sections, func(i, j int) bool {
+			return sections[i] >= 0 && (sections[j] < 0 || sections[i] < sections[j])
+		})
+		for _, pid := range sections {
+			name := fmt.Sprintf("P%d", pid)
+			if pid < 0 {
+				name = sliceLabel(pid)
+			}
+			_, _ = fmt.Fprintln(w, "    section", name)
+			for _, ts := range lanes[pid] {
+				_, _ = fmt.Fprintf(w, "    %s : %s, %s\n", name, res.schedule.formatTime(ts.Start), res.schedule.formatTime(ts.Stop))
+			}
+		}
+		_, _ = fmt.Fprintln(w, "```")
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_outputMermaid(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 3, Priority: 2},
+		{ProcessID: 1, ArrivalTime: 1, BurstDuration: 1, Priority: 1},
+	}
+	results := []result{{title: "Priority", schedule: preemptivePriority(processes, Options{})}}
+
+	var w bytes.Buffer
+	if err := outputMermaid(&w, results, Report{}); err != nil {
+		t.Fatal(err)
+	}
+	want := "```mermaid\n" +
+		"gantt\n" +
+		"    title Priority\n" +
+		"    dateFormat X\n" +
+		"    axisFormat %s\n" +
+		"    section P1\n" +
+		"    P1 : 1, 2\n" +
+		"    section P2\n" +
+		"    P2 : 0, 1\n" +
+		"    P2 : 2, 4\n" +
+		"```\n"
+	if got := w.String(); got != want {
+		t.Errorf("outputMermaid() = %q, want %q", got, want)
+	}
+}
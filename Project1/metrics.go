@@ -0,0 +1,403 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// scheduleColumns are the schedule table columns in order, by -columns and -sort-by key.
+var scheduleColumns = []struct{ key, header string }{
+	{"id", "ID"},
+	{"priority", "Priority"},
+	{"burst", "Burst"},
+	{"arrival", "Arrival"},
+	{"wait", "Wait"},
+	{"turnaround", "Turnaround"},
+	{"normalized", "Normalized"},
+	{"slowdown", "Bounded slowdown"},
+	{"response", "Response"},
+	{"switches", "Switches"},
+	{"completion", "Exit"},
+}
+
+// parseColumns looks up a comma separated list of schedule table column keys; "all" selects every
+// column. It returns the indexes of the columns in scheduleColumns.
+func parseColumns(keys string) ([]int, error) {
+	if keys == "all" {
+		return nil, nil
+	}
+	columns := make([]int, 0)
+	for _, key := range strings.Split(keys, ",") {
+		key = strings.ToLower(strings.TrimSpace(key))
+		if alias, ok := sortAliases[key]; ok {
+			key = alias
+		}
+		found := false
+		for i, c := range scheduleColumns {
+			if c.key == key {
+				columns = append(columns, i)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: unknown column %q", ErrInvalidArgs, key)
+		}
+	}
+
+	return columns, nil
+}
+
+// selectColumns returns the cells of the report's columns, all of them when it selects none.
+func selectColumns(cells []string, r Report) []string {
+	if r.Columns == nil {
+		return cells
+	}
+	selected := make([]string, len(r.Columns))
+	for i, c := range r.Columns {
+		selected[i] = cells[c]
+	}
+
+	return selected
+}
+
+// scheduleHeader names the report's schedule table columns.
+func scheduleHeader(r Report) []string {
+	header := make([]string, len(scheduleColumns))
+	for i, c := range scheduleColumns {
+		header[i] = c.header
+	}
+
+	return selectColumns(header, r)
+}
+
+// rows formats the schedule table, one row per process in the report's sort order.
+func (s Schedule) rows(r Report) [][]string {
+	rows := make([][]string, len(s.Processes))
+	normalized := s.NormalizedTurnaround()
+	bounded := s.BoundedSlowdown(r.SlowdownBound)
+	response := s.Response()
+	switches := s.SwitchesPerProcess()
+	for row, i := range s.rowOrder(r) {
+		p := s.Processes[i]
+		rows[row] = []string{
+			fmt.Sprint(p.ProcessID),
+			fmt.Sprint(p.Priority),
+			s.formatTime(p.BurstDuration),
+			s.formatTime(p.ArrivalTime),
+			s.formatTime(s.Wait[i]),
+			s.formatTime(s.Turnaround[i]),
+			fmt.Sprintf("%.2f", normalized[i]),
+			fmt.Sprintf("%.2f", bounded[i]),
+			s.formatTime(response[i]),
+			fmt.Sprint(switches[i]),
+			s.formatTime(s.Completion[i]),
+		}
+		rows[row] = selectColumns(rows[row], r)
+	}
+
+	return rows
+}
+
+// footer formats the schedule table's summary under the columns it summarizes. Wait and response
+// also get their tail latencies, which tell schedulers with the same average apart.
+func (s Schedule) footer(r Report) []string {
+	return selectColumns([]string{"", "", "", "",
+		"Average\n" + s.formatUnits(s.AverageWait()) + s.tails(s.Wait),
+		"Average\n" + s.formatUnits(s.AverageTurnaround()),
+		fmt.Sprintf("Average\n%.2f", s.AverageNormalizedTurnaround()),
+		fmt.Sprintf("Average\n%.2f", average(s.BoundedSlowdown(r.SlowdownBound))),
+		"Average\n" + s.formatUnits(s.AverageResponse()) + s.tails(s.Response()),
+		fmt.Sprintf("Total\n%d", s.ContextSwitches()),
+		fmt.Sprintf("Throughput\n%.2f/t", s.Throughput())}, r)
+}
+
+// tails formats the 95th and 99th percentiles of a per-process time as footer lines.
+func (s Schedule) tails(times []int64) string {
+	sorted := toFloats(times)
+	sort.Float64s(sorted)
+
+	return "\np95=" + s.formatUnits(s.inUnits(percentile(sorted, 95))) +
+		"\np99=" + s.formatUnits(s.inUnits(percentile(sorted, 99)))
+}
+
+// summary formats the schedule-wide metrics that do not fit under a table column, one per line.
+func (s Schedule) summary() []string {
+	lines := []string{
+		fmt.Sprintf("Makespan: %s (from %s to %s)", s.formatTime(s.Makespan()), s.formatTime(s.FirstArrival()), s.formatTime(s.LastCompletion())),
+		fmt.Sprintf("CPU utilization: %.2f%% (busy %s, idle %s)", s.Utilization()*100, s.formatTime(s.BusyTime()), s.formatTime(s.IdleTime())),
+		fmt.Sprintf("Jain's fairness index: %.2f (wait), %.2f (normalized turnaround)",
+			jainIndex(toFloats(s.Wait)), jainIndex(s.NormalizedTurnaround())),
+	}
+	for _, more := range [][]string{s.classSummary(), s.taskSummary(), s.deadlineSummary(), s.energySummary()} {
+		lines = append(lines, more...)
+	}
+
+	return lines
+}
+
+// average returns the mean of values.
+func average[T int64 | float64](values []T) float64 {
+	var total float64
+	for _, v := range values {
+		total += float64(v)
+	}
+
+	return total / float64(len(values))
+}
+
+// Response returns how long each process waited from arrival until it was first dispatched.
+func (s Schedule) Response() []int64 {
+	response := make([]int64, len(s.Processes))
+	for i, p := range s.Processes {
+		response[i] = s.FirstRun[i] - p.ArrivalTime
+	}
+
+	return response
+}
+
+// NormalizedTurnaround returns each process's turnaround divided by its burst.
+func (s Schedule) NormalizedTurnaround() []float64 {
+	normalized := make([]float64, len(s.Processes))
+	for i, p := range s.Processes {
+		normalized[i] = float64(s.Turnaround[i]) / float64(p.BurstDuration)
+	}
+
+	return normalized
+}
+
+func (s Schedule) AverageNormalizedTurnaround() float64 {
+	return average(s.NormalizedTurnaround())
+}
+
+// BoundedSlowdown returns each process's slowdown with bursts shorter than bound time units counted as
+// bound, and never below 1, so very short jobs do not dominate: max(1, turnaround / max(burst, bound)).
+func (s Schedule) BoundedSlowdown(bound int64) []float64 {
+	bound *= s.ticksPerUnit()
+	slowdown := make([]float64, len(s.Processes))
+	for i, p := range s.Processes {
+		burst := p.BurstDuration
+		if burst < bound {
+			burst = bound
+		}
+		slowdown[i] = math.Max(1, float64(s.Turnaround[i])/float64(burst))
+	}
+
+	return slowdown
+}
+
+// AverageWait, AverageTurnaround and AverageResponse return averages in time units of the workload.
+
+func (s Schedule) AverageWait() float64 { return s.inUnits(average(s.Wait)) }
+
+func (s Schedule) AverageTurnaround() float64 { return s.inUnits(average(s.Turnaround)) }
+
+func (s Schedule) AverageResponse() float64 { return s.inUnits(average(s.Response())) }
+
+// LastCompletion returns the time the last process completed.
+func (s Schedule) LastCompletion() int64 {
+	var last int64
+	for _, c := range s.Completion {
+		if c > last {
+			last = c
+		}
+	}
+
+	return last
+}
+
+// Throughput returns processes completed per time unit.
+func (s Schedule) Throughput() float64 {
+	return float64(len(s.Processes)) / s.inUnits(float64(s.LastCompletion()))
+}
+
+// ContextSwitches counts the times the CPU switched from one process to a different one.
+func (s Schedule) ContextSwitches() int {
+	var switches int
+	for _, n := range s.SwitchesPerProcess() {
+		switches += n
+	}
+
+	return switches
+}
+
+// SwitchesPerProcess counts, for each process, the context switches that dispatched it.
+func (s Schedule) SwitchesPerProcess() []int {
+	if s.streamed() {
+		return append([]int(nil), s.streamedSwitches...)
+	}
+	var (
+		switches = make([]int, len(s.Processes))
+		index    = make(map[int64]int, len(s.Processes))
+	)
+	for i, p := range s.Processes {
+		index[p.ProcessID] = i
+	}
+	for i := 1; i < len(s.Gantt); i++ {
+		if s.Gantt[i].PID != s.Gantt[i-1].PID {
+			if p, ok := index[s.Gantt[i].PID]; ok {
+				switches[p]++
+			}
+		}
+	}
+
+	return switches
+}
+
+// Preemptions counts, for each process, the times it left the CPU before completing: every run of
+// its merged GANTT slices but the last.
+func (s Schedule) Preemptions() []int {
+	var (
+		preemptions = make([]int, len(s.Processes))
+		index       = make(map[int64]int, len(s.Processes))
+		seen        = make([]bool, len(s.Processes))
+	)
+	for i, p := range s.Processes {
+		index[p.ProcessID] = i
+	}
+	for _, ts := range mergeSlices(s.Gantt) {
+		if p, ok := index[ts.PID]; ok {
+			if seen[p] {
+				preemptions[p]++
+			}
+			seen[p] = true
+		}
+	}
+
+	return preemptions
+}
+
+// addIdle records that the CPU idled from start to stop, extending the last idle period if adjacent.
+func (s *Schedule) addIdle(start, stop int64) {
+	if n := len(s.Idle); n > 0 && s.Idle[n-1].Stop == start {
+		s.Idle[n-1].Stop = stop
+		return
+	}
+	s.Idle = append(s.Idle, TimeSlice{Start: start, Stop: stop})
+}
+
+// mergeSlices returns the time slices with every contiguous run of the same process coalesced into one.
+func mergeSlices(gantt []TimeSlice) []TimeSlice {
+	merged := make([]TimeSlice, 0, len(gantt))
+	for _, ts := range gantt {
+		if n := len(merged); n > 0 && merged[n-1].PID == ts.PID && merged[n-1].Stop == ts.Start {
+			merged[n-1].Stop = ts.Stop
+			continue
+		}
+		merged = append(merged, ts)
+	}
+
+	return merged
+}
+
+// merged returns the schedule with its GANTT slices coalesced by mergeSlices.
+func (s Schedule) merged() Schedule {
+	s.Gantt = mergeSlices(s.Gantt)
+
+	return s
+}
+
+// idlePID marks the idle periods merged into a timeline.
+const idlePID int64 = -1
+
+// timeline returns the schedule's time slices with its idle periods merged in as idlePID slices and
+// its context switching periods as switchPID slices.
+func (s Schedule) timeline() []TimeSlice {
+	timeline := make([]TimeSlice, 0, len(s.Gantt)+len(s.Idle)+len(s.Switching))
+	timeline = append(timeline, s.Gantt...)
+	for _, idle := range s.Idle {
+		timeline = append(timeline, TimeSlice{PID: idlePID, Start: idle.Start, Stop: idle.Stop})
+	}
+	timeline = append(timeline, s.Switching...)
+	sort.SliceStable(timeline, func(i, j int) bool {
+		return timeline[i].Start < timeline[j].Start
+	})
+
+	return timeline
+}
+
+// FirstArrival returns the time the first process arrived.
+func (s Schedule) FirstArrival() int64 {
+	if len(s.Processes) == 0 {
+		return 0
+	}
+	first := s.Processes[0].ArrivalTime
+	for _, p := range s.Processes {
+		if p.ArrivalTime < first {
+			first = p.ArrivalTime
+		}
+	}
+
+	return first
+}
+
+// Makespan returns the length of the schedule, from the first arrival until the last completion.
+func (s Schedule) Makespan() int64 {
+	return s.LastCompletion() - s.FirstArrival()
+}
+
+// IdleTime returns how long the CPU idled between the first arrival and the last completion.
+func (s Schedule) IdleTime() int64 {
+	var (
+		idle       int64
+		start, end = s.FirstArrival(), s.LastCompletion()
+	)
+	for _, slice := range s.Idle {
+		from, to := slice.Start, slice.Stop
+		if from < start {
+			from = start
+		}
+		if to > end {
+			to = end
+		}
+		if to > from {
+			idle += to - from
+		}
+	}
+
+	return idle
+}
+
+// idles reports whether the CPU idled while processes were still to arrive or run.
+func (s Schedule) idles() bool {
+	return s.IdleTime() > 0
+}
+
+// BusyTime returns how long the CPU ran processes between the first arrival and the last completion.
+func (s Schedule) BusyTime() int64 {
+	return s.Makespan() - s.IdleTime()
+}
+
+// Utilization returns the fraction of the schedule the CPU was busy.
+func (s Schedule) Utilization() float64 {
+	if s.Makespan() == 0 {
+		return 0
+	}
+	return float64(s.BusyTime()) / float64(s.Makespan())
+}
+
+// jainIndex returns Jain's fairness index (Σx)² / (n·Σx²) of values: 1 when all are equal, down to
+// 1/n when one value dominates. All-zero values are perfectly fair.
+func jainIndex(values []float64) float64 {
+	var sum, squares float64
+	for _, v := range values {
+		sum += v
+		squares += v * v
+	}
+	if squares == 0 {
+		return 1
+	}
+
+	return sum * sum / (float64(len(values)) * squares)
+}
+
+func toFloats[T int | int64](values []T) []float64 {
+	floats := make([]float64, len(values))
+	for i, v := range values {
+		floats[i] = float64(v)
+	}
+
+	return floats
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestSchedule_Response(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	}
+	tests := []struct {
+		name         string
+		schedule     Schedule
+		want         []int64
+		wantAverage  float64
+		wantSwitches int
+		wantPer      []int
+	}{
+		{
+			name:         "fcfs",
+			schedule:     fcfs(processes, Options{}),
+			want:         []int64{0, 2, 8},
+			wantAverage:  10.0 / 3,
+			wantSwitches: 2,
+			wantPer:      []int{0, 1, 1},
+		},
+		{
+			name:         "priority",
+			schedule:     preemptivePriority(processes, Options{}),
+			want:         []int64{0, 0, 8},
+			wantAverage:  8.0 / 3,
+			wantSwitches: 3,
+			wantPer:      []int{1, 1, 1},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.schedule.Response(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Response() = %v, want %v", got, tt.want)
+			}
+			if got := tt.schedule.AverageResponse(); got != tt.wantAverage {
+				t.Errorf("AverageResponse() = %v, want %v", got, tt.wantAverage)
+			}
+			if got := tt.schedule.ContextSwitches(); got != tt.wantSwitches {
+				t.Errorf("ContextSwitches() = %v, want %v", got, tt.wantSwitches)
+			}
+			if got := tt.schedule.SwitchesPerProcess(); !reflect.DeepEqual(got, tt.wantPer) {
+				t.Errorf("SwitchesPerProcess() = %v, want %v", got, tt.wantPer)
+			}
+		})
+	}
+}
+
+func TestSchedule_Utilization(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 2, BurstDuration: 3},
+		{ProcessID: 2, ArrivalTime: 8, BurstDuration: 2},
+	}
+	preemptive, _ := selectAlgorithms("sjf,priority,rr")
+	for _, a := range preemptive {
+		s := a.run(processes, Options{})
+		if got := s.IdleTime(); got != 3 {
+			t.Errorf("%s IdleTime() = %v, want 3", a.name, got)
+		}
+		if got := s.Makespan(); got != 8 {
+			t.Errorf("%s Makespan() = %v, want 8", a.name, got)
+		}
+		if got := s.BusyTime(); got != 5 {
+			t.Errorf("%s BusyTime() = %v, want 5", a.name, got)
+		}
+		if got := s.Utilization(); got != 5.0/8 {
+			t.Errorf("%s Utilization() = %v, want %v", a.name, got, 5.0/8)
+		}
+	}
+}
+
+func TestSchedule_timeline(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 2, BurstDuration: 3},
+		{ProcessID: 2, ArrivalTime: 8, BurstDuration: 2},
+	}
+	want := []TimeSlice{
+		{PID: idlePID, Start: 0, Stop: 2},
+		{PID: 1, Start: 2, Stop: 5},
+		{PID: idlePID, Start: 5, Stop: 8},
+		{PID: 2, Start: 8, Stop: 10},
+	}
+	for _, run := range []func([]Process, Options) Schedule{sjf, preemptivePriority} {
+		if got := run(processes, Options{}).timeline(); !reflect.DeepEqual(got, want) {
+			t.Errorf("timeline() = %v, want %v", got, want)
+		}
+	}
+}
+
+func Test_mergeSlices(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		gantt []TimeSlice
+		want  []TimeSlice
+	}{
+		{name: "empty", gantt: nil, want: []TimeSlice{}},
+		{
+			name:  "round-robin ticks",
+			gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 1, Start: 1, Stop: 2}, {PID: 2, Start: 2, Stop: 3}, {PID: 1, Start: 3, Stop: 4}},
+			want:  []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 3}, {PID: 1, Start: 3, Stop: 4}},
+		},
+		{
+			name:  "gap between runs",
+			gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 1, Start: 3, Stop: 4}},
+			want:  []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 1, Start: 3, Stop: 4}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := mergeSlices(tt.gantt); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mergeSlices() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchedule_NormalizedTurnaround(t *testing.T) {
+	t.Parallel()
+	s := fcfs([]Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	}, Options{})
+	want := []float64{1, 11.0 / 9, 14.0 / 6}
+	if got := s.NormalizedTurnaround(); !reflect.DeepEqual(got, want) {
+		t.Errorf("NormalizedTurnaround() = %v, want %v", got, want)
+	}
+	if got, want := s.AverageNormalizedTurnaround(), (1+11.0/9+14.0/6)/3; math.Abs(got-want) > 1e-9 {
+		t.Errorf("AverageNormalizedTurnaround() = %v, want %v", got, want)
+	}
+}
+
+func Test_jainIndex(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		values []float64
+		want   float64
+	}{
+		{name: "equal", values: []float64{3, 3, 3}, want: 1},
+		{name: "all zero", values: []float64{0, 0}, want: 1},
+		{name: "one dominates", values: []float64{0, 0, 0, 8}, want: 0.25},
+		{name: "mixed", values: []float64{1, 2, 3}, want: 36.0 / 42},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := jainIndex(tt.values); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("jainIndex() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchedule_BoundedSlowdown(t *testing.T) {
+	t.Parallel()
+	s := fcfs([]Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	}, Options{})
+	tests := []struct {
+		name  string
+		bound int64
+		want  []float64
+	}{
+		{name: "unbounded", bound: 0, want: []float64{1, 11.0 / 9, 14.0 / 6}},
+		{name: "bound 10", bound: 10, want: []float64{1, 1.1, 1.4}},
+		{name: "never below 1", bound: 20, want: []float64{1, 1, 1}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := s.BoundedSlowdown(tt.bound); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BoundedSlowdown() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseColumns(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		keys    string
+		want    []int
+		wantErr bool
+	}{
+		{keys: "all", want: nil},
+		{keys: "id,arrival,wait,turnaround", want: []int{0, 3, 4, 5}},
+		{keys: "PID, exit", want: []int{0, 10}},
+		{keys: "id,color", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.keys, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseColumns(tt.keys)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseColumns() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseColumns() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchedule_rows_columns(t *testing.T) {
+	t.Parallel()
+	s := fcfs([]Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+	}, Options{})
+	r := Report{SlowdownBound: defaultSlowdownBound, Columns: []int{0, 4, 10}}
+	if got, want := scheduleHeader(r), []string{"ID", "Wait", "Exit"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("scheduleHeader() = %v, want %v", got, want)
+	}
+	if got, want := s.rows(r), [][]string{{"1", "0", "5"}, {"2", "2", "14"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("rows() = %v, want %v", got, want)
+	}
+	if got, want := s.footer(r), []string{"", "Average\n1.00\np95=1.90\np99=1.98", "Throughput\n0.14/t"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("footer() = %q, want %q", got, want)
+	}
+}
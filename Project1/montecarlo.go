@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// monteCarlo schedules the workload runs times with each algorithm, seeding the random parts of the
+// options, the jitter, random tie-break and sporadic releases, from the options' seed onwards. It
+// returns the results of each run, resuming from and saving to the checkpoint if there is one.
+func monteCarlo(processes []Process, opts Options, algs []algorithm, runs int, cp *checkpointer) ([][]result, error) {
+	samples := make([][]result, runs)
+	seed := opts.Seed
+	for run := range samples {
+		opts.Seed = seed + int64(run)
+		var err error
+		if samples[run], err = cp.scheduleAll(run, processes, opts, algs); err != nil {
+			return nil, err
+		}
+	}
+
+	return samples, nil
+}
+
+// randomized reports whether scheduling the processes under the options depends on the seed, so
+// that Monte Carlo runs differ.
+func (opts Options) randomized(processes []Process) bool {
+	if opts.Jitter.enabled() || opts.TieBreak == TieBreakRandom {
+		return true
+	}
+	for _, p := range processes {
+		if p.Sporadic {
+			return true
+		}
+	}
+
+	return false
+}
+
+// tCritical are the two-sided 95% critical values of Student's t distribution by degrees of freedom,
+// from 1; beyond them the normal distribution's 1.96 is close enough.
+var tCritical = []float64{
+	12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365, 2.306, 2.262, 2.228,
+	2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110, 2.101, 2.093, 2.086,
+	2.080, 2.074, 2.069, 2.064, 2.060, 2.056, 2.052, 2.048, 2.045, 2.042,
+}
+
+// confidenceInterval returns the mean of the sampled values and the half-width of its 95% confidence
+// interval, from the sample standard deviation and Student's t distribution. A single sample has no
+// interval.
+func confidenceInterval(values []float64) (mean, half float64) {
+	n := len(values)
+	if n == 0 {
+		return 0, 0
+	}
+	mean = average(values)
+	if n == 1 {
+		return mean, 0
+	}
+	var squares float64
+	for _, v := range values {
+		squares += (v - mean) * (v - mean)
+	}
+	t := 1.96
+	if n-1 <= len(tCritical) {
+		t = tCritical[n-2]
+	}
+
+	return mean, t * math.Sqrt(squares/float64(n-1)) / math.Sqrt(float64(n))
+}
+
+// monteCarloHeader names the Monte Carlo summary table columns.
+var monteCarloHeader = []string{"Algorithm", "Metric", "Mean", "95% CI", "Min", "Max"}
+
+// outputMonteCarlo writes the mean of each compared metric over the runs, with its 95% confidence
+// interval and range, one row per algorithm and metric.
+func outputMonteCarlo(w io.Writer, samples [][]result, r Report) {
+	outputTitle(w, fmt.Sprintf("Monte Carlo over %d runs", len(samples)))
+	var (
+		first = samples[0]
+		rows  = make([][]string, 0)
+	)
+	for i := range first {
+		for _, m := range resultMetrics(first) {
+			values := make([]float64, len(samples))
+			for run, results := range samples {
+				values[run] = m.value(results[i].schedule)
+			}
+			mean, half := confidenceInterval(values)
+			d := describe(values)
+			rows = append(rows, []string{
+				first[i].title,
+				m.header,
+				fmt.Sprintf(m.format, mean),
+				"± " + fmt.Sprintf(m.format, half),
+				fmt.Sprintf(m.format, d.Min),
+				fmt.Sprintf(m.format, d.Max),
+			})
+		}
+	}
+	alignment := []int{tablewriter.ALIGN_LEFT, tablewriter.ALIGN_LEFT}
+	for range monteCarloHeader[2:] {
+		alignment = append(alignment, tablewriter.ALIGN_RIGHT)
+	}
+	outputTable(w, r.TableStyle, monteCarloHeader, rows, nil, alignment)
+}
+
+// validateRuns checks the -runs flag of a command writing a report r.
+func validateRuns(runs int, r Report) error {
+	switch {
+	case runs < 1:
+		return fmt.Errorf("%w: runs must be positive", ErrInvalidArgs)
+	case runs > 1 && r.Format != "text":
+		return fmt.Errorf("%w: -runs only summarizes to the text format", ErrInvalidArgs)
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_confidenceInterval(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		values   []float64
+		wantMean float64
+		wantHalf float64
+	}{
+		{name: "empty", values: nil},
+		{name: "single", values: []float64{4}, wantMean: 4},
+		{name: "constant", values: []float64{2, 2, 2}, wantMean: 2},
+		{name: "pair", values: []float64{1, 3}, wantMean: 2, wantHalf: 12.706},
+		{name: "five", values: []float64{1, 2, 3, 4, 5}, wantMean: 3, wantHalf: 2.776 * math.Sqrt(2.5) / math.Sqrt(5)},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mean, half := confidenceInterval(tt.values)
+			if math.Abs(mean-tt.wantMean) > 1e-9 || math.Abs(half-tt.wantHalf) > 1e-9 {
+				t.Errorf("confidenceInterval() = %v ± %v, want %v ± %v", mean, half, tt.wantMean, tt.wantHalf)
+			}
+		})
+	}
+}
+
+func Test_monteCarlo(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: 1, BurstDuration: 10}, {ProcessID: 2, ArrivalTime: 2, BurstDuration: 10}}
+	opts := Options{Seed: 5, Jitter: Jitter{Burst: jitterAmount{fraction: 0.5}}}
+	samples, err := monteCarlo(processes, opts, algorithms[:1], 8, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(samples) != 8 || len(samples[0]) != 1 {
+		t.Fatalf("monteCarlo() = %d runs of %d results, want 8 of 1", len(samples), len(samples[0]))
+	}
+	differ := false
+	for _, results := range samples[1:] {
+		differ = differ || results[0].schedule.Makespan() != samples[0][0].schedule.Makespan()
+	}
+	if !differ {
+		t.Errorf("monteCarlo() runs all have makespan %d, want them seeded apart", samples[0][0].schedule.Makespan())
+	}
+	again, _ := monteCarlo(processes, opts, algorithms[:1], 8, nil)
+	for run := range samples {
+		if samples[run][0].schedule.Makespan() != again[run][0].schedule.Makespan() {
+			t.Errorf("run %d differs for the same seed", run)
+		}
+	}
+}
+
+func Test_compareCommand_runs(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "workload.csv")
+	if err := os.WriteFile(path, []byte("1,5,0,2\n2,9,3,1\n3,6,6,3\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr error
+	}{
+		{name: "runs", args: []string{"-algorithms", "fcfs,rr", "-runs", "4", "-jitter", "burst=±2", path}, want: "Monte Carlo over 4 runs"},
+		{name: "zero runs", args: []string{"-runs", "0", path}, wantErr: ErrInvalidArgs},
+		{name: "not text", args: []string{"-runs", "4", "-format", "json", path}, wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			if err := compareCommand(&w, tt.args); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("compareCommand() error = %v, want %v", err, tt.wantErr)
+			}
+			if !strings.Contains(w.String(), tt.want) {
+				t.Errorf("compareCommand() = %s, want it to contain %q", w.String(), tt.want)
+			}
+		})
+	}
+}
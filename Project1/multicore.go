@@ -0,0 +1,326 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Placement names how a process is placed when several CPUs of different speeds are free.
+type Placement string
+
+const (
+	PlacementPerformance Placement = "performance" // the fastest free CPU, for the least latency
+	PlacementEfficiency  Placement = "efficiency"  // the slowest free CPU, keeping the fast ones for load
+)
+
+// placements are the placement policies, the default first.
+var placements = []Placement{PlacementPerformance, PlacementEfficiency}
+
+// parsePlacement validates a placement policy name.
+func parsePlacement(name string) (Placement, error) {
+	for _, p := range placements {
+		if Placement(name) == p {
+			return p, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: unknown placement %q", ErrInvalidArgs, name)
+}
+
+func placementNames() []string {
+	names := make([]string, len(placements))
+	for i, p := range placements {
+		names[i] = string(p)
+	}
+
+	return names
+}
+
+// parseSpeeds parses a comma separated list of positive CPU speed factors, one per CPU; a burst takes
+// burst ÷ speed on a CPU.
+func parseSpeeds(list string) ([]float64, error) {
+	speeds := make([]float64, 0)
+	for _, field := range strings.Split(list, ",") {
+		v, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil || v <= 0 || math.IsInf(v, 0) {
+			return nil, fmt.Errorf("%w: CPU speed %q must be a positive number", ErrInvalidArgs, field)
+		}
+		speeds = append(speeds, v)
+	}
+
+	return speeds, nil
+}
+
+// multicorePicks choose the next process to start among the ready ones, given by index in input
+// order. They are selectable with multicore -algorithm.
+var multicorePicks = map[string]func(processes []Process, ready []int) int{
+	// fcfs starts the earliest arrival.
+	"fcfs": func(processes []Process, ready []int) int {
+		best := ready[0]
+		for _, i := range ready {
+			if processes[i].ArrivalTime < processes[best].ArrivalTime {
+				best = i
+			}
+		}
+		return best
+	},
+	// sjf starts the shortest burst.
+	"sjf": func(processes []Process, ready []int) int {
+		best := ready[0]
+		for _, i := range ready {
+			if processes[i].BurstDuration < processes[best].BurstDuration {
+				best = i
+			}
+		}
+		return best
+	},
+	// priority starts the most important priority, the lowest number once ranked.
+	"priority": func(processes []Process, ready []int) int {
+		best := ready[0]
+		for _, i := range ready {
+			if processes[i].Priority < processes[best].Priority {
+				best = i
+			}
+		}
+		return best
+	},
+}
+
+func multicorePickNames() []string {
+	names := make([]string, 0, len(multicorePicks))
+	for name := range multicorePicks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// multicoreRun is the outcome of scheduling processes on several CPUs. The per-process slices are
+// index-aligned with the processes and the per-CPU ones with the CPU speeds.
+type multicoreRun struct {
+	cpu        []int
+	start      []int64
+	completion []int64
+	gantt      [][]TimeSlice
+	busy       []int64
+}
+
+// makespan returns the time from the first arrival to the last completion.
+func (run multicoreRun) makespan(processes []Process) int64 {
+	first, last := processes[0].ArrivalTime, int64(0)
+	for i, p := range processes {
+		if p.ArrivalTime < first {
+			first = p.ArrivalTime
+		}
+		if run.completion[i] > last {
+			last = run.completion[i]
+		}
+	}
+
+	return last - first
+}
+
+// simulateMulticore runs the processes without preemption on CPUs of the given speeds from one global
+// ready queue. Whenever a CPU is free and a process ready, pick chooses the process to start and the
+// placement policy the free CPU it starts on. On a CPU of speed v a burst takes burst ÷ v, rounded up
+// to a whole tick.
+func simulateMulticore(processes []Process, speeds []float64, pick func([]Process, []int) int, policy Placement) multicoreRun {
+	var (
+		run = multicoreRun{
+			cpu:        make([]int, len(processes)),
+			start:      make([]int64, len(processes)),
+			completion: make([]int64, len(processes)),
+			gantt:      make([][]TimeSlice, len(speeds)),
+			busy:       make([]int64, len(speeds)),
+		}
+		started = make([]bool, len(processes))
+		freeAt  = make([]int64, len(speeds))
+		ready   = newReadiness(processes, run.completion, ClassPolicyShared)
+		order   = make([]int, len(speeds)) // CPUs in placement order
+	)
+	for c := range order {
+		order[c] = c
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		if policy == PlacementEfficiency {
+			return speeds[order[a]] < speeds[order[b]]
+		}
+		return speeds[order[a]] > speeds[order[b]]
+	})
+
+	for t, remaining := int64(0), len(processes); remaining > 0; t++ {
+		for _, c := range order {
+			if freeAt[c] > t {
+				continue
+			}
+			candidates := make([]int, 0)
+			for i := range processes {
+				if !started[i] && ready.ready(i, t) {
+					candidates = append(candidates, i)
+				}
+			}
+			if len(candidates) == 0 {
+				break
+			}
+			i := pick(processes, candidates)
+			length := int64(math.Ceil(float64(processes[i].BurstDuration) / speeds[c]))
+			started[i] = true
+			remaining--
+			run.cpu[i], run.start[i], run.completion[i] = c, t, t+length
+			run.gantt[c] = append(run.gantt[c], TimeSlice{PID: processes[i].ProcessID, Start: t, Stop: t + length})
+			run.busy[c] += length
+			freeAt[c] = t + length
+		}
+	}
+
+	return run
+}
+
+// cpuTimeline returns the slices a CPU ran with its idle periods from start to stop as idlePID slices.
+func cpuTimeline(gantt []TimeSlice, start, stop int64) []TimeSlice {
+	timeline := make([]TimeSlice, 0, 2*len(gantt)+1)
+	for _, ts := range gantt {
+		if ts.Start > start {
+			timeline = append(timeline, TimeSlice{PID: idlePID, Start: start, Stop: ts.Start})
+		}
+		timeline = append(timeline, ts)
+		start = ts.Stop
+	}
+	if stop > start {
+		timeline = append(timeline, TimeSlice{PID: idlePID, Start: start, Stop: stop})
+	}
+
+	return timeline
+}
+
+var (
+	multicoreHeader = []string{"ID", "CPU", "Burst", "Arrival", "Start", "Exit", "Wait", "Turnaround"}
+	cpuHeader       = []string{"CPU", "Speed", "Processes", "Busy", "Utilization"}
+)
+
+// outputMulticore outputs a GANTT chart per CPU, the schedule of every process, the utilization of
+// every CPU and the averages.
+func outputMulticore(w io.Writer, title string, processes []Process, speeds []float64, run multicoreRun, base timeBase, style string) {
+	outputTitle(w, title)
+	var (
+		first = processes[0].ArrivalTime
+		last  int64
+	)
+	for i, p := range processes {
+		if p.ArrivalTime < first {
+			first = p.ArrivalTime
+		}
+		if run.completion[i] > last {
+			last = run.completion[i]
+		}
+	}
+	for c, speed := range speeds {
+		_, _ = fmt.Fprintf(w, "CPU %d (speed %g)\n", c, speed)
+		outputGanttChart(w, cpuTimeline(run.gantt[c], first, last), base, Report{})
+		_, _ = fmt.Fprintln(w)
+	}
+
+	var (
+		rows       = make([][]string, len(processes))
+		wait       = make([]int64, len(processes))
+		turnaround = make([]int64, len(processes))
+	)
+	for i, p := range processes {
+		wait[i] = run.start[i] - p.ArrivalTime
+		turnaround[i] = run.completion[i] - p.ArrivalTime
+		rows[i] = []string{
+			fmt.Sprint(p.ProcessID),
+			fmt.Sprint(run.cpu[i]),
+			formatTicks(p.BurstDuration, base),
+			formatTicks(p.ArrivalTime, base),
+			formatTicks(run.start[i], base),
+			formatTicks(run.completion[i], base),
+			formatTicks(wait[i], base),
+			formatTicks(turnaround[i], base),
+		}
+	}
+	outputTable(w, style, multicoreHeader, rows, nil, nil)
+
+	makespan := run.makespan(processes)
+	rows = make([][]string, len(speeds))
+	for c, speed := range speeds {
+		utilization := 0.0
+		if makespan > 0 {
+			utilization = float64(run.busy[c]) / float64(makespan)
+		}
+		rows[c] = []string{
+			fmt.Sprint(c),
+			fmt.Sprintf("%g", speed),
+			fmt.Sprint(len(run.gantt[c])),
+			formatTicks(run.busy[c], base),
+			fmt.Sprintf("%.2f%%", utilization*100),
+		}
+	}
+	outputTable(w, style, cpuHeader, rows, nil, nil)
+	_, _ = fmt.Fprintf(w, "Makespan: %s\n", formatTicks(makespan, base))
+	_, _ = fmt.Fprintf(w, "Average wait %s, turnaround %s\n\n",
+		base.formatUnits(average(wait)/float64(base.ticksPerUnit())),
+		base.formatUnits(average(turnaround)/float64(base.ticksPerUnit())))
+}
+
+// multicoreCommand schedules a workload file on several CPUs of possibly different speeds.
+func multicoreCommand(w io.Writer, args []string) error {
+	fs := newFlagSet("multicore")
+	name := fs.String("algorithm", "fcfs", "order of the global ready queue: "+strings.Join(multicorePickNames(), ", "))
+	speedList := fs.String("speeds", "1,1", `comma separated speed factor of each CPU, e.g. "2,2,1,1" for two big and two LITTLE cores`)
+	placementName := fs.String("placement", string(PlacementPerformance), "CPU a process starts on when several are free: "+strings.Join(placementNames(), ", ")+"-first")
+	tableStyle := fs.String("table-style", "ascii", "text table style: "+strings.Join(tableStyleNames(), ", "))
+	ganttCSV := fs.String("gantt-csv", "", "write every GANTT slice to this CSV file, as algorithm,pid,start,stop,cpu")
+	priorityOrder := addPriorityOrderFlag(fs)
+	times := addTimeFlags(fs)
+	logging := addLogFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := logging(); err != nil {
+		return err
+	}
+	pick, ok := multicorePicks[*name]
+	if !ok {
+		return fmt.Errorf("%w: unknown algorithm %q (want %s)", ErrInvalidArgs, *name, strings.Join(multicorePickNames(), ", "))
+	}
+	speeds, err := parseSpeeds(*speedList)
+	if err != nil {
+		return err
+	}
+	policy, err := parsePlacement(*placementName)
+	if err != nil {
+		return err
+	}
+	order, err := priorityOrder()
+	if err != nil {
+		return err
+	}
+	base, err := times()
+	if err != nil {
+		return err
+	}
+	if _, ok := tableStyles[*tableStyle]; !ok && *tableStyle != tsvStyle {
+		return fmt.Errorf("%w: unknown table style %q", ErrInvalidArgs, *tableStyle)
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: must give a scheduling file", ErrInvalidArgs)
+	}
+	processes, err := loadWorkload(fs.Arg(0), base)
+	if err != nil {
+		return err
+	}
+	run := simulateMulticore(order.ranked(processes), speeds, pick, policy)
+	title := fmt.Sprintf("Multi-core (%s, %s-first)", *name, policy)
+	outputMulticore(w, title, processes, speeds, run, base, *tableStyle)
+	if *ganttCSV != "" {
+		return writeFile(*ganttCSV, func(w io.Writer) error { return writeMulticoreGanttCSV(w, title, run, base) })
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_simulateMulticore(t *testing.T) {
+	t.Parallel()
+	const workload = "1,8,0,1\n2,4,0,2\n3,2,1,3\n4,6,2,1"
+	tests := []struct {
+		name           string
+		algorithm      string
+		placement      Placement
+		wantCPU        []int
+		wantCompletion []int64
+		wantBusy       []int64
+		wantMakespan   int64
+	}{
+		{name: "performance", algorithm: "fcfs", placement: PlacementPerformance,
+			wantCPU: []int{0, 1, 2, 2}, wantCompletion: []int64{4, 4, 3, 9}, wantBusy: []int64{4, 4, 8}, wantMakespan: 9},
+		{name: "efficiency", algorithm: "fcfs", placement: PlacementEfficiency,
+			wantCPU: []int{1, 2, 0, 0}, wantCompletion: []int64{8, 4, 2, 5}, wantBusy: []int64{4, 8, 4}, wantMakespan: 8},
+		{name: "sjf", algorithm: "sjf", placement: PlacementPerformance,
+			wantCPU: []int{1, 0, 2, 0}, wantCompletion: []int64{8, 2, 3, 5}, wantBusy: []int64{5, 8, 2}, wantMakespan: 8},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			processes, err := loadProcesses(strings.NewReader(workload))
+			if err != nil {
+				t.Fatal(err)
+			}
+			run := simulateMulticore(processes, []float64{2, 1, 1}, multicorePicks[tt.algorithm], tt.placement)
+			if !reflect.DeepEqual(run.cpu, tt.wantCPU) {
+				t.Errorf("CPUs = %v, want %v", run.cpu, tt.wantCPU)
+			}
+			if !reflect.DeepEqual(run.completion, tt.wantCompletion) {
+				t.Errorf("completion = %v, want %v", run.completion, tt.wantCompletion)
+			}
+			if !reflect.DeepEqual(run.busy, tt.wantBusy) {
+				t.Errorf("busy = %v, want %v", run.busy, tt.wantBusy)
+			}
+			if got := run.makespan(processes); got != tt.wantMakespan {
+				t.Errorf("makespan = %d, want %d", got, tt.wantMakespan)
+			}
+		})
+	}
+}
+
+func Test_parseSpeeds(t *testing.T) {
+	t.Parallel()
+	if got, err := parseSpeeds("2, 2,1,0.5"); err != nil || !reflect.DeepEqual(got, []float64{2, 2, 1, 0.5}) {
+		t.Errorf("parseSpeeds() = %v, %v", got, err)
+	}
+	for _, list := range []string{"", "1,0", "-1", "fast", "1,Inf"} {
+		if _, err := parseSpeeds(list); err == nil {
+			t.Errorf("parseSpeeds(%q) succeeded, want an error", list)
+		}
+	}
+}
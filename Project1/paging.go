@@ -0,0 +1,337 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// pagingPolicy picks the frame whose page a page-replacement algorithm evicts. It is told of every
+// hit and every page loaded, at reference index t.
+type pagingPolicy interface {
+	hit(frame, t int)
+	load(frame, t int)
+	victim(frames []int64, t int) int
+}
+
+// pagingAlgorithm is a page-replacement algorithm selectable with -algorithms.
+type pagingAlgorithm struct {
+	name   string
+	title  string
+	policy func(refs []int64, frames int) pagingPolicy
+}
+
+var pagingAlgorithms = []pagingAlgorithm{
+	{name: "fifo", title: "FIFO", policy: func(_ []int64, frames int) pagingPolicy { return &fifoPolicy{loaded: make([]int, frames)} }},
+	{name: "lru", title: "Least recently used", policy: func(_ []int64, frames int) pagingPolicy { return &lruPolicy{used: make([]int, frames)} }},
+	{name: "clock", title: "Clock (second chance)", policy: func(_ []int64, frames int) pagingPolicy { return &clockPolicy{referenced: make([]bool, frames)} }},
+	{name: "optimal", title: "Optimal", policy: func(refs []int64, _ int) pagingPolicy { return &optimalPolicy{refs: refs} }},
+}
+
+// fifoPolicy evicts the page loaded the longest ago.
+type fifoPolicy struct{ loaded []int }
+
+func (p *fifoPolicy) hit(int, int)            {}
+func (p *fifoPolicy) load(frame, t int)       { p.loaded[frame] = t }
+func (p *fifoPolicy) victim([]int64, int) int { return oldest(p.loaded) }
+
+// lruPolicy evicts the page used the longest ago.
+type lruPolicy struct{ used []int }
+
+func (p *lruPolicy) hit(frame, t int)        { p.used[frame] = t }
+func (p *lruPolicy) load(frame, t int)       { p.used[frame] = t }
+func (p *lruPolicy) victim([]int64, int) int { return oldest(p.used) }
+
+// oldest returns the index of the smallest time.
+func oldest(times []int) int {
+	victim := 0
+	for i := range times {
+		if times[i] < times[victim] {
+			victim = i
+		}
+	}
+
+	return victim
+}
+
+// clockPolicy sweeps a hand over the frames, giving each page whose reference bit is set a second
+// chance by clearing the bit, and evicts the first page found without it. Loading a page sets its bit.
+type clockPolicy struct {
+	referenced []bool
+	hand       int
+}
+
+func (p *clockPolicy) hit(frame, _ int) { p.referenced[frame] = true }
+
+func (p *clockPolicy) load(frame, _ int) {
+	p.referenced[frame] = true
+	p.hand = (frame + 1) % len(p.referenced)
+}
+
+func (p *clockPolicy) victim([]int64, int) int {
+	for p.referenced[p.hand] {
+		p.referenced[p.hand] = false
+		p.hand = (p.hand + 1) % len(p.referenced)
+	}
+
+	return p.hand
+}
+
+// optimalPolicy evicts the page whose next use is furthest away, or never comes.
+type optimalPolicy struct{ refs []int64 }
+
+func (p *optimalPolicy) hit(int, int)  {}
+func (p *optimalPolicy) load(int, int) {}
+
+func (p *optimalPolicy) victim(frames []int64, t int) int {
+	victim, furthest := 0, -1
+	for i, page := range frames {
+		next := len(p.refs)
+		for j := t + 1; j < len(p.refs); j++ {
+			if p.refs[j] == page {
+				next = j
+				break
+			}
+		}
+		if next > furthest {
+			victim, furthest = i, next
+		}
+	}
+
+	return victim
+}
+
+// noPage marks an empty frame, or that no page was evicted.
+const noPage = -1
+
+// pagingStep is the state of the frames after one reference.
+type pagingStep struct {
+	Ref     int64
+	Frames  []int64 // the page in each frame, noPage when empty
+	Fault   bool
+	Evicted int64
+}
+
+// simulatePaging runs a reference string through a number of frames under a policy. Pages fill the
+// empty frames in order before any is evicted, and stay in their frame until evicted.
+func simulatePaging(refs []int64, frames int, policy pagingPolicy) []pagingStep {
+	var (
+		state = make([]int64, frames)
+		steps = make([]pagingStep, len(refs))
+		used  = 0
+	)
+	for i := range state {
+		state[i] = noPage
+	}
+	for t, page := range refs {
+		step := pagingStep{Ref: page, Evicted: noPage}
+		frame := -1
+		for i := range state {
+			if state[i] == page {
+				frame = i
+			}
+		}
+		switch {
+		case frame >= 0:
+			policy.hit(frame, t)
+		case used < frames:
+			step.Fault = true
+			state[used] = page
+			policy.load(used, t)
+			used++
+		default:
+			step.Fault = true
+			frame = policy.victim(state, t)
+			step.Evicted = state[frame]
+			state[frame] = page
+			policy.load(frame, t)
+		}
+		step.Frames = append([]int64(nil), state...)
+		steps[t] = step
+	}
+
+	return steps
+}
+
+// pageFaults counts the references that faulted.
+func pageFaults(steps []pagingStep) int {
+	faults := 0
+	for _, s := range steps {
+		if s.Fault {
+			faults++
+		}
+	}
+
+	return faults
+}
+
+// loadReferenceString reads page numbers separated by commas or white space.
+func loadReferenceString(r io.Reader) ([]int64, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading reference string", err)
+	}
+	fields := strings.FieldsFunc(string(data), func(c rune) bool {
+		return c == ',' || c == ' ' || c == '\t' || c == '\n' || c == '\r'
+	})
+	refs := make([]int64, len(fields))
+	for i, field := range fields {
+		page, err := strconv.ParseInt(field, 10, 64)
+		if err != nil || page < 0 {
+			return nil, fmt.Errorf("%w: reference %d: %q is not a page number", ErrInvalidArgs, i+1, field)
+		}
+		refs[i] = page
+	}
+
+	return refs, nil
+}
+
+// outputPagingResult outputs the frames after every reference of one algorithm and its fault count.
+func outputPagingResult(w io.Writer, title string, steps []pagingStep, frames int, style string) {
+	outputTitle(w, title)
+	header := []string{"Time", "Ref"}
+	for i := 1; i <= frames; i++ {
+		header = append(header, fmt.Sprintf("Frame %d", i))
+	}
+	header = append(header, "Fault", "Evicted")
+	rows := make([][]string, len(steps))
+	for t, s := range steps {
+		row := []string{fmt.Sprint(t), fmt.Sprint(s.Ref)}
+		for _, page := range s.Frames {
+			cell := ""
+			if page != noPage {
+				cell = fmt.Sprint(page)
+			}
+			row = append(row, cell)
+		}
+		fault, evicted := "", ""
+		if s.Fault {
+			fault = "F"
+		}
+		if s.Evicted != noPage {
+			evicted = fmt.Sprint(s.Evicted)
+		}
+		rows[t] = append(row, fault, evicted)
+	}
+	outputTable(w, style, header, rows, nil, nil)
+
+	faults := pageFaults(steps)
+	_, _ = fmt.Fprintf(w, "Page faults: %d, hits: %d, hit ratio: %.2f%%\n\n",
+		faults, len(steps)-faults, 100*float64(len(steps)-faults)/float64(len(steps)))
+}
+
+// outputPagingComparison outputs a table of each algorithm's faults, marking the fewest.
+func outputPagingComparison(w io.Writer, titles []string, faults []int, refs int, style string) {
+	_, _ = fmt.Fprintln(w, "Comparison")
+	best := faults[0]
+	for _, f := range faults {
+		if f < best {
+			best = f
+		}
+	}
+	rows := make([][]string, len(titles))
+	for i := range titles {
+		mark := ""
+		if faults[i] == best {
+			mark = " *"
+		}
+		rows[i] = []string{
+			titles[i],
+			fmt.Sprint(faults[i]) + mark,
+			fmt.Sprint(refs - faults[i]),
+			fmt.Sprintf("%.2f%%", 100*float64(refs-faults[i])/float64(refs)),
+		}
+	}
+	alignment := []int{tablewriter.ALIGN_LEFT, tablewriter.ALIGN_RIGHT, tablewriter.ALIGN_RIGHT, tablewriter.ALIGN_RIGHT}
+	outputTable(w, style, []string{"Algorithm", "Page faults", "Hits", "Hit ratio"}, rows, nil, alignment)
+	_, _ = fmt.Fprintln(w, "* fewest page faults")
+}
+
+// selectPagingAlgorithms looks up a comma separated list of page-replacement algorithm names; "all"
+// selects every one.
+func selectPagingAlgorithms(names string) ([]pagingAlgorithm, error) {
+	if names == "all" {
+		return pagingAlgorithms, nil
+	}
+	selected := make([]pagingAlgorithm, 0)
+	for _, name := range strings.Split(names, ",") {
+		found := false
+		for _, a := range pagingAlgorithms {
+			if a.name == strings.TrimSpace(name) {
+				selected = append(selected, a)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: unknown page-replacement algorithm %q", ErrInvalidArgs, name)
+		}
+	}
+
+	return selected, nil
+}
+
+// pagingCommand simulates page replacement of a reference string, given with -refs or in a file.
+func pagingCommand(w io.Writer, args []string) error {
+	fs := newFlagSet("paging")
+	names := fs.String("algorithms", "all", "comma separated page-replacement algorithms: fifo, lru, clock, optimal")
+	frames := fs.Int("frames", 3, "number of page frames")
+	refsFlag := fs.String("refs", "", "reference string, e.g. \"7,0,1,2,0,3\", instead of a file")
+	tableStyle := fs.String("table-style", "ascii", "text table style: "+strings.Join(tableStyleNames(), ", "))
+	logging := addLogFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := logging(); err != nil {
+		return err
+	}
+	if *frames <= 0 {
+		return fmt.Errorf("%w: frames must be positive", ErrInvalidArgs)
+	}
+	if _, ok := tableStyles[*tableStyle]; !ok && *tableStyle != tsvStyle {
+		return fmt.Errorf("%w: unknown table style %q", ErrInvalidArgs, *tableStyle)
+	}
+	selected, err := selectPagingAlgorithms(*names)
+	if err != nil {
+		return err
+	}
+
+	var refs []int64
+	switch {
+	case *refsFlag != "" && fs.NArg() == 0:
+		refs, err = loadReferenceString(strings.NewReader(*refsFlag))
+	case *refsFlag == "" && fs.NArg() == 1:
+		f, openErr := os.Open(fs.Arg(0))
+		if openErr != nil {
+			return fmt.Errorf("%v: error opening reference string file", openErr)
+		}
+		defer f.Close()
+		refs, err = loadReferenceString(f)
+	default:
+		return fmt.Errorf("%w: must give a reference string with -refs or a file", ErrInvalidArgs)
+	}
+	if err != nil {
+		return err
+	}
+	if len(refs) == 0 {
+		return fmt.Errorf("%w: empty reference string", ErrValidation)
+	}
+
+	var (
+		titles = make([]string, len(selected))
+		faults = make([]int, len(selected))
+	)
+	for i, a := range selected {
+		steps := simulatePaging(refs, *frames, a.policy(refs, *frames))
+		outputPagingResult(w, a.title, steps, *frames, *tableStyle)
+		titles[i], faults[i] = a.title, pageFaults(steps)
+	}
+	if len(selected) > 1 {
+		outputPagingComparison(w, titles, faults, len(refs), *tableStyle)
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_pagingAlgorithms(t *testing.T) {
+	t.Parallel()
+	// The reference string of the textbook example.
+	refs := []int64{7, 0, 1, 2, 0, 3, 0, 4, 2, 3, 0, 3, 2, 1, 2, 0, 1, 7, 0, 1}
+	tests := []struct {
+		name       string
+		frames     int
+		wantFaults int
+	}{
+		{name: "fifo", frames: 3, wantFaults: 15},
+		{name: "lru", frames: 3, wantFaults: 12},
+		{name: "clock", frames: 3, wantFaults: 14},
+		{name: "optimal", frames: 3, wantFaults: 9},
+		{name: "fifo", frames: 4, wantFaults: 10},
+		{name: "optimal", frames: 4, wantFaults: 8},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			selected, err := selectPagingAlgorithms(tt.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			steps := simulatePaging(refs, tt.frames, selected[0].policy(refs, tt.frames))
+			if got := pageFaults(steps); got != tt.wantFaults {
+				t.Errorf("pageFaults() = %d, want %d", got, tt.wantFaults)
+			}
+		})
+	}
+}
+
+func Test_simulatePaging_frames(t *testing.T) {
+	t.Parallel()
+	refs := []int64{1, 2, 3, 1, 4}
+	steps := simulatePaging(refs, 3, &lruPolicy{used: make([]int, 3)})
+	want := []pagingStep{
+		{Ref: 1, Frames: []int64{1, noPage, noPage}, Fault: true, Evicted: noPage},
+		{Ref: 2, Frames: []int64{1, 2, noPage}, Fault: true, Evicted: noPage},
+		{Ref: 3, Frames: []int64{1, 2, 3}, Fault: true, Evicted: noPage},
+		{Ref: 1, Frames: []int64{1, 2, 3}, Evicted: noPage},
+		{Ref: 4, Frames: []int64{1, 4, 3}, Fault: true, Evicted: 2},
+	}
+	if !reflect.DeepEqual(steps, want) {
+		t.Errorf("simulatePaging() = %v, want %v", steps, want)
+	}
+}
+
+func Test_loadReferenceString(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		input   string
+		want    []int64
+		wantErr error
+	}{
+		{name: "commas", input: "7,0,1", want: []int64{7, 0, 1}},
+		{name: "white space", input: "7 0\n1\t2\n", want: []int64{7, 0, 1, 2}},
+		{name: "mixed", input: "7, 0,\n1", want: []int64{7, 0, 1}},
+		{name: "negative", input: "7,-1", wantErr: ErrInvalidArgs},
+		{name: "not a number", input: "7,x", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := loadReferenceString(strings.NewReader(tt.input))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadReferenceString() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_pagingCommand(t *testing.T) {
+	t.Parallel()
+	refs := "7,0,1,2,0,3,0,4,2,3,0,3,2,1,2,0,1,7,0,1"
+	path := filepath.Join(t.TempDir(), "refs.txt")
+	if err := os.WriteFile(path, []byte(refs+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		args    []string
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "compare",
+			args: []string{"-refs", refs, "-algorithms", "fifo,optimal"},
+			want: []string{"Page faults: 15, hits: 5, hit ratio: 25.00%", "Page faults: 9, hits: 11", "| Optimal   |         9 * |"},
+		},
+		{name: "file", args: []string{"-algorithms", "lru", path}, want: []string{"Page faults: 12"}},
+		{name: "no references", args: nil, wantErr: ErrInvalidArgs},
+		{name: "refs and file", args: []string{"-refs", refs, path}, wantErr: ErrInvalidArgs},
+		{name: "empty", args: []string{"-refs", ","}, wantErr: ErrValidation},
+		{name: "no frames", args: []string{"-frames", "0", "-refs", refs}, wantErr: ErrInvalidArgs},
+		{name: "bad algorithm", args: []string{"-algorithms", "random", "-refs", refs}, wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			err := pagingCommand(&w, tt.args)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(w.String(), want) {
+					t.Errorf("pagingCommand() = %s, want it to contain %q", w.String(), want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// algorithmParam is a parameter of one algorithm, set into the options with -opt algorithm.key=value.
+type algorithmParam struct {
+	usage string
+	set   func(opts *Options, value string, base timeBase) error
+}
+
+// algorithmParams are the parameters of each algorithm, by algorithm name and key. Give a new
+// algorithm's parameters here rather than flags of their own.
+var algorithmParams = map[string]map[string]algorithmParam{
+	"rr": {
+		"quantum": {usage: "time quantum of processes without a quantum: column, like -quantum", set: func(opts *Options, value string, base timeBase) error {
+			q, err := parseTime(value, base)
+			if err != nil || q <= 0 {
+				return fmt.Errorf("must be a positive time")
+			}
+			opts.Quantum = q
+			return nil
+		}},
+		"queue": {usage: "ready queue, " + strings.Join(rrQueueNames(), " or ") + ", like -rr-queue", set: func(opts *Options, value string, _ timeBase) error {
+			q, err := parseRRQueue(value)
+			opts.RRQueue = q
+			return err
+		}},
+	},
+	"feedback": {
+		"queues": {usage: fmt.Sprintf("number of queues, 1 to %d", feedbackMaxLevel+1), set: func(opts *Options, value string, _ timeBase) error {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 || n > feedbackMaxLevel+1 {
+				return fmt.Errorf("must be 1 to %d", feedbackMaxLevel+1)
+			}
+			opts.Feedback.Queues = n
+			return nil
+		}},
+		"quantum": {usage: "quantum of the highest queue, doubling in each lower one", set: func(opts *Options, value string, base timeBase) error {
+			q, err := parseTime(value, base)
+			if err != nil || q <= 0 || q > math.MaxInt64>>feedbackMaxLevel {
+				return fmt.Errorf("must be a positive time")
+			}
+			opts.Feedback.Quantum = q
+			return nil
+		}},
+	},
+}
+
+// algorithmParamNames returns the "algorithm.key" names of every parameter, sorted.
+func algorithmParamNames() []string {
+	names := make([]string, 0)
+	for name, params := range algorithmParams {
+		for key := range params {
+			names = append(names, name+"."+key)
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// paramFlag collects the values of a repeatable -opt flag.
+type paramFlag []string
+
+func (f *paramFlag) String() string {
+	return strings.Join(*f, " ")
+}
+
+func (f *paramFlag) Set(value string) error {
+	*f = append(*f, value)
+
+	return nil
+}
+
+// setParams sets each "algorithm.key=value" parameter into the options, in order, so a later one
+// overrides an earlier one and the flag the parameter stands for.
+func setParams(opts *Options, params []string, base timeBase) error {
+	for _, param := range params {
+		name, value, ok := strings.Cut(param, "=")
+		algorithm, key, dotted := strings.Cut(name, ".")
+		if !ok || !dotted {
+			return fmt.Errorf("%w: option %q must be algorithm.key=value", ErrInvalidArgs, param)
+		}
+		p, ok := algorithmParams[algorithm][key]
+		if !ok {
+			return fmt.Errorf("%w: unknown option %q (want %s)", ErrInvalidArgs, name, strings.Join(algorithmParamNames(), ", "))
+		}
+		if err := p.set(opts, strings.TrimSpace(value), base); err != nil {
+			return fmt.Errorf("%w: option %s=%s: %v", ErrInvalidArgs, name, value, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_setParams(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		params  []string
+		want    Options
+		wantErr bool
+	}{
+		{name: "none", want: Options{Quantum: 1}},
+		{name: "rr", params: []string{"rr.quantum=4", "rr.queue=fifo"}, want: Options{Quantum: 4, RRQueue: RRQueueFIFO}},
+		{name: "later wins", params: []string{"rr.quantum=4", "rr.quantum=2"}, want: Options{Quantum: 2}},
+		{name: "feedback", params: []string{"feedback.queues=3", "feedback.quantum=2"}, want: Options{Quantum: 1, Feedback: FeedbackConfig{Queues: 3, Quantum: 2}}},
+		{name: "unknown algorithm", params: []string{"mlfq.queues=3"}, wantErr: true},
+		{name: "unknown key", params: []string{"sjf.alpha=0.5"}, wantErr: true},
+		{name: "no value", params: []string{"rr.quantum"}, wantErr: true},
+		{name: "no algorithm", params: []string{"quantum=4"}, wantErr: true},
+		{name: "bad quantum", params: []string{"rr.quantum=0"}, wantErr: true},
+		{name: "bad queue", params: []string{"rr.queue=lifo"}, wantErr: true},
+		{name: "too many queues", params: []string{"feedback.queues=32"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := Options{Quantum: 1}
+			err := setParams(&got, tt.params, timeBase{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("setParams() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !errors.Is(err, ErrInvalidArgs) {
+					t.Errorf("setParams() error = %v, want %v", err, ErrInvalidArgs)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("setParams() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_feedback_config(t *testing.T) {
+	t.Parallel()
+	processes, err := loadProcesses(strings.NewReader("1,5,0,2\n2,9,3,1\n3,6,6,3\n4,2,7,1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		config FeedbackConfig
+		want   Schedule
+	}{
+		{name: "one queue is round robin", config: FeedbackConfig{Queues: 1}, want: roundRobinFIFO(processes, Options{Quantum: 1})},
+		{name: "long quantum is first-come, first-serve", config: FeedbackConfig{Quantum: 100}, want: fcfs(processes, Options{})},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := feedback(processes, Options{Feedback: tt.config})
+			if !reflect.DeepEqual(got.Completion, tt.want.Completion) {
+				t.Errorf("Completion = %v, want %v", got.Completion, tt.want.Completion)
+			}
+		})
+	}
+}
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+var (
+	// perfEvent matches the "[<cpu>] <timestamp>: sched:<event>: <fields>" part of a `perf script` line.
+	perfEvent = regexp.MustCompile(`\s(?:\[(\d+)\]\s+)?(\d+\.\d+):\s+(?:\d+\s+)?sched:(\w+):\s*(.*)$`)
+	perfField = regexp.MustCompile(`(\w+)=(\S+)`)
+	// perfPluginSwitch and perfPluginWakeup match the fields of the events as the libtraceevent sched
+	// plugin prints them: "<comm>:<pid> [<prio>] <state> ==> <comm>:<pid> [<prio>]" and
+	// "<comm>:<pid> [<prio>] ...".
+	perfPluginSwitch = regexp.MustCompile(`^.*:(\d+) \[(-?\d+)\] \S+ ==> .*:(\d+) \[(-?\d+)\]`)
+	perfPluginWakeup = regexp.MustCompile(`^.*?:(\d+) \[(-?\d+)\]`)
+)
+
+type perfTask struct {
+	pid      int64
+	arrival  int64
+	priority int64
+	burst    int64
+}
+
+type perfRun struct {
+	pid   int64
+	since int64
+}
+
+// perfFields returns the fields of a sched event by name, whether perf printed them raw as
+// "<name>=<value>" pairs or through the libtraceevent sched plugin, whose fields are named as the raw
+// ones would be.
+func perfFields(event, text string) map[string]string {
+	fields := make(map[string]string)
+	for _, kv := range perfField.FindAllStringSubmatch(text, -1) {
+		fields[kv[1]] = kv[2]
+	}
+	switch event {
+	case "sched_switch":
+		if _, ok := fields["next_pid"]; ok {
+			break
+		}
+		if m := perfPluginSwitch.FindStringSubmatch(text); m != nil {
+			fields["prev_pid"], fields["prev_prio"], fields["next_pid"], fields["next_prio"] = m[1], m[2], m[3], m[4]
+		}
+	case "sched_wakeup", "sched_wakeup_new", "sched_waking":
+		if _, ok := fields["pid"]; ok {
+			break
+		}
+		if m := perfPluginWakeup.FindStringSubmatch(text); m != nil {
+			fields["pid"], fields["prio"] = m[1], m[2]
+		}
+	}
+
+	return fields
+}
+
+// loadPerfSched converts `perf script` output of a `perf sched record` session given:
+// • a reader of the perf script text
+// • the number of microseconds that make up one scheduler time unit
+// into the processes seen and the time slices they actually ran in. Arrival is the first time a task
+// is woken or switched, burst is its total on-CPU time and priority is the kernel priority.
+// The idle task (PID 0) is ignored.
+func loadPerfSched(r io.Reader, unit int64) ([]Process, []TimeSlice, error) {
+	if unit <= 0 {
+		return nil, nil, fmt.Errorf("%w: time unit must be positive", ErrInvalidArgs)
+	}
+
+	var (
+		base    = -1.0
+		last    int64
+		tasks   = make(map[int64]*perfTask)
+		running = make(map[string]perfRun)
+		gantt   = make([]TimeSlice, 0)
+	)
+	tick := func(seconds float64) int64 {
+		if base < 0 {
+			base = seconds
+		}
+		return int64(math.Round((seconds - base) * 1e6 / float64(unit)))
+	}
+	task := func(pid, prio, now int64) {
+		t, ok := tasks[pid]
+		if !ok {
+			t = &perfTask{pid: pid, arrival: now}
+			tasks[pid] = t
+		}
+		if prio >= 0 {
+			t.priority = prio
+		}
+	}
+	stop := func(cpu string, now int64) {
+		run, ok := running[cpu]
+		if !ok {
+			return
+		}
+		delete(running, cpu)
+		if now > run.since {
+			gantt = append(gantt, TimeSlice{PID: run.pid, Start: run.since, Stop: now})
+			tasks[run.pid].burst += now - run.since
+		}
+	}
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		m := perfEvent.FindStringSubmatch(scanner.Text())
+		if m == nil {
+			continue
+		}
+		seconds, err := strconv.ParseFloat(m[2], 64)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: line %d: timestamp", err, line)
+		}
+		now := tick(seconds)
+		last = now
+
+		fields := perfFields(m[3], m[4])
+		num := func(key string) int64 {
+			n, err := strconv.ParseInt(fields[key], 10, 64)
+			if err != nil {
+				return -1
+			}
+			return n
+		}
+
+		switch m[3] {
+		case "sched_wakeup", "sched_wakeup_new", "sched_waking":
+			if pid := num("pid"); pid > 0 {
+				task(pid, num("prio"), now)
+			}
+		case "sched_switch":
+			cpu := m[1]
+			if pid := num("prev_pid"); pid > 0 {
+				task(pid, num("prev_prio"), now)
+			}
+			stop(cpu, now)
+			if pid := num("next_pid"); pid > 0 {
+				task(pid, num("next_prio"), now)
+				running[cpu] = perfRun{pid: pid, since: now}
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("%w: reading perf script", err)
+	}
+	for cpu := range running {
+		stop(cpu, last)
+	}
+
+	processes := make([]Process, 0, len(tasks))
+	for _, t := range tasks {
+		if t.burst > 0 {
+			processes = append(processes, Process{
+				ProcessID:     t.pid,
+				ArrivalTime:   t.arrival,
+				BurstDuration: t.burst,
+				Priority:      t.priority,
+			})
+		}
+	}
+	sort.Slice(processes, func(i, j int) bool {
+		if processes[i].ArrivalTime != processes[j].ArrivalTime {
+			return processes[i].ArrivalTime < processes[j].ArrivalTime
+		}
+		return processes[i].ProcessID < processes[j].ProcessID
+	})
+	sort.SliceStable(gantt, func(i, j int) bool {
+		return gantt[i].Start < gantt[j].Start
+	})
+
+	return processes, gantt, nil
+}
+
+// ReplaySchedule outputs an already executed schedule in a GANTT chart and a table of timing given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+// • the time slices the processes ran in
+func ReplaySchedule(w io.Writer, title string, processes []Process, gantt []TimeSlice) {
+	outputResult(w, title, replay(processes, gantt), Report{SlowdownBound: defaultSlowdownBound})
+}
+
+// replay derives every process's timing from the time slices it actually ran in.
+func replay(processes []Process, gantt []TimeSlice) Schedule {
+	var (
+		s       = newSchedule(processes)
+		index   = make(map[int64]int, len(processes))
+		started = make([]bool, len(processes))
+	)
+	for i := range processes {
+		index[processes[i].ProcessID] = i
+	}
+	s.Gantt = gantt
+	for _, slice := range gantt {
+		i, ok := index[slice.PID]
+		if !ok {
+			continue
+		}
+		if !started[i] || slice.Start < s.FirstRun[i] {
+			s.FirstRun[i] = slice.Start
+			started[i] = true
+		}
+		if slice.Stop > s.Completion[i] {
+			s.Completion[i] = slice.Stop
+		}
+	}
+	var busyUntil int64
+	for _, slice := range gantt {
+		if slice.Start > busyUntil {
+			s.addIdle(busyUntil, slice.Start)
+		}
+		if slice.Stop > busyUntil {
+			busyUntil = slice.Stop
+		}
+	}
+	for i := range processes {
+		s.Turnaround[i] = s.Completion[i] - processes[i].ArrivalTime
+		s.Wait[i] = s.Turnaround[i] - processes[i].BurstDuration
+	}
+
+	return s
+}
+
+// perfCommand replays a `perf script` trace and compares it against the simulated schedulers,
+// or with -workload writes the derived workload CSV to w instead.
+func perfCommand(w io.Writer, args []string) error {
+	fs := newFlagSet("import-perf")
+	unit := fs.Int64("unit", 1000, "trace microseconds per time unit")
+	workload := fs.Bool("workload", false, "only write the derived workload CSV")
+	options := addOptionFlags(fs)
+	report := addReportFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	opts, err := options()
+	if err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: must give a perf script file to import", ErrInvalidArgs)
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("%v: error opening perf script file", err)
+	}
+	defer f.Close()
+
+	processes, gantt, err := loadPerfSched(f, *unit)
+	if err != nil {
+		return err
+	}
+	if len(processes) == 0 {
+		return fmt.Errorf("%w: no sched_switch events found", ErrInvalidArgs)
+	}
+	if *workload {
+		return writeProcesses(w, processes)
+	}
+
+	r, err := report()
+	if err != nil {
+		return err
+	}
+	if err := r.parseStarvation(opts.timeBase()); err != nil {
+		return err
+	}
+	observed := result{title: "Observed (perf sched)", schedule: replay(processes, gantt)}
+
+	return outputResults(w, append([]result{observed}, scheduleAll(processes, opts, algorithms)...), r)
+}
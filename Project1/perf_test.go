@@ -0,0 +1,92 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_loadPerfSched(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		r    io.Reader
+		unit int64
+	}
+	tests := []struct {
+		name      string
+		args      args
+		want      []Process
+		wantGantt []TimeSlice
+		wantErr   error
+	}{
+		{
+			name: "bad unit",
+			args: args{
+				r: strings.NewReader(""),
+			},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name: "raw",
+			args: args{
+				r: strings.NewReader(`
+            bash   100 [000]  10.000000:       sched:sched_wakeup_new: comm=bash pid=200 prio=120 target_cpu=000
+         swapper     0 [000]  10.001000:       sched:sched_switch: prev_comm=swapper/0 prev_pid=0 prev_prio=120 prev_state=R ==> next_comm=make next_pid=200 next_prio=120
+            make   200 [000]  10.004000:       sched:sched_switch: prev_comm=make prev_pid=200 prev_prio=120 prev_state=R ==> next_comm=cc next_pid=300 next_prio=110
+              cc   300 [000]  10.006000:       sched:sched_switch: prev_comm=cc prev_pid=300 prev_prio=110 prev_state=S ==> next_comm=make next_pid=200 next_prio=120
+            make   200 [000]  10.009000:       sched:sched_switch: prev_comm=make prev_pid=200 prev_prio=120 prev_state=S ==> next_comm=swapper/0 next_pid=0 next_prio=120
+`),
+				unit: 1000,
+			},
+			want: []Process{
+				{ProcessID: 200, ArrivalTime: 0, BurstDuration: 6, Priority: 120},
+				{ProcessID: 300, ArrivalTime: 4, BurstDuration: 2, Priority: 110},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 200, Start: 1, Stop: 4},
+				{PID: 300, Start: 4, Stop: 6},
+				{PID: 200, Start: 6, Stop: 9},
+			},
+		},
+		{
+			name: "sched plugin",
+			args: args{
+				r: strings.NewReader(`
+            bash   100 [000]  10.000000:       sched:sched_wakeup_new: make:200 [120] CPU:000
+         swapper     0 [000]  10.001000:       sched:sched_switch: swapper/0:0 [120] R ==> make:200 [120]
+            make   200 [000]  10.004000:       sched:sched_switch: make:200 [120] R ==> cc:300 [110]
+              cc   300 [000]  10.006000:       sched:sched_switch: cc:300 [110] S ==> make:200 [120]
+            make   200 [000]  10.009000:       sched:sched_switch: make:200 [120] S ==> swapper/0:0 [120]
+`),
+				unit: 1000,
+			},
+			want: []Process{
+				{ProcessID: 200, ArrivalTime: 0, BurstDuration: 6, Priority: 120},
+				{ProcessID: 300, ArrivalTime: 4, BurstDuration: 2, Priority: 110},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 200, Start: 1, Stop: 4},
+				{PID: 300, Start: 4, Stop: 6},
+				{PID: 200, Start: 6, Stop: 9},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, gotGantt, err := loadPerfSched(tt.args.r, tt.args.unit)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadPerfSched() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(gotGantt, tt.wantGantt) {
+				t.Errorf("loadPerfSched() gantt = %v, want %v", gotGantt, tt.wantGantt)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
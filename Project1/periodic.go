@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// sporadicPrefix starts the optional sporadic column of a workload row, "sporadic:10". A sporadic task
+// is a periodic task whose period is only the least time between its job releases.
+const sporadicPrefix = "sporadic:"
+
+// maxJobs caps the jobs the periodic tasks of a workload release, as maxTemplateProcesses caps the
+// processes of a workload, so a short workload cannot make the simulator run out of memory.
+const maxJobs = 1_000_000
+
+// maxHorizon caps the hyperperiod periodic tasks release jobs over by default, in ticks.
+const maxHorizon = 1_000_000
+
+// periodicHorizon returns the time up to which periodic tasks release jobs by default: one hyperperiod,
+// the least common multiple of the periods, after the last task's first release, so the schedule
+// covers every combination of releases once. If that passes maxHorizon it returns false and the end
+// of the first period of the task whose first period ends last, so every task releases one job.
+func periodicHorizon(processes []Process) (int64, bool) {
+	var (
+		tasks        = periodicTasks(processes)
+		phase, first int64
+	)
+	for _, p := range tasks {
+		if p.ArrivalTime > phase {
+			phase = p.ArrivalTime
+		}
+		if end := p.ArrivalTime + p.Period; end > first {
+			first = end
+		}
+	}
+	if h, ok := hyperperiod(tasks, maxHorizon); ok {
+		return phase + h, true
+	}
+
+	return first, false
+}
+
+// releaseHorizon returns the time up to which the periodic tasks of the processes release jobs: the
+// options' horizon, or else the periodicHorizon, false if that falls back to the first periods.
+func (opts Options) releaseHorizon(processes []Process) (int64, bool) {
+	if opts.Horizon > 0 || len(periodicTasks(processes)) == 0 {
+		return opts.Horizon, true
+	}
+
+	return periodicHorizon(processes)
+}
+
+// validateJobs checks that the workload, with its periodic tasks released as jobs up to the options'
+// horizon, holds at most maxJobs processes. It counts a release every period, which sporadic tasks
+// release no more often than, so releaseJobs cannot exceed it.
+func validateJobs(processes []Process, opts Options) error {
+	horizon, _ := opts.releaseHorizon(processes)
+	jobs := int64(len(processes))
+	for _, p := range periodicTasks(processes) {
+		if horizon > p.ArrivalTime {
+			jobs += (horizon - p.ArrivalTime - 1) / p.Period // the releases after the first
+		}
+		if jobs > maxJobs {
+			return fmt.Errorf("%w: periodic tasks release more than %d jobs before %d; lower the horizon",
+				ErrInvalidArgs, maxJobs, horizon)
+		}
+	}
+
+	return nil
+}
+
+// releaseJobs returns the workload with every periodic task replaced by the jobs it releases, one
+// each period from its arrival until the horizon, ordered by release time. A sporadic task waits its
+// period plus an exponentially distributed extra time of the same mean between releases, drawn from a
+// seed so the same workload always releases the same jobs. The first job of a task keeps its ID; later
+// jobs are numbered on from the largest ID in the workload, in release order. Releasing more than
+// maxJobs jobs is an ErrInvalidArgs error, and releasing stops with ctx's error once ctx is done.
+func releaseJobs(ctx context.Context, processes []Process, horizon, seed int64) ([]Process, error) {
+	if len(periodicTasks(processes)) == 0 {
+		return processes, nil
+	}
+
+	var (
+		jobs = make([]Process, 0, len(processes))
+		rng  = rand.New(rand.NewSource(seed))
+		next int64
+	)
+	gap := func(p Process) int64 {
+		if !p.Sporadic {
+			return p.Period
+		}
+		return p.Period + int64(math.Round(rng.ExpFloat64()*float64(p.Period)))
+	}
+	for _, p := range processes {
+		if p.ProcessID > next {
+			next = p.ProcessID
+		}
+		if p.Period == 0 || p.Job > 0 {
+			jobs = append(jobs, p)
+			continue
+		}
+		for job, release := 1, p.ArrivalTime; job == 1 || release < horizon; job, release = job+1, release+gap(p) {
+			if len(jobs) == maxJobs {
+				return nil, fmt.Errorf("%w: periodic tasks release more than %d jobs before %d; lower the horizon",
+					ErrInvalidArgs, maxJobs, horizon)
+			}
+			if len(jobs)%4096 == 0 && ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			j := p
+			j.ArrivalTime, j.Task, j.Job = release, p.ProcessID, job
+			jobs = append(jobs, j)
+		}
+	}
+	sort.SliceStable(jobs, func(i, j int) bool { return jobs[i].ArrivalTime < jobs[j].ArrivalTime })
+	for i := range jobs {
+		jobs[i].input = i // the workload does not list the jobs, so their input order is their release order
+		if jobs[i].Job > 1 {
+			next++
+			jobs[i].ProcessID = next
+		}
+	}
+
+	return jobs, nil
+}
+
+// taskSummary formats the statistics of every periodic task over the jobs it released, one line per
+// task in order of first release, or none when the schedule has no periodic tasks. A task's worst
+// turnaround is its observed worst-case response time.
+func (s Schedule) taskSummary() []string {
+	var (
+		tasks    = make([]int64, 0)
+		jobs     = make(map[int64][]int)
+		response = s.Response()
+	)
+	for i, p := range s.Processes {
+		if p.Job == 0 {
+			continue
+		}
+		if _, ok := jobs[p.Task]; !ok {
+			tasks = append(tasks, p.Task)
+		}
+		jobs[p.Task] = append(jobs[p.Task], i)
+	}
+
+	lines := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		var wait, turnaround, resp []int64
+		for _, i := range jobs[task] {
+			wait = append(wait, s.Wait[i])
+			turnaround = append(turnaround, s.Turnaround[i])
+			resp = append(resp, response[i])
+		}
+		lines = append(lines, fmt.Sprintf("Task %d: %d jobs, average wait %s, turnaround %s (worst %s), response %s (worst %s)",
+			task, len(wait), s.formatUnits(s.inUnits(average(wait))),
+			s.formatUnits(s.inUnits(average(turnaround))), s.formatTime(maximum(turnaround)),
+			s.formatUnits(s.inUnits(average(resp))), s.formatTime(maximum(resp))))
+	}
+
+	return lines
+}
+
+// maximum returns the largest of values, or 0 if there are none.
+func maximum(values []int64) int64 {
+	var largest int64
+	for _, v := range values {
+		if v > largest {
+			largest = v
+		}
+	}
+
+	return largest
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// horizon returns the default periodic horizon of a workload.
+func horizon(processes []Process) int64 {
+	h, _ := periodicHorizon(processes)
+
+	return h
+}
+
+// released returns the jobs the processes release up to horizon, failing the test on an error.
+func released(t *testing.T, processes []Process, horizon, seed int64) []Process {
+	t.Helper()
+	jobs, err := releaseJobs(context.Background(), processes, horizon, seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return jobs
+}
+
+func Test_releaseJobs(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		workload    string
+		wantIDs     []int64
+		wantArrival []int64
+		wantTask    []int64
+	}{
+		{name: "one-off", workload: "1,5,0,2\n2,3,1,1", wantIDs: []int64{1, 2}, wantArrival: []int64{0, 1}, wantTask: []int64{0, 0}},
+		{
+			name:        "periodic",
+			workload:    "1,1,0,1,period:4\n2,2,0,2,period:5\n3,3,0,3,period:10",
+			wantIDs:     []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11},
+			wantArrival: []int64{0, 0, 0, 4, 5, 8, 10, 10, 12, 15, 16},
+			wantTask:    []int64{1, 2, 3, 1, 2, 1, 2, 3, 1, 2, 1},
+		},
+		{
+			name:        "phased with one-off",
+			workload:    "1,1,2,1,period:3\n7,2,3,2\n4,1,0,4,period:6",
+			wantIDs:     []int64{4, 1, 7, 8, 9},
+			wantArrival: []int64{0, 2, 3, 5, 6},
+			wantTask:    []int64{4, 1, 0, 1, 4},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			processes, err := loadProcesses(strings.NewReader(tt.workload))
+			if err != nil {
+				t.Fatal(err)
+			}
+			jobs := released(t, processes, horizon(processes), 1)
+			var ids, arrival, task []int64
+			for _, p := range jobs {
+				ids = append(ids, p.ProcessID)
+				arrival = append(arrival, p.ArrivalTime)
+				task = append(task, p.Task)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("IDs = %v, want %v", ids, tt.wantIDs)
+			}
+			if !reflect.DeepEqual(arrival, tt.wantArrival) {
+				t.Errorf("arrivals = %v, want %v", arrival, tt.wantArrival)
+			}
+			if !reflect.DeepEqual(task, tt.wantTask) {
+				t.Errorf("tasks = %v, want %v", task, tt.wantTask)
+			}
+			if again := released(t, jobs, horizon(jobs), 1); !reflect.DeepEqual(again, jobs) {
+				t.Errorf("releaseJobs() of jobs = %v, want them unchanged", again)
+			}
+		})
+	}
+}
+
+func Test_periodicHorizon(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		workload string
+		want     int64
+		wantOK   bool
+	}{
+		{name: "hyperperiod", workload: "1,1,0,1,period:4\n2,2,0,2,period:6", want: 12, wantOK: true},
+		{name: "phased", workload: "1,1,3,1,period:4\n2,2,0,2,period:6", want: 15, wantOK: true},
+		{name: "sporadic", workload: "1,1,0,1,sporadic:4\n2,2,0,2,period:10", want: 20, wantOK: true},
+		{name: "too long", workload: "1,1,0,1,period:997\n2,2,5,2,period:991\n3,1,0,3,period:983", want: 997, wantOK: false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			processes, err := loadProcesses(strings.NewReader(tt.workload))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, ok := periodicHorizon(processes); got != tt.want || ok != tt.wantOK {
+				t.Errorf("periodicHorizon() = %d, %v, want %d, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestSchedule_taskSummary(t *testing.T) {
+	t.Parallel()
+	processes, err := loadProcesses(strings.NewReader("1,1,0,1,period:4\n2,2,0,2,period:5\n3,3,0,3,period:10"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := algorithms[1].schedule(processes, Options{Horizon: 10})
+	want := []string{
+		"Task 1: 3 jobs, average wait 0.33, turnaround 1.33 (worst 2), response 0.33 (worst 1)",
+		"Task 2: 2 jobs, average wait 1.50, turnaround 3.50 (worst 4), response 1.50 (worst 2)",
+		"Task 3: 1 jobs, average wait 4.00, turnaround 7.00 (worst 7), response 3.00 (worst 3)",
+	}
+	if got := s.taskSummary(); !reflect.DeepEqual(got, want) {
+		t.Errorf("taskSummary() = %q, want %q", got, want)
+	}
+	if got := sjf(processes, Options{}).taskSummary(); len(got) != 0 {
+		t.Errorf("taskSummary() = %q without released jobs, want none", got)
+	}
+}
+
+func Test_releaseJobs_limit(t *testing.T) {
+	t.Parallel()
+	processes, err := loadProcesses(strings.NewReader("1,1,0,1,period:1\n2,1,0,1,period:2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := releaseJobs(context.Background(), processes, maxJobs, 1); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("error = %v, want %v", err, ErrInvalidArgs)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := releaseJobs(ctx, processes, 10, 1); !errors.Is(err, context.Canceled) {
+		t.Errorf("error of a cancelled release = %v, want %v", err, context.Canceled)
+	}
+}
+
+func Test_validateJobs(t *testing.T) {
+	t.Parallel()
+	// Ten period:1 tasks over the hyperperiod of a period:999983 one release about ten million jobs.
+	var csv strings.Builder
+	for i := 1; i <= 10; i++ {
+		fmt.Fprintf(&csv, "%d,1,0,1,period:1\n", i)
+	}
+	csv.WriteString("11,1,0,1,period:999983\n")
+	processes, err := loadProcesses(strings.NewReader(csv.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		opts    Options
+		wantErr error
+	}{
+		{name: "hyperperiod", wantErr: ErrInvalidArgs},
+		{name: "horizon", opts: Options{Horizon: maxJobs}, wantErr: ErrInvalidArgs},
+		{name: "short horizon", opts: Options{Horizon: 1000}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := validateJobs(processes, tt.opts); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// pluginProcess is a ready process as a plugin sees it. Times are in ticks: time units, unless the
+// workload has a -resolution.
+type pluginProcess struct {
+	ID        int64  `json:"id"`
+	Arrival   int64  `json:"arrival"`
+	Burst     int64  `json:"burst"`
+	Remaining int64  `json:"remaining"`
+	Priority  int64  `json:"priority"`
+	Deadline  int64  `json:"deadline,omitempty"` // relative to the arrival
+	Class     string `json:"class,omitempty"`
+}
+
+// pluginQuery asks a plugin which of the ready processes runs next at a time. Running is the process
+// that ran until then, if it has time left.
+type pluginQuery struct {
+	Time    int64           `json:"time"`
+	Running *int64          `json:"running"`
+	Ready   []pluginProcess `json:"ready"`
+}
+
+// pluginReply is a plugin's answer: the process to run, and for how long at most. Without a slice
+// the process runs until a process arrives or it completes, when the plugin is asked again.
+type pluginReply struct {
+	Run   int64 `json:"run"`
+	Slice int64 `json:"slice"`
+}
+
+// plugin is a scheduler implemented by an external program, in any language. The simulator writes a
+// pluginQuery as a line of JSON to the program's standard input whenever a process arrives or
+// completes, or the slice of the last reply runs out, and reads a pluginReply line from its standard
+// output. The program is started for every schedule and its input is closed once all processes
+// complete.
+type plugin struct {
+	command []string
+	mu      sync.Mutex // guards err, as a sweep runs the program for several schedules at once
+	err     error      // the first error of running the program
+}
+
+// newPlugin returns the plugin running a command line, split at spaces, or nil for an empty one.
+func newPlugin(command string) *plugin {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	return &plugin{command: fields}
+}
+
+// algorithm returns the plugin as an algorithm to schedule with.
+func (p *plugin) algorithm() algorithm {
+	return algorithm{name: "plugin", title: "Plugin " + strings.Join(p.command, " "), run: p.schedule}
+}
+
+// failed returns the first error of running the plugin, nil for a nil plugin.
+func (p *plugin) failed() error {
+	if p == nil {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("%v: plugin %s", p.err, strings.Join(p.command, " "))
+}
+
+// schedule runs the plugin program to schedule the processes. On an error it keeps the first and
+// returns the schedule as far as it got.
+func (p *plugin) schedule(processes []Process, opts Options) Schedule {
+	cmd := exec.Command(p.command[0], p.command[1:]...)
+	cmd.Stderr = os.Stderr
+	in, err := cmd.StdinPipe()
+	if err != nil {
+		p.fail(err)
+		return newSchedule(processes)
+	}
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		p.fail(err)
+		return newSchedule(processes)
+	}
+	if err := cmd.Start(); err != nil {
+		p.fail(err)
+		return newSchedule(processes)
+	}
+	s, err := drivePlugin(processes, opts, in, out)
+	p.fail(err)
+	_ = in.Close()
+	p.fail(cmd.Wait())
+
+	return s
+}
+
+func (p *plugin) fail(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err == nil {
+		p.err = err
+	}
+}
+
+// drivePlugin simulates the processes, asking the plugin talking over in and out which ready process
+// runs next.
+func drivePlugin(processes []Process, opts Options, in io.Writer, out io.Reader) (Schedule, error) {
+	var (
+		encoder = json.NewEncoder(in)
+		decoder = json.NewDecoder(out)
+	)
+
+	return simulateDecisions(processes, opts, func(query pluginQuery) (pluginReply, error) {
+		var reply pluginReply
+		if err := encoder.Encode(query); err != nil {
+			return reply, err
+		}
+		if err := decoder.Decode(&reply); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return reply, fmt.Errorf("reading the reply at %d: %w", query.Time, err)
+		}
+		return reply, nil
+	})
+}
+
+// simulateDecisions simulates the processes, asking decide which ready process runs next whenever a
+// process arrives or completes, or the slice of the last decision runs out. The CPU idles, without
+// asking, while no process is ready.
+func simulateDecisions(processes []Process, opts Options, decide func(query pluginQuery) (pluginReply, error)) (Schedule, error) {
+	var (
+		serviceTime int64
+		remTime     = make([]int64, len(processes))
+		s           = newSchedule(processes)
+		ready       = newReadiness(processes, s.Completion, opts.ClassPolicy)
+	)
+	completed := 0
+	count := len(processes)
+	last := -1 // the process that ran last, while it has time left
+
+	for i := range processes {
+		remTime[i] = processes[i].BurstDuration
+	}
+
+	for completed != count && !opts.cancelled() {
+		query := pluginQuery{Time: serviceTime, Ready: make([]pluginProcess, 0)}
+		for i, p := range processes {
+			if remTime[i] > 0 && ready.ready(i, serviceTime) {
+				query.Ready = append(query.Ready, pluginProcess{
+					ID:        p.ProcessID,
+					Arrival:   p.ArrivalTime,
+					Burst:     p.BurstDuration,
+					Remaining: remTime[i],
+					Priority:  p.Priority,
+					Deadline:  p.Deadline,
+					Class:     p.Class,
+				})
+			}
+		}
+		if len(query.Ready) == 0 {
+			idle := ready.idleUntil(serviceTime)
+			s.addIdle(serviceTime, idle)
+			serviceTime = idle
+			last = -1
+			continue
+		}
+		if last >= 0 {
+			query.Running = &processes[last].ProcessID
+		}
+
+		reply, err := decide(query)
+		if err != nil {
+			return s, err
+		}
+		current := -1
+		for i, p := range processes {
+			if p.ProcessID == reply.Run && remTime[i] > 0 && ready.ready(i, serviceTime) {
+				current = i
+			}
+		}
+		if current < 0 {
+			return s, fmt.Errorf("process %d to run at %d is not ready", reply.Run, serviceTime)
+		}
+
+		run := remTime[current]
+		if reply.Slice > 0 && reply.Slice < run {
+			run = reply.Slice
+		}
+		if next := ready.nextRelease(serviceTime); next-serviceTime < run {
+			run = next - serviceTime
+		}
+		if remTime[current] == processes[current].BurstDuration {
+			s.FirstRun[current] = serviceTime
+		}
+		s.Gantt = opts.gantt.append(s.Gantt, TimeSlice{
+			PID:   processes[current].ProcessID,
+			Start: serviceTime,
+			Stop:  serviceTime + run,
+		})
+		serviceTime += run
+		remTime[current] -= run
+		last = current
+
+		if remTime[current] == 0 {
+			completed++
+			opts.progress.complete()
+			last = -1
+			s.Completion[current] = serviceTime
+			s.Turnaround[current] = serviceTime - processes[current].ArrivalTime
+			s.Wait[current] = s.Turnaround[current] - processes[current].BurstDuration
+		}
+	}
+
+	return s, nil
+}
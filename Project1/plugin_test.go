@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// shortestRemaining answers plugin queries over the pipes like an external SRTF scheduler would, until
+// its input is closed.
+func shortestRemaining(in io.Reader, out io.WriteCloser) {
+	defer out.Close()
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		var query pluginQuery
+		if err := json.Unmarshal(scanner.Bytes(), &query); err != nil {
+			return
+		}
+		best := query.Ready[0]
+		for _, p := range query.Ready[1:] {
+			if p.Remaining < best.Remaining {
+				best = p
+			}
+		}
+		data, _ := json.Marshal(pluginReply{Run: best.ID})
+		_, _ = out.Write(append(data, '\n'))
+	}
+}
+
+func Test_drivePlugin(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		workload string
+	}{
+		{name: "example", workload: "1,5,0,2\n2,9,3,1\n3,6,6,3"},
+		{name: "idle gap", workload: "1,2,0,1\n2,3,10,1\n3,1,11,1"},
+		{name: "preemption", workload: "1,10,0,1\n2,2,1,1\n3,1,2,1"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			processes, err := loadProcesses(strings.NewReader(tt.workload))
+			if err != nil {
+				t.Fatal(err)
+			}
+			queries, queriesW := io.Pipe()
+			replies, repliesW := io.Pipe()
+			go shortestRemaining(queries, repliesW)
+			got, err := drivePlugin(processes, Options{}, queriesW, replies)
+			_ = queriesW.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := sjf(processes, Options{})
+			if !reflect.DeepEqual(got.Completion, want.Completion) {
+				t.Errorf("Completion = %v, want %v like SJF", got.Completion, want.Completion)
+			}
+			if !reflect.DeepEqual(got.Wait, want.Wait) {
+				t.Errorf("Wait = %v, want %v like SJF", got.Wait, want.Wait)
+			}
+			if !reflect.DeepEqual(got.Idle, want.Idle) {
+				t.Errorf("Idle = %v, want %v like SJF", got.Idle, want.Idle)
+			}
+		})
+	}
+}
+
+func Test_drivePlugin_errors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		replies string
+		wantErr string
+	}{
+		{name: "not ready", replies: `{"run": 2}` + "\n", wantErr: "process 2 to run at 0 is not ready"},
+		{name: "no reply", replies: "", wantErr: "unexpected EOF"},
+		{name: "not JSON", replies: "P1\n", wantErr: "reading the reply at 0"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			processes, err := loadProcesses(strings.NewReader("1,2,0,1\n2,3,5,1"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = drivePlugin(processes, Options{}, io.Discard, strings.NewReader(tt.replies))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("drivePlugin() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_plugin(t *testing.T) {
+	t.Parallel()
+	if newPlugin("  ") != nil {
+		t.Error("newPlugin() of an empty command is not nil")
+	}
+	if err := (*plugin)(nil).failed(); err != nil {
+		t.Errorf("failed() of a nil plugin = %v, want nil", err)
+	}
+	processes, err := loadProcesses(strings.NewReader("1,2,0,1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := newPlugin("./no-such-scheduler")
+	p.algorithm().schedule(processes, Options{})
+	if err := p.failed(); err == nil || !strings.Contains(err.Error(), "no-such-scheduler") {
+		t.Errorf("failed() = %v, want the missing program", err)
+	}
+}
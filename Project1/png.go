@@ -0,0 +1,158 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// defaultPNGWidth is the width of PNG GANTT charts unless -png-width says otherwise.
+const defaultPNGWidth = 1980
+
+// pngGlyphs is a 5x7 bitmap font, one byte per row with the leftmost pixel in bit 4. Lowercase is
+// drawn as uppercase and anything else as a space.
+var pngGlyphs = map[rune][7]byte{
+	'0': {0x0e, 0x11, 0x13, 0x15, 0x19, 0x11, 0x0e},
+	'1': {0x04, 0x0c, 0x04, 0x04, 0x04, 0x04, 0x0e},
+	'2': {0x0e, 0x11, 0x01, 0x02, 0x04, 0x08, 0x1f},
+	'3': {0x1f, 0x02, 0x04, 0x02, 0x01, 0x11, 0x0e},
+	'4': {0x02, 0x06, 0x0a, 0x12, 0x1f, 0x02, 0x02},
+	'5': {0x1f, 0x10, 0x1e, 0x01, 0x01, 0x11, 0x0e},
+	'6': {0x06, 0x08, 0x10, 0x1e, 0x11, 0x11, 0x0e},
+	'7': {0x1f, 0x01, 0x02, 0x04, 0x08, 0x08, 0x08},
+	'8': {0x0e, 0x11, 0x11, 0x0e, 0x11, 0x11, 0x0e},
+	'9': {0x0e, 0x11, 0x11, 0x0f, 0x01, 0x02, 0x0c},
+	'A': {0x0e, 0x11, 0x11, 0x11, 0x1f, 0x11, 0x11},
+	'B': {0x1e, 0x11, 0x11, 0x1e, 0x11, 0x11, 0x1e},
+	'C': {0x0e, 0x11, 0x10, 0x10, 0x10, 0x11, 0x0e},
+	'D': {0x1c, 0x12, 0x11, 0x11, 0x11, 0x12, 0x1c},
+	'E': {0x1f, 0x10, 0x10, 0x1e, 0x10, 0x10, 0x1f},
+	'F': {0x1f, 0x10, 0x10, 0x1e, 0x10, 0x10, 0x10},
+	'G': {0x0e, 0x11, 0x10, 0x17, 0x11, 0x11, 0x0f},
+	'H': {0x11, 0x11, 0x11, 0x1f, 0x11, 0x11, 0x11},
+	'I': {0x0e, 0x04, 0x04, 0x04, 0x04, 0x04, 0x0e},
+	'J': {0x07, 0x02, 0x02, 0x02, 0x02, 0x12, 0x0c},
+	'K': {0x11, 0x12, 0x14, 0x18, 0x14, 0x12, 0x11},
+	'L': {0x10, 0x10, 0x10, 0x10, 0x10, 0x10, 0x1f},
+	'M': {0x11, 0x1b, 0x15, 0x15, 0x11, 0x11, 0x11},
+	'N': {0x11, 0x11, 0x19, 0x15, 0x13, 0x11, 0x11},
+	'O': {0x0e, 0x11, 0x11, 0x11, 0x11, 0x11, 0x0e},
+	'P': {0x1e, 0x11, 0x11, 0x1e, 0x10, 0x10, 0x10},
+	'Q': {0x0e, 0x11, 0x11, 0x11, 0x15, 0x12, 0x0d},
+	'R': {0x1e, 0x11, 0x11, 0x1e, 0x14, 0x12, 0x11},
+	'S': {0x0f, 0x10, 0x10, 0x0e, 0x01, 0x01, 0x1e},
+	'T': {0x1f, 0x04, 0x04, 0x04, 0x04, 0x04, 0x04},
+	'U': {0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x0e},
+	'V': {0x11, 0x11, 0x11, 0x11, 0x11, 0x0a, 0x04},
+	'W': {0x11, 0x11, 0x11, 0x15, 0x15, 0x15, 0x0a},
+	'X': {0x11, 0x11, 0x0a, 0x04, 0x0a, 0x11, 0x11},
+	'Y': {0x11, 0x11, 0x11, 0x0a, 0x04, 0x04, 0x04},
+	'Z': {0x1f, 0x01, 0x02, 0x04, 0x08, 0x10, 0x1f},
+	'-': {0x00, 0x00, 0x00, 0x1f, 0x00, 0x00, 0x00},
+	',': {0x00, 0x00, 0x00, 0x00, 0x0c, 0x04, 0x08},
+	'.': {0x00, 0x00, 0x00, 0x00, 0x00, 0x0c, 0x0c},
+	':': {0x00, 0x0c, 0x0c, 0x00, 0x0c, 0x0c, 0x00},
+	'(': {0x02, 0x04, 0x08, 0x08, 0x08, 0x04, 0x02},
+	')': {0x08, 0x04, 0x02, 0x02, 0x02, 0x04, 0x08},
+	'/': {0x00, 0x01, 0x02, 0x04, 0x08, 0x10, 0x00},
+	'%': {0x18, 0x19, 0x02, 0x04, 0x08, 0x13, 0x03},
+}
+
+// pngCanvas draws the GANTT chart layout shared with the SVG output, scaled by a factor.
+type pngCanvas struct {
+	img   *image.RGBA
+	scale float64
+}
+
+func (c pngCanvas) px(v float64) int {
+	return int(v*c.scale + 0.5)
+}
+
+// rect fills a rectangle given in SVG layout coordinates.
+func (c pngCanvas) rect(x0, y0, x1, y1 float64, col color.Color) {
+	r := image.Rect(c.px(x0), c.px(y0), c.px(x1), c.px(y1))
+	draw.Draw(c.img, r, image.NewUniform(col), image.Point{}, draw.Src)
+}
+
+// text draws a string whose baseline starts, centers or ends at (x, y) in SVG layout coordinates.
+func (c pngCanvas) text(x, y float64, s, anchor string, col color.Color) {
+	dot := c.px(1.2)
+	if dot < 1 {
+		dot = 1
+	}
+	s = strings.ToUpper(s)
+	width := len([]rune(s))*6*dot - dot
+	left, top := c.px(x), c.px(y)-7*dot
+	switch anchor {
+	case "middle":
+		left -= width / 2
+	case "end":
+		left -= width
+	}
+	for i, ch := range []rune(s) {
+		glyph := pngGlyphs[ch]
+		for row, bits := range glyph {
+			for column := 0; column < 5; column++ {
+				if bits&(0x10>>column) == 0 {
+					continue
+				}
+				x0, y0 := left+(i*6+column)*dot, top+row*dot
+				draw.Draw(c.img, image.Rect(x0, y0, x0+dot, y0+dot), image.NewUniform(col), image.Point{}, draw.Src)
+			}
+		}
+	}
+}
+
+// hexColor parses a "#rrggbb" color.
+func hexColor(hex string) color.RGBA {
+	n, err := strconv.ParseUint(strings.TrimPrefix(hex, "#"), 16, 32)
+	if err != nil {
+		return color.RGBA{A: 0xff}
+	}
+
+	return color.RGBA{R: uint8(n >> 16), G: uint8(n >> 8), B: uint8(n), A: 0xff}
+}
+
+// outputPNG outputs the same GANTT charts as the SVG format as a PNG image r.PNGWidth pixels wide.
+func outputPNG(w io.Writer, results []result, r Report) error {
+	width := r.PNGWidth
+	if width <= 0 {
+		width = defaultPNGWidth
+	}
+	layoutWidth, layoutHeight := svgLabelWidth+svgPlotWidth+20, len(results)*svgRowHeight+10
+	c := pngCanvas{scale: float64(width) / float64(layoutWidth)}
+	c.img = image.NewRGBA(image.Rect(0, 0, width, c.px(float64(layoutHeight))))
+	draw.Draw(c.img, c.img.Bounds(), image.White, image.Point{}, draw.Src)
+
+	start, stop := ganttSpan(results)
+	scale := float64(svgPlotWidth)
+	if stop > start {
+		scale /= float64(stop - start)
+	}
+	x := func(t int64) float64 { return svgLabelWidth + float64(t-start)*scale }
+	ink := hexColor("#333333")
+	for i, res := range results {
+		top := float64(i*svgRowHeight + 24)
+		c.text(10, top+svgLaneHeight/2+4, res.title, "start", color.Black)
+		for _, ts := range res.schedule.timeline() {
+			label, textColor := sliceLabel(ts.PID), color.Color(color.White)
+			if ts.PID == idlePID || ts.PID == switchPID {
+				textColor = hexColor("#666666")
+			}
+			c.rect(x(ts.Start), top, x(ts.Stop)-1, top+svgLaneHeight, hexColor(pidColor(ts.PID)))
+			c.text((x(ts.Start)+x(ts.Stop))/2, top+svgLaneHeight/2+4, label, "middle", textColor)
+		}
+		axis := top + svgLaneHeight
+		c.rect(svgLabelWidth, axis, svgLabelWidth+svgPlotWidth, axis+1, ink)
+		for _, t := range ganttTicks(start, stop) {
+			c.rect(x(t), axis, x(t)+1, axis+4, ink)
+			c.text(x(t), axis+16, res.schedule.formatTime(t), "middle", ink)
+		}
+	}
+
+	return png.Encode(w, c.img)
+}
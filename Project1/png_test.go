@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func Test_hexColor(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		hex  string
+		want color.RGBA
+	}{
+		{hex: "#f28e2b", want: color.RGBA{R: 0xf2, G: 0x8e, B: 0x2b, A: 0xff}},
+		{hex: "#000000", want: color.RGBA{A: 0xff}},
+		{hex: "#zzzzzz", want: color.RGBA{A: 0xff}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.hex, func(t *testing.T) {
+			t.Parallel()
+			if got := hexColor(tt.hex); got != tt.want {
+				t.Errorf("hexColor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_outputPNG(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 15, Priority: 1},
+	}
+	results := []result{{title: "First-come, first-serve", schedule: fcfs(processes, Options{})}}
+
+	var w bytes.Buffer
+	if err := outputPNG(&w, results, Report{PNGWidth: 495}); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := img.Bounds().Size(); got.X != 495 || got.Y != 45 {
+		t.Errorf("size = %v, want 495x45", got)
+	}
+	// Process 1 runs from 0 to 5 of 20, so the lane a quarter in is its color
+	// and three quarters in is process 2's.
+	for x, want := range map[int]string{90: pidColor(1), 390: pidColor(2)} {
+		if got := color.RGBAModel.Convert(img.At(x, 14)); got != hexColor(want) {
+			t.Errorf("pixel at %d = %v, want %v", x, got, hexColor(want))
+		}
+	}
+}
@@ -0,0 +1,281 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// policyVariables are the values of a ready process a policy expression can use, at the time of the
+// decision.
+var policyVariables = map[string]func(t int64, p pluginProcess) float64{
+	"remaining": func(_ int64, p pluginProcess) float64 { return float64(p.Remaining) },
+	"burst":     func(_ int64, p pluginProcess) float64 { return float64(p.Burst) },
+	"arrival":   func(_ int64, p pluginProcess) float64 { return float64(p.Arrival) },
+	"priority":  func(_ int64, p pluginProcess) float64 { return float64(p.Priority) },
+	"deadline":  func(_ int64, p pluginProcess) float64 { return float64(p.Deadline) },
+	"id":        func(_ int64, p pluginProcess) float64 { return float64(p.ID) },
+	"time":      func(t int64, _ pluginProcess) float64 { return float64(t) },
+	"age":       func(t int64, p pluginProcess) float64 { return float64(t - p.Arrival) },
+	"wait": func(t int64, p pluginProcess) float64 {
+		return float64(t - p.Arrival - (p.Burst - p.Remaining))
+	},
+}
+
+// policyFunctions are the functions a policy expression can call.
+var policyFunctions = map[string]func(args []float64) float64{
+	"min": func(args []float64) float64 {
+		m := args[0]
+		for _, a := range args[1:] {
+			m = math.Min(m, a)
+		}
+		return m
+	},
+	"max": func(args []float64) float64 {
+		m := args[0]
+		for _, a := range args[1:] {
+			m = math.Max(m, a)
+		}
+		return m
+	},
+	"abs": func(args []float64) float64 { return math.Abs(args[0]) },
+}
+
+// policyExpr evaluates a policy expression for a ready process at a time.
+type policyExpr func(t int64, p pluginProcess) float64
+
+// policy is a scheduling policy written as an expression over the ready processes: "min remaining +
+// 0.5*priority" runs the process for which the expression is least. It is re-evaluated whenever a
+// process arrives or completes; ties go to the process listed first.
+type policy struct {
+	source   string
+	maximize bool
+	score    policyExpr
+}
+
+// parsePolicy parses a policy, "min EXPR" or "max EXPR", optionally written "pick min(EXPR)". The
+// expression has numbers, the policyVariables, + - * /, parentheses and the policyFunctions.
+func parsePolicy(source string) (*policy, error) {
+	text := strings.TrimSpace(source)
+	text = strings.TrimSpace(strings.TrimPrefix(text, "pick "))
+	p := &policy{source: strings.TrimSpace(source)}
+	switch {
+	case strings.HasPrefix(text, "min"):
+		text = text[len("min"):]
+	case strings.HasPrefix(text, "max"):
+		p.maximize = true
+		text = text[len("max"):]
+	default:
+		return nil, fmt.Errorf("%w: policy %q must start with min or max", ErrInvalidArgs, source)
+	}
+	parser := &policyParser{text: text}
+	score, err := parser.expr()
+	if err == nil && parser.skipSpace() < len(parser.text) {
+		err = fmt.Errorf("unexpected %q", parser.text[parser.pos:])
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: policy %q: %v", ErrInvalidArgs, source, err)
+	}
+	p.score = score
+
+	return p, nil
+}
+
+// algorithm returns the policy as an algorithm to schedule with.
+func (p *policy) algorithm() algorithm {
+	return algorithm{name: "policy", title: "Policy " + p.source, run: p.schedule}
+}
+
+// schedule runs the ready process the policy picks, until a process arrives or completes.
+func (p *policy) schedule(processes []Process, opts Options) Schedule {
+	s, _ := simulateDecisions(processes, opts, p.decide) // the policy always picks a ready process
+
+	return s
+}
+
+// decide picks the ready process with the least score, or the greatest when maximizing.
+func (p *policy) decide(query pluginQuery) (pluginReply, error) {
+	best, bestScore := 0, 0.0
+	for i, proc := range query.Ready {
+		score := p.score(query.Time, proc)
+		if p.maximize {
+			score = -score
+		}
+		if i == 0 || score < bestScore {
+			best, bestScore = i, score
+		}
+	}
+
+	return pluginReply{Run: query.Ready[best].ID}, nil
+}
+
+// policyParser is a recursive descent parser of policy expressions:
+//
+//	expr   = term { ("+" | "-") term }
+//	term   = unary { ("*" | "/") unary }
+//	unary  = "-" unary | number | name | name "(" expr { "," expr } ")" | "(" expr ")"
+type policyParser struct {
+	text string
+	pos  int
+}
+
+// skipSpace skips spaces and returns the position after them.
+func (pp *policyParser) skipSpace() int {
+	for pp.pos < len(pp.text) && pp.text[pp.pos] == ' ' {
+		pp.pos++
+	}
+
+	return pp.pos
+}
+
+// accept consumes the operator c if it comes next.
+func (pp *policyParser) accept(c byte) bool {
+	if pp.skipSpace() < len(pp.text) && pp.text[pp.pos] == c {
+		pp.pos++
+		return true
+	}
+
+	return false
+}
+
+func (pp *policyParser) expr() (policyExpr, error) {
+	left, err := pp.term()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch {
+		case pp.accept('+'):
+			right, err := pp.term()
+			if err != nil {
+				return nil, err
+			}
+			l := left
+			left = func(t int64, p pluginProcess) float64 { return l(t, p) + right(t, p) }
+		case pp.accept('-'):
+			right, err := pp.term()
+			if err != nil {
+				return nil, err
+			}
+			l := left
+			left = func(t int64, p pluginProcess) float64 { return l(t, p) - right(t, p) }
+		default:
+			return left, nil
+		}
+	}
+}
+
+func (pp *policyParser) term() (policyExpr, error) {
+	left, err := pp.unary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch {
+		case pp.accept('*'):
+			right, err := pp.unary()
+			if err != nil {
+				return nil, err
+			}
+			l := left
+			left = func(t int64, p pluginProcess) float64 { return l(t, p) * right(t, p) }
+		case pp.accept('/'):
+			right, err := pp.unary()
+			if err != nil {
+				return nil, err
+			}
+			l := left
+			left = func(t int64, p pluginProcess) float64 { return l(t, p) / right(t, p) }
+		default:
+			return left, nil
+		}
+	}
+}
+
+func (pp *policyParser) unary() (policyExpr, error) {
+	if pp.accept('-') {
+		operand, err := pp.unary()
+		if err != nil {
+			return nil, err
+		}
+		return func(t int64, p pluginProcess) float64 { return -operand(t, p) }, nil
+	}
+	if pp.accept('(') {
+		inner, err := pp.expr()
+		if err != nil {
+			return nil, err
+		}
+		if !pp.accept(')') {
+			return nil, fmt.Errorf("missing ) at %d", pp.pos)
+		}
+		return inner, nil
+	}
+
+	start := pp.skipSpace()
+	for pp.pos < len(pp.text) && (pp.text[pp.pos] == '.' || unicode.IsLetter(rune(pp.text[pp.pos])) ||
+		unicode.IsDigit(rune(pp.text[pp.pos])) || pp.text[pp.pos] == '_') {
+		pp.pos++
+	}
+	token := pp.text[start:pp.pos]
+	switch {
+	case token == "":
+		if start == len(pp.text) {
+			return nil, fmt.Errorf("unexpected end")
+		}
+		return nil, fmt.Errorf("unexpected %q", pp.text[start:])
+	case unicode.IsDigit(rune(token[0])) || token[0] == '.':
+		v, err := strconv.ParseFloat(token, 64)
+		if err != nil {
+			return nil, fmt.Errorf("bad number %q", token)
+		}
+		return func(int64, pluginProcess) float64 { return v }, nil
+	}
+	if fn, ok := policyFunctions[token]; ok {
+		if !pp.accept('(') {
+			return nil, fmt.Errorf("%s must be called, as %s(...)", token, token)
+		}
+		args := make([]policyExpr, 0)
+		for {
+			arg, err := pp.expr()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if pp.accept(')') {
+				break
+			}
+			if !pp.accept(',') {
+				return nil, fmt.Errorf("missing ) at %d", pp.pos)
+			}
+		}
+		return func(t int64, p pluginProcess) float64 {
+			values := make([]float64, len(args))
+			for i, arg := range args {
+				values[i] = arg(t, p)
+			}
+			return fn(values)
+		}, nil
+	}
+	if variable, ok := policyVariables[token]; ok {
+		return variable, nil
+	}
+
+	return nil, fmt.Errorf("unknown name %q (want %s)", token, strings.Join(policyNames(), ", "))
+}
+
+// policyNames returns the variables and functions of policy expressions, sorted.
+func policyNames() []string {
+	names := make([]string, 0, len(policyVariables)+len(policyFunctions))
+	for name := range policyVariables {
+		names = append(names, name)
+	}
+	for name := range policyFunctions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_parsePolicy(t *testing.T) {
+	t.Parallel()
+	p := pluginProcess{ID: 3, Arrival: 2, Burst: 8, Remaining: 5, Priority: 4, Deadline: 20}
+	tests := []struct {
+		source  string
+		want    float64
+		wantMax bool
+		wantErr bool
+	}{
+		{source: "min remaining + 0.5*priority", want: 7},
+		{source: "pick min(remaining + 0.5*priority)", want: 7},
+		{source: "max wait / burst", want: 0.625, wantMax: true},
+		{source: "min -(age - 2) * (1 + priority)", want: -30},
+		{source: "min max(remaining, burst / 2) + abs(-id)", want: 8},
+		{source: "min deadline + arrival - time", want: 12},
+		{source: "remaining", wantErr: true},
+		{source: "min remaining +", wantErr: true},
+		{source: "min (remaining", wantErr: true},
+		{source: "min prio", wantErr: true},
+		{source: "min max", wantErr: true},
+		{source: "min remaining priority", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.source, func(t *testing.T) {
+			t.Parallel()
+			got, err := parsePolicy(tt.source)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !errors.Is(err, ErrInvalidArgs) {
+					t.Errorf("parsePolicy() error = %v, want %v", err, ErrInvalidArgs)
+				}
+				return
+			}
+			if score := got.score(10, p); score != tt.want || got.maximize != tt.wantMax {
+				t.Errorf("score = %v, maximize %v, want %v, %v", score, got.maximize, tt.want, tt.wantMax)
+			}
+		})
+	}
+}
+
+func Test_policy_schedule(t *testing.T) {
+	t.Parallel()
+	processes, err := loadProcesses(strings.NewReader("1,5,0,2\n2,9,3,1\n3,6,6,3\n4,2,7,1\n5,3,30,2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		source string
+		want   Schedule
+	}{
+		{source: "min remaining", want: sjf(processes, Options{})},
+		{source: "min arrival", want: fcfs(processes, Options{})},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.source, func(t *testing.T) {
+			t.Parallel()
+			p, err := parsePolicy(tt.source)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := p.algorithm().schedule(processes, Options{})
+			if !reflect.DeepEqual(got.Completion, tt.want.Completion) {
+				t.Errorf("Completion = %v, want %v", got.Completion, tt.want.Completion)
+			}
+			if !reflect.DeepEqual(got.Wait, tt.want.Wait) {
+				t.Errorf("Wait = %v, want %v", got.Wait, tt.want.Wait)
+			}
+		})
+	}
+}
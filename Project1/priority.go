@@ -0,0 +1,63 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// PriorityOrder names which end of the priority numbers is the more important.
+type PriorityOrder string
+
+const (
+	PriorityOrderLow  PriorityOrder = "low"  // lower numbers are more important, as with Unix nice values
+	PriorityOrderHigh PriorityOrder = "high" // higher numbers are more important, as in Windows and Java
+)
+
+// priorityOrders are the priority orders, the default first.
+var priorityOrders = []PriorityOrder{PriorityOrderLow, PriorityOrderHigh}
+
+// parsePriorityOrder validates a priority order name.
+func parsePriorityOrder(name string) (PriorityOrder, error) {
+	for _, o := range priorityOrders {
+		if PriorityOrder(name) == o {
+			return o, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: unknown priority order %q (want %s)", ErrInvalidArgs, name, strings.Join(priorityOrderNames(), " or "))
+}
+
+func priorityOrderNames() []string {
+	names := make([]string, len(priorityOrders))
+	for i, o := range priorityOrders {
+		names[i] = string(o)
+	}
+
+	return names
+}
+
+// ranked returns the processes with their priorities and preemption thresholds renumbered so that
+// lower numbers are more important, which is what the schedulers compare. Under the high order a
+// priority p becomes ^p, -p-1, which reverses the order without overflowing.
+func (o PriorityOrder) ranked(processes []Process) []Process {
+	if o != PriorityOrderHigh {
+		return processes
+	}
+	ranked := append([]Process(nil), processes...)
+	for i := range ranked {
+		ranked[i].Priority = ^ranked[i].Priority
+		if ranked[i].HasThreshold {
+			ranked[i].Threshold = ^ranked[i].Threshold
+		}
+	}
+
+	return ranked
+}
+
+// addPriorityOrderFlag registers the -priority-order flag on fs. Call the returned func after parsing.
+func addPriorityOrderFlag(fs *flag.FlagSet) func() (PriorityOrder, error) {
+	order := fs.String("priority-order", string(PriorityOrderLow), "which priority numbers are more important: low or high")
+
+	return func() (PriorityOrder, error) { return parsePriorityOrder(*order) }
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPreemptivePriority_priorityOrder(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		workload       string
+		order          PriorityOrder
+		wantCompletion []int64
+	}{
+		{name: "low", workload: "1,4,0,3\n2,2,1,2\n3,1,2,0", order: PriorityOrderLow, wantCompletion: []int64{7, 4, 3}},
+		{name: "default", workload: "1,4,0,3\n2,2,1,2\n3,1,2,0", wantCompletion: []int64{7, 4, 3}},
+		{name: "high", workload: "1,4,0,3\n2,2,1,2\n3,1,2,0", order: PriorityOrderHigh, wantCompletion: []int64{4, 6, 7}},
+		{name: "high preempts", workload: "1,4,0,0\n2,2,1,2\n3,1,2,5", order: PriorityOrderHigh, wantCompletion: []int64{7, 4, 3}},
+		{name: "high threshold", workload: "1,4,0,0,threshold:3\n2,2,1,2\n3,1,2,5", order: PriorityOrderHigh, wantCompletion: []int64{7, 5, 3}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			processes, err := loadProcesses(strings.NewReader(tt.workload))
+			if err != nil {
+				t.Fatal(err)
+			}
+			s := preemptivePriority(processes, Options{Priority: tt.order})
+			if !reflect.DeepEqual(s.Completion, tt.wantCompletion) {
+				t.Errorf("completion = %v, want %v", s.Completion, tt.wantCompletion)
+			}
+			if !reflect.DeepEqual(s.Processes, processes) {
+				t.Errorf("processes = %v, want the workload's %v", s.Processes, processes)
+			}
+		})
+	}
+}
+
+func Test_parsePriorityOrder(t *testing.T) {
+	t.Parallel()
+	for _, name := range priorityOrderNames() {
+		if got, err := parsePriorityOrder(name); err != nil || string(got) != name {
+			t.Errorf("parsePriorityOrder(%q) = %q, %v", name, got, err)
+		}
+	}
+	if _, err := parsePriorityOrder("highest"); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("parsePriorityOrder(%q) error = %v, want %v", "highest", err, ErrInvalidArgs)
+	}
+}
+
+func TestPriorityOrder_ranked(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: 1, Priority: 1, Threshold: 4, HasThreshold: true}, {ProcessID: 2, Priority: 3}}
+	if got := PriorityOrderLow.ranked(processes); !reflect.DeepEqual(got, processes) {
+		t.Errorf("low ranked = %v, want %v", got, processes)
+	}
+	got := PriorityOrderHigh.ranked(processes)
+	if got[0].Priority <= got[1].Priority || got[0].Threshold >= got[0].Priority || got[1].Threshold != 0 {
+		t.Errorf("high ranked = %v, want the priority order reversed", got)
+	}
+	if processes[0].Priority != 1 {
+		t.Errorf("ranked changed the workload: %v", processes)
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// defaultConfigFile is where profiles are read from unless -config says otherwise.
+const defaultConfigFile = "schedsim.conf"
+
+// profileSetting is one "flag = value" line of a profile.
+type profileSetting struct {
+	flag, value string
+	line        int
+}
+
+// loadProfiles reads the profiles of a config file: a "[name]" line starts a profile, followed by
+// "flag = value" lines naming any command flag without its dash. Blank lines and lines starting
+// with # are ignored.
+func loadProfiles(r io.Reader) (map[string][]profileSetting, error) {
+	var (
+		profiles = make(map[string][]profileSetting)
+		current  string
+		scanner  = bufio.NewScanner(r)
+	)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		switch {
+		case text == "" || strings.HasPrefix(text, "#"):
+		case strings.HasPrefix(text, "[") && strings.HasSuffix(text, "]"):
+			current = strings.TrimSpace(text[1 : len(text)-1])
+			if current == "" {
+				return nil, fmt.Errorf("%w: line %d: profile without a name", ErrInvalidArgs, line)
+			}
+			if _, ok := profiles[current]; ok {
+				return nil, fmt.Errorf("%w: line %d: profile %q defined twice", ErrInvalidArgs, line, current)
+			}
+			profiles[current] = make([]profileSetting, 0)
+		default:
+			key, value, ok := strings.Cut(text, "=")
+			if !ok {
+				return nil, fmt.Errorf("%w: line %d: want flag = value", ErrInvalidArgs, line)
+			}
+			if current == "" {
+				return nil, fmt.Errorf("%w: line %d: setting outside a [profile]", ErrInvalidArgs, line)
+			}
+			key = strings.TrimLeft(strings.TrimSpace(key), "-")
+			profiles[current] = append(profiles[current], profileSetting{flag: key, value: strings.TrimSpace(value), line: line})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: reading config file", err)
+	}
+
+	return profiles, nil
+}
+
+// parseFlags parses a command's arguments, then sets the flags not given on the command line from
+// the -profile in the -config file, if one is selected. A profile may hold flags of other commands,
+// which this command skips.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+	}
+	name := fs.Lookup("profile").Value.String()
+	if name == "" {
+		return nil
+	}
+	path := fs.Lookup("config").Value.String()
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("%w: opening config file %s: %v", ErrInvalidArgs, path, err)
+	}
+	defer f.Close()
+	profiles, err := loadProfiles(f)
+	if err != nil {
+		return fmt.Errorf("%w (%s)", err, path)
+	}
+	settings, ok := profiles[name]
+	if !ok {
+		return fmt.Errorf("%w: no profile %q in %s", ErrInvalidArgs, name, path)
+	}
+
+	given := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { given[f.Name] = true })
+	for _, setting := range settings {
+		switch {
+		case setting.flag == "profile", setting.flag == "config":
+			return fmt.Errorf("%w: %s line %d: a profile cannot set -%s", ErrInvalidArgs, path, setting.line, setting.flag)
+		case given[setting.flag], fs.Lookup(setting.flag) == nil:
+		default:
+			if err := fs.Set(setting.flag, setting.value); err != nil {
+				return fmt.Errorf("%w: %s line %d: -%s: %v", ErrInvalidArgs, path, setting.line, setting.flag, err)
+			}
+		}
+	}
+
+	return nil
+}
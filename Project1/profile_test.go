@@ -0,0 +1,111 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_loadProfiles(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		config  string
+		want    map[string][]profileSetting
+		wantErr error
+	}{
+		{
+			name: "profiles",
+			config: `# assignment part 2
+[rt]
+algorithms = priority,rr
+-format=markdown
+
+[batch]
+`,
+			want: map[string][]profileSetting{
+				"rt": {
+					{flag: "algorithms", value: "priority,rr", line: 3},
+					{flag: "format", value: "markdown", line: 4},
+				},
+				"batch": {},
+			},
+		},
+		{name: "outside profile", config: "format = html\n", wantErr: ErrInvalidArgs},
+		{name: "no value", config: "[rt]\nstats\n", wantErr: ErrInvalidArgs},
+		{name: "twice", config: "[rt]\n[rt]\n", wantErr: ErrInvalidArgs},
+		{name: "no name", config: "[ ]\n", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := loadProfiles(strings.NewReader(tt.config))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadProfiles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseFlags(t *testing.T) {
+	t.Parallel()
+	config := filepath.Join(t.TempDir(), "schedsim.conf")
+	if err := os.WriteFile(config, []byte(`[rt]
+format = markdown
+stats = true
+algorithms = priority,rr
+
+[broken]
+stats = maybe
+
+[loop]
+profile = rt
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantFormat string
+		wantStats  bool
+		wantErr    error
+	}{
+		{name: "no profile", args: []string{"w.csv"}, wantFormat: "text"},
+		{name: "profile", args: []string{"-config", config, "-profile", "rt", "w.csv"}, wantFormat: "markdown", wantStats: true},
+		{name: "flag wins", args: []string{"-config", config, "-profile", "rt", "-format", "html", "w.csv"}, wantFormat: "html", wantStats: true},
+		{name: "missing config", args: []string{"-config", config + ".missing", "-profile", "rt"}, wantErr: ErrInvalidArgs},
+		{name: "unknown profile", args: []string{"-config", config, "-profile", "nope"}, wantErr: ErrInvalidArgs},
+		{name: "bad value", args: []string{"-config", config, "-profile", "broken"}, wantErr: ErrInvalidArgs},
+		{name: "profile in profile", args: []string{"-config", config, "-profile", "loop"}, wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.String("profile", "", "")
+			fs.String("config", defaultConfigFile, "")
+			format := fs.String("format", "text", "")
+			stats := fs.Bool("stats", false, "")
+			err := parseFlags(fs, tt.args)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if *format != tt.wantFormat || *stats != tt.wantStats {
+				t.Errorf("format, stats = %q, %v, want %q, %v", *format, *stats, tt.wantFormat, tt.wantStats)
+			}
+		})
+	}
+}
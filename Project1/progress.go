@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// progressInterval is how often a long simulation reports its progress.
+const progressInterval = time.Second
+
+// progressMeter reports how many processes a simulation has completed, at most every interval, so
+// users of long simulations know it has not hung. A nil meter reports nothing.
+type progressMeter struct {
+	w         io.Writer
+	label     string
+	total     int
+	completed int
+	start     time.Time
+	last      time.Time
+	reported  bool
+	now       func() time.Time
+}
+
+// newProgressMeter returns a meter of the total processes labeled label, writing to w, or nil when
+// w is nil.
+func newProgressMeter(w io.Writer, label string, total int) *progressMeter {
+	if w == nil {
+		return nil
+	}
+	start := time.Now()
+
+	return &progressMeter{w: w, label: label, total: total, start: start, last: start, now: time.Now}
+}
+
+// complete counts a completed process, reporting the progress if an interval has passed since the
+// last report.
+func (m *progressMeter) complete() {
+	if m == nil {
+		return
+	}
+	m.completed++
+	if now := m.now(); now.Sub(m.last) >= progressInterval && m.completed < m.total {
+		m.last = now
+		m.reported = true
+		_, _ = fmt.Fprintf(m.w, "%s: %3.0f%% (%d of %d processes)\n", m.label,
+			100*float64(m.completed)/float64(m.total), m.completed, m.total)
+	}
+}
+
+// finish reports the simulation done, if it ran long enough to report its progress before.
+func (m *progressMeter) finish() {
+	if m == nil || !m.reported {
+		return
+	}
+	_, _ = fmt.Fprintf(m.w, "%s: done, %d processes in %s\n", m.label, m.total,
+		m.now().Sub(m.start).Round(time.Millisecond))
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_progressMeter(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		steps []time.Duration // the time between completions
+		want  string
+	}{
+		{
+			name:  "quick",
+			steps: []time.Duration{time.Millisecond, time.Millisecond, time.Millisecond, time.Millisecond},
+			want:  "",
+		},
+		{
+			name:  "slow",
+			steps: []time.Duration{time.Second, 500 * time.Millisecond, 600 * time.Millisecond, time.Second},
+			want: "FCFS:  25% (1 of 4 processes)\n" +
+				"FCFS:  75% (3 of 4 processes)\n" +
+				"FCFS: done, 4 processes in 3.1s\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var (
+				b     bytes.Buffer
+				clock = time.Unix(0, 0)
+				m     = newProgressMeter(&b, "FCFS", len(tt.steps))
+			)
+			m.start, m.last = clock, clock
+			m.now = func() time.Time { return clock }
+			for _, step := range tt.steps {
+				clock = clock.Add(step)
+				m.complete()
+			}
+			m.finish()
+			if got := b.String(); got != tt.want {
+				t.Errorf("progress = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_progressMeter_nil(t *testing.T) {
+	t.Parallel()
+	m := newProgressMeter(nil, "FCFS", 3)
+	if m != nil {
+		t.Fatalf("newProgressMeter(nil) = %+v, want nil", m)
+	}
+	m.complete()
+	m.finish()
+}
+
+func Test_algorithm_schedule_progress(t *testing.T) {
+	t.Parallel()
+	processes, err := loadProcesses(strings.NewReader("1,2,0,1\n2,3,1,1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b bytes.Buffer
+	s := algorithms[0].schedule(processes, Options{Progress: &b})
+	if want := []int64{2, 5}; !reflect.DeepEqual(s.Completion, want) {
+		t.Errorf("Completion = %v, want %v", s.Completion, want)
+	}
+	if b.Len() != 0 {
+		t.Errorf("progress = %q, want nothing from a quick simulation", b.String())
+	}
+}
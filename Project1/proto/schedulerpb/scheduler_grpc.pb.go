@@ -0,0 +1,198 @@
+// The process scheduler as a gRPC service, served by `serve -grpc`. It mirrors the JSON API of
+// `serve` (POST /simulate) and the `generate` and `compare` subcommands, so the messages carry the
+// same fields.
+//
+// Regenerate the Go stubs in schedulerpb, from the Project1 directory, with:
+//
+//   protoc -I proto --go_out=proto/schedulerpb --go_opt=paths=source_relative \
+//     --go-grpc_out=proto/schedulerpb --go-grpc_opt=paths=source_relative proto/scheduler.proto
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: scheduler.proto
+
+package schedulerpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Scheduler_Simulate_FullMethodName         = "/scheduler.v1.Scheduler/Simulate"
+	Scheduler_GenerateWorkload_FullMethodName = "/scheduler.v1.Scheduler/GenerateWorkload"
+	Scheduler_Compare_FullMethodName          = "/scheduler.v1.Scheduler/Compare"
+)
+
+// SchedulerClient is the client API for Scheduler service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SchedulerClient interface {
+	// Simulate schedules a workload with each requested algorithm.
+	Simulate(ctx context.Context, in *SimulateRequest, opts ...grpc.CallOption) (*SimulateResponse, error)
+	// GenerateWorkload draws a random workload, like the generate subcommand.
+	GenerateWorkload(ctx context.Context, in *GenerateWorkloadRequest, opts ...grpc.CallOption) (*GenerateWorkloadResponse, error)
+	// Compare schedules a workload with each requested algorithm and ranks them on their averages.
+	Compare(ctx context.Context, in *SimulateRequest, opts ...grpc.CallOption) (*CompareResponse, error)
+}
+
+type schedulerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSchedulerClient(cc grpc.ClientConnInterface) SchedulerClient {
+	return &schedulerClient{cc}
+}
+
+func (c *schedulerClient) Simulate(ctx context.Context, in *SimulateRequest, opts ...grpc.CallOption) (*SimulateResponse, error) {
+	out := new(SimulateResponse)
+	err := c.cc.Invoke(ctx, Scheduler_Simulate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerClient) GenerateWorkload(ctx context.Context, in *GenerateWorkloadRequest, opts ...grpc.CallOption) (*GenerateWorkloadResponse, error) {
+	out := new(GenerateWorkloadResponse)
+	err := c.cc.Invoke(ctx, Scheduler_GenerateWorkload_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerClient) Compare(ctx context.Context, in *SimulateRequest, opts ...grpc.CallOption) (*CompareResponse, error) {
+	out := new(CompareResponse)
+	err := c.cc.Invoke(ctx, Scheduler_Compare_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SchedulerServer is the server API for Scheduler service.
+// All implementations must embed UnimplementedSchedulerServer
+// for forward compatibility
+type SchedulerServer interface {
+	// Simulate schedules a workload with each requested algorithm.
+	Simulate(context.Context, *SimulateRequest) (*SimulateResponse, error)
+	// GenerateWorkload draws a random workload, like the generate subcommand.
+	GenerateWorkload(context.Context, *GenerateWorkloadRequest) (*GenerateWorkloadResponse, error)
+	// Compare schedules a workload with each requested algorithm and ranks them on their averages.
+	Compare(context.Context, *SimulateRequest) (*CompareResponse, error)
+	mustEmbedUnimplementedSchedulerServer()
+}
+
+// UnimplementedSchedulerServer must be embedded to have forward compatible implementations.
+type UnimplementedSchedulerServer struct {
+}
+
+func (UnimplementedSchedulerServer) Simulate(context.Context, *SimulateRequest) (*SimulateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Simulate not implemented")
+}
+func (UnimplementedSchedulerServer) GenerateWorkload(context.Context, *GenerateWorkloadRequest) (*GenerateWorkloadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateWorkload not implemented")
+}
+func (UnimplementedSchedulerServer) Compare(context.Context, *SimulateRequest) (*CompareResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Compare not implemented")
+}
+func (UnimplementedSchedulerServer) mustEmbedUnimplementedSchedulerServer() {}
+
+// UnsafeSchedulerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SchedulerServer will
+// result in compilation errors.
+type UnsafeSchedulerServer interface {
+	mustEmbedUnimplementedSchedulerServer()
+}
+
+func RegisterSchedulerServer(s grpc.ServiceRegistrar, srv SchedulerServer) {
+	s.RegisterService(&Scheduler_ServiceDesc, srv)
+}
+
+func _Scheduler_Simulate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimulateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServer).Simulate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Scheduler_Simulate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServer).Simulate(ctx, req.(*SimulateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Scheduler_GenerateWorkload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateWorkloadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServer).GenerateWorkload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Scheduler_GenerateWorkload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServer).GenerateWorkload(ctx, req.(*GenerateWorkloadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Scheduler_Compare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimulateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServer).Compare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Scheduler_Compare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServer).Compare(ctx, req.(*SimulateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Scheduler_ServiceDesc is the grpc.ServiceDesc for Scheduler service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Scheduler_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "scheduler.v1.Scheduler",
+	HandlerType: (*SchedulerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Simulate",
+			Handler:    _Scheduler_Simulate_Handler,
+		},
+		{
+			MethodName: "GenerateWorkload",
+			Handler:    _Scheduler_GenerateWorkload_Handler,
+		},
+		{
+			MethodName: "Compare",
+			Handler:    _Scheduler_Compare_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "scheduler.proto",
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// quantumPrefix starts the optional quantum column of a workload row, "quantum:4", giving the process
+// its own round-robin time slice in place of the global -quantum, as some RTOSes allow.
+const quantumPrefix = "quantum:"
+
+// quantum returns the global round-robin time quantum of the options in ticks, one time unit if unset.
+func (opts Options) quantum() int64 {
+	if opts.Quantum > 0 {
+		return opts.Quantum
+	}
+
+	return opts.ticksPerUnit()
+}
+
+// quantumOf returns the round-robin time quantum of a process: its own, or the global one.
+func quantumOf(p Process, global int64) int64 {
+	if p.Quantum > 0 {
+		return p.Quantum
+	}
+
+	return global
+}
+
+// RRQueue names how round-robin orders its ready queue.
+type RRQueue string
+
+const (
+	RRQueueRotation RRQueue = "rotation" // turns go round the processes in input order, skipping those not ready
+	RRQueueFIFO     RRQueue = "fifo"     // processes queue on arrival and requeue at the back after their slice
+)
+
+// rrQueues are the round-robin queue orders, the default first.
+var rrQueues = []RRQueue{RRQueueRotation, RRQueueFIFO}
+
+// parseRRQueue validates a round-robin queue order name.
+func parseRRQueue(name string) (RRQueue, error) {
+	for _, q := range rrQueues {
+		if RRQueue(name) == q {
+			return q, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: unknown round-robin queue %q", ErrInvalidArgs, name)
+}
+
+func rrQueueNames() []string {
+	names := make([]string, len(rrQueues))
+	for i, q := range rrQueues {
+		names[i] = string(q)
+	}
+
+	return names
+}
+
+// roundRobinFIFO schedules the processes round-robin from a FIFO ready queue. Processes join it as
+// they arrive, in arrival order, and one that uses up its quantum rejoins at the back, behind the
+// processes that arrived while it ran.
+func roundRobinFIFO(processes []Process, opts Options) Schedule {
+	var (
+		serviceTime int64
+		timeQuantum = opts.quantum()
+		remTime     = make([]int64, len(processes))
+		admitted    = make([]bool, len(processes))
+		queue       = make([]int, 0, len(processes))
+		s           = newSchedule(processes)
+		ready       = newReadiness(processes, s.Completion, opts.ClassPolicy)
+	)
+	s.Quantum = timeQuantum
+	completed := 0
+	count := len(processes)
+
+	for i := range processes { // Populating the remaining time array that will be updated along the way
+		remTime[i] = processes[i].BurstDuration
+	}
+	admit := func() { // queue every process that has become ready, in arrival order
+		arrived := make([]int, 0)
+		for i := range processes {
+			if !admitted[i] && ready.released(i, serviceTime) {
+				admitted[i] = true
+				arrived = append(arrived, i)
+			}
+		}
+		sort.SliceStable(arrived, func(a, b int) bool {
+			return processes[arrived[a]].ArrivalTime < processes[arrived[b]].ArrivalTime
+		})
+		queue = append(queue, arrived...)
+	}
+
+	for completed != count && !opts.cancelled() {
+		admit()
+		current, at := -1, 0
+		for k, i := range queue {
+			if ready.ready(i, serviceTime) {
+				current, at = i, k
+				break
+			}
+		}
+		if current < 0 {
+			idle := ready.idleUntil(serviceTime)
+			s.addIdle(serviceTime, idle)
+			serviceTime = idle
+			continue
+		}
+		queue = append(queue[:at], queue[at+1:]...)
+
+		if remTime[current] == processes[current].BurstDuration {
+			s.FirstRun[current] = serviceTime
+		}
+		run := quantumOf(processes[current], timeQuantum)
+		if !ready.othersReady(current, serviceTime, remTime) { // alone, it gets quantum after quantum
+			run = aloneRun(remTime[current], run, serviceTime, ready.nextRelease(serviceTime))
+		}
+		if remTime[current] < run {
+			run = remTime[current]
+		}
+		s.Gantt = opts.gantt.append(s.Gantt, TimeSlice{
+			PID:   processes[current].ProcessID,
+			Start: serviceTime,
+			Stop:  serviceTime + run,
+		})
+		serviceTime += run
+		remTime[current] -= run
+
+		if remTime[current] == 0 {
+			completed++
+			opts.progress.complete()
+			s.Completion[current] = serviceTime
+			s.Wait[current] = s.Completion[current] - processes[current].BurstDuration - processes[current].ArrivalTime
+			continue
+		}
+		admit()
+		queue = append(queue, current)
+	}
+
+	for i := range s.Wait {
+		s.Turnaround[i] = processes[i].BurstDuration + s.Wait[i]
+	}
+
+	return s
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRoundRobin_quantum(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		workload       string
+		quantum        int64
+		wantCompletion []int64
+	}{
+		{name: "global", workload: "1,5,0,1\n2,4,0,1", quantum: 2, wantCompletion: []int64{9, 8}},
+		{name: "per process", workload: "1,5,0,1,quantum:3\n2,4,0,1", wantCompletion: []int64{6, 9}},
+		{name: "per process and global", workload: "1,5,0,1,quantum:3\n2,4,0,1", quantum: 2, wantCompletion: []int64{7, 9}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			processes, err := loadProcesses(strings.NewReader(tt.workload))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := roundRobin(processes, Options{Quantum: tt.quantum}).Completion; !reflect.DeepEqual(got, tt.wantCompletion) {
+				t.Errorf("completion = %v, want %v", got, tt.wantCompletion)
+			}
+		})
+	}
+}
+
+func Test_loadProcesses_quantum(t *testing.T) {
+	t.Parallel()
+	processes, err := loadProcessesAt(strings.NewReader("1,5,0,1,quantum:1.5"), timeBase{resolution: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := processes[0].Quantum; got != 15 {
+		t.Errorf("Quantum = %d, want 15", got)
+	}
+	if _, err := loadProcesses(strings.NewReader("1,5,0,1,quantum:long")); err == nil {
+		t.Error("loadProcesses() succeeded with a bad quantum, want an error")
+	}
+}
+
+func TestRoundRobin_queue(t *testing.T) {
+	t.Parallel()
+	// 1 comes first in the input but arrives last.
+	processes, err := loadProcesses(strings.NewReader("1,2,2,1\n2,3,0,1\n3,2,1,1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		queue          RRQueue
+		wantCompletion []int64
+		wantOrder      []int64
+	}{
+		{queue: RRQueueRotation, wantCompletion: []int64{6, 7, 5}, wantOrder: []int64{2, 3, 1, 2, 3, 1, 2}},
+		{queue: RRQueueFIFO, wantCompletion: []int64{7, 6, 5}, wantOrder: []int64{2, 3, 2, 1, 3, 2, 1}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(string(tt.queue), func(t *testing.T) {
+			t.Parallel()
+			s := roundRobin(processes, Options{RRQueue: tt.queue})
+			if !reflect.DeepEqual(s.Completion, tt.wantCompletion) {
+				t.Errorf("completion = %v, want %v", s.Completion, tt.wantCompletion)
+			}
+			order := make([]int64, len(s.Gantt))
+			for i, ts := range s.Gantt {
+				order[i] = ts.PID
+			}
+			if !reflect.DeepEqual(order, tt.wantOrder) {
+				t.Errorf("slices = %v, want %v", order, tt.wantOrder)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// queueSample is the state of the queue at one time, in time units: the length of the ready queue,
+// the processes in the system (arrived and not completed, running or not) and their outstanding work.
+type queueSample struct {
+	Time     float64 `json:"time"`
+	Length   int     `json:"length"`
+	InSystem int     `json:"in_system"`
+	Work     float64 `json:"work"`
+}
+
+// QueueLengths returns the state of the queue at every time a scheduling event happened.
+func (s Schedule) QueueLengths() []queueSample {
+	index := make(map[int64]int, len(s.Processes))
+	for i, p := range s.Processes {
+		index[p.ProcessID] = i
+	}
+	samples := make([]queueSample, 0)
+	for _, e := range s.Events() {
+		t := s.inUnits(float64(e.Time))
+		if n := len(samples); n > 0 && samples[n-1].Time == t {
+			continue
+		}
+		var (
+			inSystem int
+			work     int64
+			served   = make([]int64, len(s.Processes))
+		)
+		for _, ts := range s.Gantt {
+			if i, ok := index[ts.PID]; ok && ts.Start < e.Time {
+				stop := ts.Stop
+				if stop > e.Time {
+					stop = e.Time
+				}
+				served[i] += stop - ts.Start
+			}
+		}
+		for i, p := range s.Processes {
+			if p.ArrivalTime <= e.Time && e.Time < s.Completion[i] {
+				inSystem++
+				work += p.BurstDuration - served[i]
+			}
+		}
+		samples = append(samples, queueSample{Time: t, Length: len(s.readyQueue(e.Time)), InSystem: inSystem, Work: s.inUnits(float64(work))})
+	}
+
+	return samples
+}
+
+// writeQueueLengths writes the queue samples of every result as CSV with an algorithm, time, length,
+// in_system and work column, or as a JSON object of each algorithm's samples.
+func writeQueueLengths(w io.Writer, results []result, asJSON bool) error {
+	if asJSON {
+		byAlgorithm := make(map[string][]queueSample, len(results))
+		for _, res := range results {
+			byAlgorithm[res.title] = res.schedule.QueueLengths()
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(byAlgorithm)
+	}
+
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"algorithm", "time", "length", "in_system", "work"})
+	for _, res := range results {
+		for _, q := range res.schedule.QueueLengths() {
+			_ = cw.Write([]string{res.title, strconv.FormatFloat(q.Time, 'f', -1, 64), fmt.Sprint(q.Length),
+				fmt.Sprint(q.InSystem), strconv.FormatFloat(q.Work, 'f', -1, 64)})
+		}
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestSchedule_QueueLengths(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, Priority: 1},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 1, Priority: 1},
+	}
+	want := []queueSample{
+		{Time: 0, Length: 0, InSystem: 1, Work: 4},
+		{Time: 1, Length: 1, InSystem: 2, Work: 4},
+		{Time: 2, Length: 2, InSystem: 3, Work: 4},
+		{Time: 4, Length: 1, InSystem: 2, Work: 2},
+		{Time: 5, Length: 0, InSystem: 1, Work: 1},
+		{Time: 6, Length: 0},
+	}
+	if got := fcfs(processes, Options{}).QueueLengths(); !reflect.DeepEqual(got, want) {
+		t.Errorf("QueueLengths() = %v, want %v", got, want)
+	}
+}
+
+func Test_writeQueueLengths(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, Priority: 1}}
+	results := []result{{title: "First-come, first-serve", schedule: fcfs(processes, Options{})}}
+	tests := []struct {
+		name   string
+		asJSON bool
+		want   string
+	}{
+		{
+			name: "CSV",
+			want: "algorithm,time,length,in_system,work\n\"First-come, first-serve\",0,0,1,2\n\"First-come, first-serve\",2,0,0,0\n",
+		},
+		{
+			name:   "JSON",
+			asJSON: true,
+			want: `{
+  "First-come, first-serve": [
+    {
+      "time": 0,
+      "length": 0,
+      "in_system": 1,
+      "work": 2
+    },
+    {
+      "time": 2,
+      "length": 0,
+      "in_system": 0,
+      "work": 0
+    }
+  ]
+}
+`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			if err := writeQueueLengths(&w, results, tt.asJSON); err != nil {
+				t.Fatal(err)
+			}
+			if got := w.String(); got != tt.want {
+				t.Errorf("writeQueueLengths() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import "math"
+
+// absoluteDeadline returns the time a process must complete by, math.MaxInt64 if it has no deadline.
+func absoluteDeadline(p Process) int64 {
+	if d := relativeDeadline(p); d > 0 {
+		return p.ArrivalTime + d
+	}
+
+	return math.MaxInt64
+}
+
+// edf schedules the processes earliest deadline first: every time unit the ready process with the
+// earliest absolute deadline runs, preempting the running one. Processes without a deadline run
+// only when no process with one is ready.
+func edf(processes []Process, opts Options) Schedule {
+	return leastKeyFirst(processes, opts, func(i int, _, _ int64) int64 {
+		return absoluteDeadline(processes[i])
+	})
+}
+
+// llf schedules the processes least laxity first: every time unit the ready process with the least
+// laxity, the time to its deadline less its remaining burst, runs, preempting the running one.
+// Processes without a deadline run only when no process with one is ready.
+func llf(processes []Process, opts Options) Schedule {
+	return leastKeyFirst(processes, opts, func(i int, t, remaining int64) int64 {
+		deadline := absoluteDeadline(processes[i])
+		if deadline == math.MaxInt64 {
+			return deadline
+		}
+		return deadline - t - remaining
+	})
+}
+
+// leastKeyFirst runs, every time unit, the ready process with the least key of its index, the time
+// and its remaining burst. The running process keeps the CPU on ties; other ties go by the tie-break.
+func leastKeyFirst(processes []Process, opts Options, key func(i int, t, remaining int64) int64) Schedule {
+	var (
+		serviceTime int64
+		remTime     = make([]int64, len(processes))
+		s           = newSchedule(processes)
+		tb          = newTieBreaker(opts.TieBreak, opts.Seed, processes)
+		ready       = newReadiness(processes, s.Completion, opts.ClassPolicy)
+	)
+	completed := 0
+	running := -1 // -1 while idle
+	count := len(processes)
+
+	for i := range processes { // Populating the remaining time array that will be updated along the way
+		remTime[i] = processes[i].BurstDuration
+	}
+
+	for completed != count && !opts.cancelled() {
+		current := -1
+		var least int64
+		for j := 0; j < count; j++ {
+			if !ready.ready(j, serviceTime) || remTime[j] == 0 {
+				continue
+			}
+			k := key(j, serviceTime, remTime[j])
+			if current < 0 || k < least || k == least && current != running && (j == running || tb.prefer(j, current)) {
+				current, least = j, k
+			}
+		}
+		if current < 0 {
+			idle := ready.idleUntil(serviceTime)
+			s.addIdle(serviceTime, idle)
+			serviceTime = idle
+			running = -1
+			continue
+		}
+
+		if remTime[current] == processes[current].BurstDuration {
+			s.FirstRun[current] = serviceTime
+		}
+		if n := len(s.Gantt); current == running && n > 0 {
+			s.Gantt[n-1].Stop++
+		} else {
+			s.Gantt = opts.gantt.append(s.Gantt, TimeSlice{
+				PID:   processes[current].ProcessID,
+				Start: serviceTime,
+				Stop:  serviceTime + 1,
+			})
+		}
+		remTime[current]--
+		serviceTime++
+		running = current
+
+		if remTime[current] == 0 {
+			completed++
+			opts.progress.complete()
+			running = -1
+			s.Completion[current] = serviceTime
+			s.Wait[current] = s.Completion[current] - processes[current].BurstDuration - processes[current].ArrivalTime
+		}
+	}
+
+	for i := range s.Wait {
+		s.Turnaround[i] = processes[i].BurstDuration + s.Wait[i]
+	}
+
+	return s
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSchedulers_deadlines(t *testing.T) {
+	t.Parallel()
+	// Schedulable under EDF but not under rate-monotonic priorities: 2 has the shorter period.
+	const workload = "1,4,0,2,period:7\n2,2,0,1,period:5"
+	tests := []struct {
+		name           string
+		run            func([]Process, Options) Schedule
+		wantCompletion []int64
+	}{
+		{name: "edf", run: edf, wantCompletion: []int64{6, 2, 8}},
+		{name: "llf", run: llf, wantCompletion: []int64{6, 3, 8}},
+		{name: "priority", run: preemptivePriority, wantCompletion: []int64{8, 2, 7}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			processes, err := loadProcesses(strings.NewReader(workload))
+			if err != nil {
+				t.Fatal(err)
+			}
+			s := tt.run(released(t, processes, 7, 1), Options{})
+			if !reflect.DeepEqual(s.Completion, tt.wantCompletion) {
+				t.Errorf("completion = %v, want %v", s.Completion, tt.wantCompletion)
+			}
+		})
+	}
+}
+
+func TestSchedulers_noDeadlines(t *testing.T) {
+	t.Parallel()
+	// Without deadlines every process ties, so the running one keeps the CPU, as in FCFS.
+	processes, err := loadProcesses(strings.NewReader("1,3,0,1\n2,2,1,1\n3,1,1,1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []int64{3, 5, 6}
+	for _, run := range []func([]Process, Options) Schedule{edf, llf} {
+		if got := run(processes, Options{}).Completion; !reflect.DeepEqual(got, want) {
+			t.Errorf("completion = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestSchedulers_longIdleGap(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 1},
+		{ProcessID: 2, ArrivalTime: 1 << 40, BurstDuration: 1},
+	}
+	want := []int64{1, 1<<40 + 1}
+	for _, run := range []func([]Process, Options) Schedule{edf, llf} {
+		if got := run(processes, Options{}).Completion; !reflect.DeepEqual(got, want) {
+			t.Errorf("completion = %v, want %v", got, want)
+		}
+	}
+}
+
+func Test_releaseJobs_sporadic(t *testing.T) {
+	t.Parallel()
+	processes, err := loadProcesses(strings.NewReader("1,1,0,1,sporadic:2,deadline:2\n2,1,0,2,period:40"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !processes[0].Sporadic || processes[0].Period != 2 {
+		t.Fatalf("Sporadic, Period = %v, %d, want true, 2", processes[0].Sporadic, processes[0].Period)
+	}
+	releases := func(seed int64) []int64 {
+		var times []int64
+		for _, p := range released(t, processes, horizon(processes), seed) {
+			if p.Task == 1 {
+				times = append(times, p.ArrivalTime)
+			}
+		}
+		return times
+	}
+
+	first := releases(1)
+	if len(first) < 2 {
+		t.Fatalf("releases = %v, want several", first)
+	}
+	regular := true
+	for i := 1; i < len(first); i++ {
+		if gap := first[i] - first[i-1]; gap < 2 {
+			t.Errorf("releases %v are %d apart, want at least 2", first, gap)
+		} else if gap != 2 {
+			regular = false
+		}
+	}
+	if regular {
+		t.Errorf("releases = %v, want irregular gaps", first)
+	}
+	if again := releases(1); !reflect.DeepEqual(again, first) {
+		t.Errorf("releases = %v with the same seed, want %v", again, first)
+	}
+	if other := releases(2); reflect.DeepEqual(other, first) {
+		t.Errorf("releases = %v with another seed, want them to differ", other)
+	}
+}
+
+func Test_loadProcesses_sporadic(t *testing.T) {
+	t.Parallel()
+	for _, workload := range []string{"1,1,0,1,period:4,sporadic:4", "1,1,0,1,sporadic:4,period:4", "1,1,0,1,sporadic:x"} {
+		if _, err := loadProcesses(strings.NewReader(workload)); err == nil {
+			t.Errorf("loadProcesses(%q) succeeded, want an error", workload)
+		}
+	}
+}
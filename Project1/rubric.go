@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// assertionOps are the comparisons of rubric assertions, two-character ones first so they are
+// matched before their prefixes.
+var assertionOps = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+// assertion is a rubric line: an algorithm's compared metric against an expected value or bound.
+type assertion struct {
+	source    string
+	algorithm algorithm
+	metric    comparedMetric
+	op        string
+	want      float64
+	tolerance float64 // half the last digit given, so "== 12.33" holds for 12.333
+}
+
+// parseAssertion parses "ALGORITHM METRIC OP VALUE", e.g. "fcfs average wait == 12.33" or "rr context
+// switches <= 40". Algorithms and metrics are named as in the comparison table, in any case.
+func parseAssertion(line string) (assertion, error) {
+	a := assertion{source: line}
+	at, op := -1, ""
+	for _, o := range assertionOps {
+		if i := strings.Index(line, o); i >= 0 {
+			at, op = i, o
+			break
+		}
+	}
+	if at < 0 {
+		return a, fmt.Errorf("%q must compare with one of %s", line, strings.Join(assertionOps, " "))
+	}
+	a.op = op
+	value := strings.TrimSuffix(strings.TrimSpace(line[at+len(op):]), "%")
+	var err error
+	if a.want, err = strconv.ParseFloat(value, 64); err != nil {
+		return a, fmt.Errorf("%q: %q is not a number", line, value)
+	}
+	a.tolerance = 0.5
+	if dot := strings.IndexByte(value, '.'); dot >= 0 {
+		a.tolerance = 0.5 * math.Pow(10, -float64(len(value)-dot-1))
+	}
+
+	name, metric, _ := strings.Cut(strings.TrimSpace(line[:at]), " ")
+	selected, err := selectAlgorithms(strings.ToLower(name))
+	if err != nil || len(selected) != 1 {
+		return a, fmt.Errorf("%q: unknown algorithm %q", line, name)
+	}
+	a.algorithm = selected[0]
+	metric = strings.Join(strings.Fields(metric), " ")
+	for _, m := range comparedMetrics {
+		if strings.EqualFold(m.header, metric) {
+			a.metric = m
+			return a, nil
+		}
+	}
+
+	return a, fmt.Errorf("%q: unknown metric %q", line, metric)
+}
+
+// holds reports whether the value meets the assertion.
+func (a assertion) holds(v float64) bool {
+	switch a.op {
+	case "==":
+		return math.Abs(v-a.want) <= a.tolerance
+	case "!=":
+		return math.Abs(v-a.want) > a.tolerance
+	case "<=":
+		return v <= a.want+a.tolerance
+	case ">=":
+		return v >= a.want-a.tolerance
+	case "<":
+		return v < a.want
+	default:
+		return v > a.want
+	}
+}
+
+// loadRubric reads a rubric file of an assertion per line. Blank lines and # comments are skipped,
+// and a line may be a YAML list item, "- fcfs average wait == 12.33", quoted or not.
+func loadRubric(path string) ([]assertion, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("%v: error opening rubric", err)
+	}
+	defer f.Close()
+	rubric := make([]assertion, 0)
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimPrefix(line, "- "))
+		line = strings.Trim(line, `"'`)
+		a, err := parseAssertion(line)
+		if err != nil {
+			return nil, fmt.Errorf("%w: rubric %s line %d: %v", ErrInvalidArgs, path, n, err)
+		}
+		rubric = append(rubric, a)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(rubric) == 0 {
+		return nil, fmt.Errorf("%w: rubric %s holds no assertions", ErrInvalidArgs, path)
+	}
+
+	return rubric, nil
+}
+
+// checkRubric checks each assertion of the rubric against the results, writing a line per failed one
+// and a tally, and returns ErrMismatch if any failed.
+func checkRubric(w io.Writer, rubric []assertion, results []result) error {
+	failed := 0
+	for _, a := range rubric {
+		var (
+			found bool
+			got   float64
+		)
+		for _, res := range results {
+			if res.title == a.algorithm.title {
+				found, got = true, a.metric.value(res.schedule)
+			}
+		}
+		switch {
+		case !found:
+			_, _ = fmt.Fprintf(w, "FAIL %s: %s was not scheduled\n", a.source, a.algorithm.name)
+		case !a.holds(got):
+			_, _ = fmt.Fprintf(w, "FAIL %s: got "+a.metric.format+"\n", a.source, got)
+		default:
+			continue
+		}
+		failed++
+	}
+	_, _ = fmt.Fprintf(w, "%d of %d assertions passed\n", len(rubric)-failed, len(rubric))
+	if failed > 0 {
+		return fmt.Errorf("%w: %d of %d assertions failed", ErrMismatch, failed, len(rubric))
+	}
+
+	return nil
+}
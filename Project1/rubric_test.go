@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_parseAssertion(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		line      string
+		value     float64
+		wantHolds bool
+		wantErr   bool
+	}{
+		{line: "fcfs average wait == 12.33", value: 12.333, wantHolds: true},
+		{line: "FCFS Average Wait == 12.33", value: 12.34, wantHolds: false},
+		{line: "rr context switches <= 40", value: 40, wantHolds: true},
+		{line: "rr context switches < 40", value: 40, wantHolds: false},
+		{line: "sjf cpu utilization >= 95%", value: 96.5, wantHolds: true},
+		{line: "priority throughput > 0.2", value: 0.15, wantHolds: false},
+		{line: "edf deadline misses != 0", value: 0, wantHolds: false},
+		{line: "fcfs average wait is 12", wantErr: true},
+		{line: "fifo average wait == 12", wantErr: true},
+		{line: "fcfs waiting == 12", wantErr: true},
+		{line: "fcfs average wait == twelve", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.line, func(t *testing.T) {
+			t.Parallel()
+			a, err := parseAssertion(tt.line)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseAssertion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && a.holds(tt.value) != tt.wantHolds {
+				t.Errorf("holds(%v) = %v, want %v", tt.value, !tt.wantHolds, tt.wantHolds)
+			}
+		})
+	}
+}
+
+func Test_checkRubric(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "rubric.yaml")
+	rubric := "# Part 1\n\n- fcfs average wait == 3.33\n- \"sjf average wait == 2.67\"\n- 'rr context switches <= 5'\n- edf makespan == 20\n"
+	if err := os.WriteFile(path, []byte(rubric), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	assertions, err := loadRubric(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	processes, err := loadProcesses(strings.NewReader(loadFixture(t, "example_processes.csv")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	selected, _ := selectAlgorithms("fcfs,sjf,rr")
+	var b bytes.Buffer
+	err = checkRubric(&b, assertions, scheduleAll(processes, Options{}, selected))
+	if !errors.Is(err, ErrMismatch) {
+		t.Errorf("checkRubric() error = %v, want %v", err, ErrMismatch)
+	}
+	want := "FAIL rr context switches <= 5: got 16\nFAIL edf makespan == 20: edf was not scheduled\n2 of 4 assertions passed\n"
+	if b.String() != want {
+		t.Errorf("checkRubric() wrote %q, want %q", b.String(), want)
+	}
+}
+
+func Test_loadRubric(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "empty", content: "# nothing yet\n"},
+		{name: "bad line", content: "- fcfs average wait == 3.33\n- fcfs average wait\n"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			path := filepath.Join(t.TempDir(), "rubric.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := loadRubric(path); !errors.Is(err, ErrInvalidArgs) {
+				t.Errorf("loadRubric() error = %v, want %v", err, ErrInvalidArgs)
+			}
+		})
+	}
+}
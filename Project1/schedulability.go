@@ -0,0 +1,310 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+)
+
+// periodPrefix and deadlinePrefix start the optional period and relative deadline columns of a
+// workload row, "period:10" and "deadline:8". A row with a period is a periodic task whose burst is
+// its worst-case execution time (WCET) and whose arrival is its first release.
+const (
+	periodPrefix   = "period:"
+	deadlinePrefix = "deadline:"
+)
+
+// maxDemandHorizon caps the hyperperiod the EDF processor demand test checks, in ticks.
+const maxDemandHorizon = 10_000_000
+
+// Schedulability verdicts.
+const (
+	verdictSchedulable   = "schedulable"
+	verdictUnschedulable = "unschedulable"
+	verdictInconclusive  = "inconclusive"
+)
+
+// periodicTasks returns the periodic tasks of a workload, in input order, leaving out jobs they released.
+func periodicTasks(processes []Process) []Process {
+	tasks := make([]Process, 0)
+	for _, p := range processes {
+		if p.Period > 0 && p.Job == 0 {
+			tasks = append(tasks, p)
+		}
+	}
+
+	return tasks
+}
+
+// relativeDeadline returns the relative deadline of a periodic task: its period unless it has a
+// shorter deadline.
+func relativeDeadline(p Process) int64 {
+	if p.Deadline > 0 {
+		return p.Deadline
+	}
+
+	return p.Period
+}
+
+// utilization returns the fraction of the CPU the tasks need, the sum of WCET ÷ period.
+func utilization(tasks []Process) float64 {
+	var u float64
+	for _, p := range tasks {
+		u += float64(p.BurstDuration) / float64(p.Period)
+	}
+
+	return u
+}
+
+// density returns the sum of WCET ÷ relative deadline of the tasks.
+func density(tasks []Process) float64 {
+	var d float64
+	for _, p := range tasks {
+		d += float64(p.BurstDuration) / float64(relativeDeadline(p))
+	}
+
+	return d
+}
+
+// liuLaylandBound returns the utilization up to which any n tasks with deadlines equal to their
+// periods are schedulable under rate-monotonic priorities, n(2^(1/n) - 1).
+func liuLaylandBound(n int) float64 {
+	return float64(n) * (math.Pow(2, 1/float64(n)) - 1)
+}
+
+// rmOrder returns the task indexes in rate-monotonic priority order: shorter periods first, input
+// order on ties.
+func rmOrder(tasks []Process) []int {
+	order := make([]int, len(tasks))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return tasks[order[i]].Period < tasks[order[j]].Period })
+
+	return order
+}
+
+// responseTimes returns the worst-case response time of every task under rate-monotonic priorities
+// by response-time analysis: the least fixed point of R = C + Σ ⌈R ÷ T⌉·C over the higher priority
+// tasks. The iteration stops once a response time passes its deadline, so that one is only a bound.
+func responseTimes(tasks []Process) []int64 {
+	var (
+		order    = rmOrder(tasks)
+		response = make([]int64, len(tasks))
+	)
+	for k, i := range order {
+		r := tasks[i].BurstDuration
+		for _, j := range order[:k] {
+			r += tasks[j].BurstDuration
+		}
+		for r <= relativeDeadline(tasks[i]) {
+			next := tasks[i].BurstDuration
+			for _, j := range order[:k] {
+				next += (r + tasks[j].Period - 1) / tasks[j].Period * tasks[j].BurstDuration
+			}
+			if next == r {
+				break
+			}
+			r = next
+		}
+		response[i] = r
+	}
+
+	return response
+}
+
+// hyperperiod returns the least common multiple of the task periods, and false if it passes limit.
+func hyperperiod(tasks []Process, limit int64) (int64, bool) {
+	h := int64(1)
+	for _, p := range tasks {
+		a, b := h, p.Period
+		for b != 0 {
+			a, b = b, a%b
+		}
+		if h/a > limit/p.Period {
+			return 0, false
+		}
+		h = h / a * p.Period
+	}
+
+	return h, h <= limit
+}
+
+// demandMiss runs the EDF processor demand test over a horizon: it returns the first absolute
+// deadline t of synchronously released jobs at which the work due by t exceeds t, or -1 if there is
+// none.
+func demandMiss(tasks []Process, horizon int64) (t, demand int64) {
+	deadlines := make([]int64, 0)
+	for _, p := range tasks {
+		for d := relativeDeadline(p); d <= horizon; d += p.Period {
+			deadlines = append(deadlines, d)
+		}
+	}
+	sort.Slice(deadlines, func(i, j int) bool { return deadlines[i] < deadlines[j] })
+	for _, t := range deadlines {
+		var demand int64
+		for _, p := range tasks {
+			if d := relativeDeadline(p); t >= d {
+				demand += ((t-d)/p.Period + 1) * p.BurstDuration
+			}
+		}
+		if demand > t {
+			return t, demand
+		}
+	}
+
+	return -1, 0
+}
+
+// schedulabilityTest is the outcome of one schedulability test of a task set under a policy.
+type schedulabilityTest struct {
+	policy  string
+	test    string
+	detail  string
+	verdict string
+}
+
+// analyzeSchedulability runs the utilization bound and response-time analysis tests for rate-monotonic
+// (RM) priorities and the utilization, density and processor demand tests for earliest deadline first
+// (EDF) on a periodic task set, assuming every task may release its jobs at once.
+func analyzeSchedulability(tasks []Process, base timeBase) []schedulabilityTest {
+	var (
+		u         = utilization(tasks)
+		implicit  = true
+		tests     = make([]schedulabilityTest, 0, 5)
+		formatted = func(t int64) string { return formatTicks(t, base) }
+	)
+	for _, p := range tasks {
+		implicit = implicit && relativeDeadline(p) == p.Period
+	}
+
+	// RM
+	switch bound := liuLaylandBound(len(tasks)); {
+	case u > 1:
+		tests = append(tests, schedulabilityTest{"RM", "utilization", fmt.Sprintf("U = %.3f > 1", u), verdictUnschedulable})
+	case !implicit:
+		tests = append(tests, schedulabilityTest{"RM", "utilization bound", "needs deadlines = periods", verdictInconclusive})
+	case u <= bound:
+		tests = append(tests, schedulabilityTest{"RM", "utilization bound", fmt.Sprintf("U = %.3f ≤ %.3f for %d tasks", u, bound, len(tasks)), verdictSchedulable})
+	default:
+		tests = append(tests, schedulabilityTest{"RM", "utilization bound", fmt.Sprintf("U = %.3f > %.3f for %d tasks", u, bound, len(tasks)), verdictInconclusive})
+	}
+	rta := schedulabilityTest{"RM", "response-time analysis", "every R ≤ D", verdictSchedulable}
+	for _, i := range rmOrder(tasks) {
+		if r, d := responseTimes(tasks)[i], relativeDeadline(tasks[i]); r > d {
+			rta.detail = fmt.Sprintf("task %d: R = %s > D = %s", tasks[i].ProcessID, formatted(r), formatted(d))
+			rta.verdict = verdictUnschedulable
+			break
+		}
+	}
+	tests = append(tests, rta)
+
+	// EDF
+	switch d := density(tasks); {
+	case u > 1:
+		tests = append(tests, schedulabilityTest{"EDF", "utilization", fmt.Sprintf("U = %.3f > 1", u), verdictUnschedulable})
+	case implicit:
+		tests = append(tests, schedulabilityTest{"EDF", "utilization", fmt.Sprintf("U = %.3f ≤ 1", u), verdictSchedulable})
+	case d <= 1:
+		tests = append(tests, schedulabilityTest{"EDF", "density", fmt.Sprintf("Σ C/D = %.3f ≤ 1", d), verdictSchedulable})
+	default:
+		h, ok := hyperperiod(tasks, maxDemandHorizon)
+		if !ok {
+			tests = append(tests, schedulabilityTest{"EDF", "processor demand", "hyperperiod too long to check", verdictInconclusive})
+			break
+		}
+		if t, demand := demandMiss(tasks, h); t >= 0 {
+			tests = append(tests, schedulabilityTest{"EDF", "processor demand", fmt.Sprintf("%s due by time %s", formatted(demand), formatted(t)), verdictUnschedulable})
+		} else {
+			tests = append(tests, schedulabilityTest{"EDF", "processor demand", "demand within time up to the hyperperiod " + formatted(h), verdictSchedulable})
+		}
+	}
+
+	return tests
+}
+
+// policyVerdict combines the verdicts of a policy's tests: unschedulable if any test proves it,
+// otherwise schedulable if any test proves that.
+func policyVerdict(tests []schedulabilityTest, policy string) string {
+	verdict := verdictInconclusive
+	for _, t := range tests {
+		switch {
+		case t.policy != policy:
+		case t.verdict == verdictUnschedulable:
+			return verdictUnschedulable
+		case t.verdict == verdictSchedulable:
+			verdict = verdictSchedulable
+		}
+	}
+
+	return verdict
+}
+
+// outputSchedulability outputs the periodic tasks with their utilization and RM response times, the
+// schedulability tests and a verdict per policy.
+func outputSchedulability(w io.Writer, tasks []Process, base timeBase, style string) {
+	outputTitle(w, "Schedulability")
+	response := responseTimes(tasks)
+	rows := make([][]string, len(tasks))
+	for i, p := range tasks {
+		rows[i] = []string{
+			fmt.Sprint(p.ProcessID),
+			formatTicks(p.Period, base),
+			formatTicks(p.BurstDuration, base),
+			formatTicks(relativeDeadline(p), base),
+			fmt.Sprintf("%.3f", float64(p.BurstDuration)/float64(p.Period)),
+			formatTicks(response[i], base),
+		}
+	}
+	outputTable(w, style, []string{"ID", "Period", "WCET", "Deadline", "Utilization", "RM response"}, rows, nil, nil)
+
+	tests := analyzeSchedulability(tasks, base)
+	rows = make([][]string, len(tests))
+	for i, t := range tests {
+		rows[i] = []string{t.policy, t.test, t.detail, t.verdict}
+	}
+	outputTable(w, style, []string{"Policy", "Test", "Result", "Verdict"}, rows, nil, nil)
+	verdicts := make([]string, 0, 2)
+	for _, policy := range []string{"RM", "EDF"} {
+		verdicts = append(verdicts, policy+" "+policyVerdict(tests, policy))
+	}
+	_, _ = fmt.Fprintf(w, "Verdict: %s\n\n", strings.Join(verdicts, ", "))
+}
+
+// schedulabilityCommand analyzes whether the periodic tasks of a workload file are schedulable.
+func schedulabilityCommand(w io.Writer, args []string) error {
+	fs := newFlagSet("schedulability")
+	tableStyle := fs.String("table-style", "ascii", "text table style: "+strings.Join(tableStyleNames(), ", "))
+	times := addTimeFlags(fs)
+	logging := addLogFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := logging(); err != nil {
+		return err
+	}
+	base, err := times()
+	if err != nil {
+		return err
+	}
+	if _, ok := tableStyles[*tableStyle]; !ok && *tableStyle != tsvStyle {
+		return fmt.Errorf("%w: unknown table style %q", ErrInvalidArgs, *tableStyle)
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: must give a scheduling file", ErrInvalidArgs)
+	}
+	processes, err := loadWorkload(fs.Arg(0), base)
+	if err != nil {
+		return err
+	}
+	tasks := periodicTasks(processes)
+	if len(tasks) == 0 {
+		return fmt.Errorf("%w: no periodic tasks; give rows a %s column", ErrInvalidArgs, periodPrefix)
+	}
+
+	outputSchedulability(w, tasks, base, *tableStyle)
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_loadProcesses_periodic(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		workload     string
+		wantPeriod   int64
+		wantDeadline int64
+		wantErr      error
+	}{
+		{name: "one-off", workload: "1,5,0,2"},
+		{name: "period", workload: "1,2,0,2,period:10", wantPeriod: 10},
+		{name: "period and deadline", workload: "1,2,0,2,period:10,deadline:8", wantPeriod: 10, wantDeadline: 8},
+		{name: "deadline and class", workload: "1,2,0,2,deadline:8,class:system,period:10", wantPeriod: 10, wantDeadline: 8},
+		{name: "deadline beyond period", workload: "1,2,0,2,period:10,deadline:12", wantErr: ErrValidation},
+		{name: "negative period", workload: "1,2,0,2,period:-10", wantErr: ErrValidation},
+		{name: "bad period", workload: "1,2,0,2,period:ten", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			processes, err := loadProcesses(strings.NewReader(tt.workload))
+			if err == nil {
+				err = validateProcesses(processes)
+			}
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if p := processes[0]; p.Period != tt.wantPeriod || p.Deadline != tt.wantDeadline {
+				t.Errorf("Period, Deadline = %d, %d, want %d, %d", p.Period, p.Deadline, tt.wantPeriod, tt.wantDeadline)
+			}
+		})
+	}
+}
+
+func Test_analyzeSchedulability(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		workload     string
+		wantResponse []int64
+		wantRM       string
+		wantEDF      string
+	}{
+		{
+			name:         "above the Liu and Layland bound",
+			workload:     "1,1,0,1,period:4\n2,2,0,2,period:5\n3,3,0,3,period:10",
+			wantResponse: []int64{1, 3, 10},
+			wantRM:       verdictSchedulable,
+			wantEDF:      verdictSchedulable,
+		},
+		{
+			name:         "under the Liu and Layland bound",
+			workload:     "1,1,0,1,period:4\n2,1,0,2,period:5",
+			wantResponse: []int64{1, 2},
+			wantRM:       verdictSchedulable,
+			wantEDF:      verdictSchedulable,
+		},
+		{
+			name:         "EDF only",
+			workload:     "1,4,0,1,period:7\n2,2,0,2,period:5",
+			wantResponse: []int64{8, 2},
+			wantRM:       verdictUnschedulable,
+			wantEDF:      verdictSchedulable,
+		},
+		{
+			name:         "overloaded",
+			workload:     "1,2,0,1,period:4\n2,3,0,2,period:5",
+			wantResponse: []int64{2, 7},
+			wantRM:       verdictUnschedulable,
+			wantEDF:      verdictUnschedulable,
+		},
+		{
+			name:         "constrained deadlines miss",
+			workload:     "1,1,0,1,period:4,deadline:2\n2,2,0,2,period:6,deadline:3\n3,2,0,3,period:8,deadline:4",
+			wantResponse: []int64{1, 3, 5},
+			wantRM:       verdictUnschedulable,
+			wantEDF:      verdictUnschedulable,
+		},
+		{
+			name:         "constrained deadlines by processor demand",
+			workload:     "1,1,0,1,period:4,deadline:2\n2,2,0,2,period:6,deadline:5\n3,2,0,3,period:8,deadline:8",
+			wantResponse: []int64{1, 3, 6},
+			wantRM:       verdictSchedulable,
+			wantEDF:      verdictSchedulable,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			processes, err := loadProcesses(strings.NewReader(tt.workload))
+			if err != nil {
+				t.Fatal(err)
+			}
+			tasks := periodicTasks(processes)
+			if got := responseTimes(tasks); !reflect.DeepEqual(got, tt.wantResponse) {
+				t.Errorf("responseTimes() = %v, want %v", got, tt.wantResponse)
+			}
+			tests := analyzeSchedulability(tasks, timeBase{resolution: 1})
+			if got := policyVerdict(tests, "RM"); got != tt.wantRM {
+				t.Errorf("RM verdict = %s, want %s", got, tt.wantRM)
+			}
+			if got := policyVerdict(tests, "EDF"); got != tt.wantEDF {
+				t.Errorf("EDF verdict = %s, want %s", got, tt.wantEDF)
+			}
+		})
+	}
+}
+
+func Test_hyperperiod(t *testing.T) {
+	t.Parallel()
+	tasks := []Process{{Period: 4}, {Period: 6}, {Period: 10}}
+	if got, ok := hyperperiod(tasks, maxDemandHorizon); got != 60 || !ok {
+		t.Errorf("hyperperiod() = %d, %v, want 60, true", got, ok)
+	}
+	if _, ok := hyperperiod(tasks, 59); ok {
+		t.Errorf("hyperperiod() within 59, want beyond it")
+	}
+}
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// contentTypes are the HTTP content types of the output formats that are not plain text.
+var contentTypes = map[string]string{
+	"html":   "text/html; charset=utf-8",
+	"svg":    "image/svg+xml",
+	"png":    "image/png",
+	"chrome": "application/json",
+	"json":   "application/json",
+	"csv":    "text/csv; charset=utf-8",
+}
+
+// serverMetrics counts the simulations a server ran, for its /metrics endpoint.
+type serverMetrics struct {
+	mu       sync.Mutex
+	requests int64
+	failures int64
+	runs     map[string]int64   // by algorithm
+	seconds  map[string]float64 // total time spent simulating, by algorithm
+	lastWait map[string]float64 // average wait of the last run, by algorithm
+}
+
+func newServerMetrics() *serverMetrics {
+	return &serverMetrics{
+		runs:     make(map[string]int64),
+		seconds:  make(map[string]float64),
+		lastWait: make(map[string]float64),
+	}
+}
+
+// observe records one simulation run of an algorithm.
+func (m *serverMetrics) observe(algorithm string, elapsed time.Duration, s Schedule) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.runs[algorithm]++
+	m.seconds[algorithm] += elapsed.Seconds()
+	m.lastWait[algorithm] = s.AverageWait()
+}
+
+// request records one simulation request and whether it failed.
+func (m *serverMetrics) request(failed bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests++
+	if failed {
+		m.failures++
+	}
+}
+
+// write writes the metrics in the Prometheus text exposition format.
+func (m *serverMetrics) write(w io.Writer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, _ = fmt.Fprintln(w, "# HELP scheduler_requests_total Simulation requests received.")
+	_, _ = fmt.Fprintln(w, "# TYPE scheduler_requests_total counter")
+	_, _ = fmt.Fprintln(w, "scheduler_requests_total", m.requests)
+	_, _ = fmt.Fprintln(w, "# HELP scheduler_request_failures_total Simulation requests rejected or failed.")
+	_, _ = fmt.Fprintln(w, "# TYPE scheduler_request_failures_total counter")
+	_, _ = fmt.Fprintln(w, "scheduler_request_failures_total", m.failures)
+
+	names := make([]string, 0, len(m.runs))
+	for name := range m.runs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	series := []struct {
+		name, kind, help string
+		value            func(algorithm string) float64
+	}{
+		{"scheduler_runs_total", "counter", "Simulations run, by algorithm.", func(a string) float64 { return float64(m.runs[a]) }},
+		{"scheduler_run_seconds_total", "counter", "Time spent simulating, by algorithm.", func(a string) float64 { return m.seconds[a] }},
+		{"scheduler_last_average_wait", "gauge", "Average wait of the last simulation, by algorithm.", func(a string) float64 { return m.lastWait[a] }},
+	}
+	for _, sr := range series {
+		_, _ = fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", sr.name, sr.help, sr.name, sr.kind)
+		for _, name := range names {
+			_, _ = fmt.Fprintf(w, "%s{algorithm=%q} %g\n", sr.name, name, sr.value(name))
+		}
+	}
+}
+
+// maxRequestBody caps the bytes of a request body the server reads, so a client cannot make it
+// buffer and parse an arbitrarily large workload.
+const maxRequestBody = 1 << 20
+
+// bodyTooLarge reports whether err came from reading past maxRequestBody of a request body.
+func bodyTooLarge(err error) bool {
+	var tooLarge *http.MaxBytesError
+
+	return errors.As(err, &tooLarge)
+}
+
+// server simulates workloads posted to it over HTTP.
+type server struct {
+	metrics *serverMetrics
+	timeout time.Duration // longest a request may simulate, 0 for no limit
+}
+
+// handler routes GET / to the web dashboard, POST /run to the simulator, POST /simulate to its JSON
+// API and GET /metrics to the Prometheus metrics. Every request body is capped at maxRequestBody.
+func (sv *server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", sv.handleDashboard)
+	mux.HandleFunc("/run", sv.handleRun)
+	mux.HandleFunc("/simulate", sv.handleSimulate)
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		sv.metrics.write(w)
+	})
+
+	return limitBodies(mux)
+}
+
+// limitBodies caps the request bodies h reads at maxRequestBody, whatever the route.
+func limitBodies(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		req.Body = http.MaxBytesReader(w, req.Body, maxRequestBody)
+		h.ServeHTTP(w, req)
+	})
+}
+
+// handleRun schedules the workload CSV in the request body with the algorithms, format, tie-break
+// and seed given as query parameters, and responds with the report.
+func (sv *server) handleRun(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "POST a workload CSV", http.StatusMethodNotAllowed)
+		return
+	}
+	fail := func(err error) {
+		sv.metrics.request(true)
+		logs.Info("bad run request", "remote", req.RemoteAddr, "err", err)
+		status := http.StatusBadRequest
+		if bodyTooLarge(err) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		http.Error(w, err.Error(), status)
+	}
+
+	query := req.URL.Query()
+	get := func(key, fallback string) string {
+		if v := query.Get(key); v != "" {
+			return v
+		}
+		return fallback
+	}
+	selected, err := selectAlgorithms(get("algorithms", "all"))
+	if err != nil {
+		fail(err)
+		return
+	}
+	tieBreak, err := parseTieBreak(get("tie-break", string(TieBreakInput)))
+	if err != nil {
+		fail(err)
+		return
+	}
+	seed, err := strconv.ParseInt(get("seed", "1"), 10, 64)
+	if err != nil {
+		fail(fmt.Errorf("%w: seed: %v", ErrInvalidArgs, err))
+		return
+	}
+	r := Report{SlowdownBound: defaultSlowdownBound, Format: get("format", "text"), Compare: len(selected) > 1}
+	if _, ok := formats[r.Format]; !ok {
+		fail(fmt.Errorf("%w: unknown format %q", ErrInvalidArgs, r.Format))
+		return
+	}
+	processes, err := loadProcesses(req.Body)
+	if err != nil {
+		fail(err)
+		return
+	}
+	opts := Options{TieBreak: tieBreak, Seed: seed}
+	if err := validateProcesses(processes); err != nil {
+		fail(err)
+		return
+	}
+	if err := validateJobs(processes, opts); err != nil {
+		fail(err)
+		return
+	}
+
+	results, err := sv.simulate(req.Context(), req.RemoteAddr, processes, selected, opts)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	logs.Info("run", "remote", req.RemoteAddr, "processes", len(processes), "algorithms", len(selected), "format", r.Format)
+
+	if contentType, ok := contentTypes[r.Format]; ok {
+		w.Header().Set("Content-Type", contentType)
+	} else {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	}
+	_ = outputResults(w, results, r)
+}
+
+// simulate runs each algorithm over the processes for the client at remote, recording the runs and
+// the request in the metrics. The simulations stop when ctx, the request's, is cancelled, by the
+// client going away or the server's timeout.
+func (sv *server) simulate(ctx context.Context, remote string, processes []Process, selected []algorithm, opts Options) ([]result, error) {
+	if sv.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, sv.timeout)
+		defer cancel()
+	}
+	results := make([]result, 0, len(selected))
+	for _, a := range selected {
+		start := time.Now()
+		s, err := a.scheduleContext(ctx, processes, opts)
+		if err != nil {
+			sv.metrics.request(true)
+			logs.Warn("simulation cancelled", "remote", remote, "algorithm", a.name, "err", err)
+			return nil, fmt.Errorf("simulation cancelled: %w", err)
+		}
+		sv.metrics.observe(a.name, time.Since(start), s)
+		results = append(results, result{title: a.title, schedule: s})
+	}
+	sv.metrics.request(false)
+
+	return results, nil
+}
+
+// serveCommand runs the simulator as a long-lived HTTP service, and gRPC service with -grpc.
+func serveCommand(w io.Writer, args []string) error {
+	fs := newFlagSet("serve")
+	addr := fs.String("addr", ":8080", "address to listen on")
+	grpcAddr := fs.String("grpc", "", "also serve the gRPC API of proto/scheduler.proto on this address, e.g. :9090 (off when empty)")
+	timeout := fs.Duration("timeout", 10*time.Second, "longest a request may simulate before it is cancelled, 0 for no limit")
+	logging := addLogFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := logging(); err != nil {
+		return err
+	}
+
+	sv := &server{metrics: newServerMetrics(), timeout: *timeout}
+	errs := make(chan error, 2)
+	if *grpcAddr != "" {
+		lis, err := net.Listen("tcp", *grpcAddr)
+		if err != nil {
+			return err
+		}
+		gs := newGRPCServer(sv)
+		defer gs.Stop()
+		_, _ = fmt.Fprintf(w, "listening on %s: gRPC Scheduler service\n", *grpcAddr)
+		go func() { errs <- gs.Serve(lis) }()
+	}
+	_, _ = fmt.Fprintf(w, "listening on %s: GET / for the dashboard, POST /run, POST /simulate, GET /metrics\n", *addr)
+
+	hs := &http.Server{
+		Addr:              *addr,
+		Handler:           sv.handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       30 * time.Second,
+	}
+	go func() { errs <- hs.ListenAndServe() }()
+
+	return <-errs
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func Test_server(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer((&server{metrics: newServerMetrics()}).handler())
+	defer srv.Close()
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		want       string
+	}{
+		{
+			name:       "run",
+			method:     http.MethodPost,
+			path:       "/run?algorithms=fcfs,sjf&format=markdown",
+			body:       "1,5,0,2\n2,9,3,1\n",
+			wantStatus: http.StatusOK,
+			want:       "## Comparison",
+		},
+		{
+			name:       "bad workload",
+			method:     http.MethodPost,
+			path:       "/run",
+			body:       "1,five,0,2\n",
+			wantStatus: http.StatusBadRequest,
+			want:       "invalid args",
+		},
+		{
+			name:       "bad algorithm",
+			method:     http.MethodPost,
+			path:       "/run?algorithms=lottery",
+			body:       "1,5,0,2\n",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid workload",
+			method:     http.MethodPost,
+			path:       "/run",
+			body:       "1,0,0,2\n",
+			wantStatus: http.StatusBadRequest,
+			want:       "burst must be positive",
+		},
+		{
+			name:       "body too large",
+			method:     http.MethodPost,
+			path:       "/run",
+			body:       strings.Repeat("1,5,0,2\n", maxRequestBody/8+1),
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:       "template count too large",
+			method:     http.MethodPost,
+			path:       "/run",
+			body:       "template: burst=1 count=9000000000000000000\n",
+			wantStatus: http.StatusBadRequest,
+			want:       "template count",
+		},
+		{
+			name:       "dashboard",
+			method:     http.MethodGet,
+			path:       "/",
+			wantStatus: http.StatusOK,
+			want:       `<input type="checkbox" name="algorithms" value="rr" checked> Round-robin`,
+		},
+		{
+			name:       "post dashboard",
+			method:     http.MethodPost,
+			path:       "/",
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:       "not found",
+			method:     http.MethodGet,
+			path:       "/nope",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "get run",
+			method:     http.MethodGet,
+			path:       "/run",
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+	for _, tt := range tests {
+		req, err := http.NewRequest(tt.method, srv.URL+tt.path, strings.NewReader(tt.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if resp.StatusCode != tt.wantStatus {
+			t.Errorf("%s: status = %d, want %d", tt.name, resp.StatusCode, tt.wantStatus)
+		}
+		if !strings.Contains(string(body), tt.want) {
+			t.Errorf("%s: body = %s, want it to contain %q", tt.name, body, tt.want)
+		}
+	}
+
+	resp, err := http.Get(srv.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	for _, want := range []string{
+		"scheduler_requests_total 6\n",
+		"scheduler_request_failures_total 5\n",
+		"# TYPE scheduler_runs_total counter\n",
+		`scheduler_runs_total{algorithm="fcfs"} 1` + "\n",
+		`scheduler_last_average_wait{algorithm="sjf"} 1` + "\n",
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("metrics = %s, want it to contain %q", body, want)
+		}
+	}
+}
+
+func Test_server_contentTypes(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer((&server{metrics: newServerMetrics()}).handler())
+	defer srv.Close()
+
+	for format, want := range map[string]string{
+		"text": "text/plain; charset=utf-8",
+		"json": "application/json",
+		"csv":  "text/csv; charset=utf-8",
+	} {
+		resp, err := http.Post(srv.URL+"/run?algorithms=fcfs&format="+format, "text/csv", strings.NewReader("1,5,0,2\n"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+		if got := resp.Header.Get("Content-Type"); got != want {
+			t.Errorf("%s: Content-Type = %q, want %q", format, got, want)
+		}
+	}
+}
+
+func Test_limitBodies(t *testing.T) {
+	t.Parallel()
+	var err error
+	h := limitBodies(http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+		_, err = io.ReadAll(req.Body)
+	}))
+	for _, size := range []int{maxRequestBody, maxRequestBody + 1} {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/any", strings.NewReader(strings.Repeat("x", size))))
+		if got, want := bodyTooLarge(err), size > maxRequestBody; got != want {
+			t.Errorf("%d byte body: bodyTooLarge(%v) = %v, want %v", size, err, got, want)
+		}
+	}
+}
+
+func Test_server_cancelled(t *testing.T) {
+	t.Parallel()
+	sv := &server{metrics: newServerMetrics()}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel() // as if the client went away before the simulation completed
+	req := httptest.NewRequest(http.MethodPost, "/run?algorithms=rr", strings.NewReader("1,5,0,2\n2,9,3,1\n")).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	sv.handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+	if want := "context canceled"; !strings.Contains(rec.Body.String(), want) {
+		t.Errorf("body = %s, want it to contain %q", rec.Body.String(), want)
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// procStat is the part of a /proc/[pid]/stat record a workload snapshot needs.
+type procStat struct {
+	PID      int64
+	Comm     string
+	CPUTicks int64 // utime + stime, in clock ticks
+	Priority int64
+	Start    int64 // start time after boot, in clock ticks
+}
+
+// parseProcStat parses one /proc/[pid]/stat line. See proc(5) for the field layout.
+func parseProcStat(line string) (procStat, error) {
+	open, closing := strings.IndexByte(line, '('), strings.LastIndexByte(line, ')')
+	if open < 0 || closing < open {
+		return procStat{}, fmt.Errorf("%w: malformed stat line", ErrInvalidArgs)
+	}
+	pid, err := strconv.ParseInt(strings.TrimSpace(line[:open]), 10, 64)
+	if err != nil {
+		return procStat{}, fmt.Errorf("%w: stat pid", err)
+	}
+	// fields[0] is the state, field 3 of proc(5).
+	fields := strings.Fields(line[closing+1:])
+	if len(fields) < 20 {
+		return procStat{}, fmt.Errorf("%w: stat line for %d has %d fields", ErrInvalidArgs, pid, len(fields))
+	}
+	num := func(field int) (int64, error) {
+		return strconv.ParseInt(fields[field-3], 10, 64)
+	}
+
+	var s = procStat{PID: pid, Comm: line[open+1 : closing]}
+	utime, err := num(14)
+	if err != nil {
+		return procStat{}, fmt.Errorf("%w: stat utime", err)
+	}
+	stime, err := num(15)
+	if err != nil {
+		return procStat{}, fmt.Errorf("%w: stat stime", err)
+	}
+	if s.Priority, err = num(18); err != nil {
+		return procStat{}, fmt.Errorf("%w: stat priority", err)
+	}
+	if s.Start, err = num(22); err != nil {
+		return procStat{}, fmt.Errorf("%w: stat starttime", err)
+	}
+	s.CPUTicks = utime + stime
+
+	return s, nil
+}
+
+// snapshotWorkload turns two samples of the process table taken interval apart into processes given:
+// • the samples before and after the interval
+// • the interval's start time after boot, in clock ticks
+// • the number of clock ticks that make up one scheduler time unit
+// The CPU time used during the interval becomes the burst and processes started during the
+// interval arrive that far into it. Processes that did not run are left out.
+func snapshotWorkload(before, after []procStat, since, unit int64) []Process {
+	used := make(map[int64]int64, len(before))
+	for _, s := range before {
+		used[s.PID] = s.CPUTicks
+	}
+
+	processes := make([]Process, 0)
+	for _, s := range after {
+		ticks := s.CPUTicks - used[s.PID]
+		if ticks <= 0 {
+			continue
+		}
+		var arrival int64
+		if s.Start > since {
+			arrival = (s.Start - since) / unit
+		}
+		burst := (ticks + unit - 1) / unit
+		processes = append(processes, Process{
+			ProcessID:     s.PID,
+			ArrivalTime:   arrival,
+			BurstDuration: burst,
+			Priority:      s.Priority,
+		})
+	}
+	sort.SliceStable(processes, func(i, j int) bool {
+		if processes[i].ArrivalTime != processes[j].ArrivalTime {
+			return processes[i].ArrivalTime < processes[j].ArrivalTime
+		}
+		return processes[i].ProcessID < processes[j].ProcessID
+	})
+
+	return processes
+}
+
+// snapshotCommand samples the live process table twice and writes the CPU used in between as a
+// workload CSV to w.
+func snapshotCommand(w io.Writer, args []string) error {
+	fs := newFlagSet("snapshot")
+	interval := fs.Duration("interval", time.Second, "how long to measure CPU usage for")
+	unit := fs.Int64("unit", 1, "clock ticks (usually 10ms) per time unit")
+	logging := addLogFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := logging(); err != nil {
+		return err
+	}
+	if *unit <= 0 || *interval <= 0 {
+		return fmt.Errorf("%w: unit and interval must be positive", ErrInvalidArgs)
+	}
+
+	since, before, err := readProcStats()
+	if err != nil {
+		return err
+	}
+	time.Sleep(*interval)
+	_, after, err := readProcStats()
+	if err != nil {
+		return err
+	}
+
+	return writeProcesses(w, snapshotWorkload(before, after, since, *unit))
+}
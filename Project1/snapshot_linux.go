@@ -0,0 +1,48 @@
+//go:build linux
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// readProcStats reads the stat record of every process in /proc, returning the system uptime in
+// clock ticks alongside. Processes that exit while being read are skipped.
+func readProcStats() (int64, []procStat, error) {
+	uptime, err := os.ReadFile("/proc/uptime")
+	if err != nil {
+		return 0, nil, fmt.Errorf("%v: error reading uptime", err)
+	}
+	fields := strings.Fields(string(uptime))
+	if len(fields) == 0 {
+		return 0, nil, fmt.Errorf("%w: empty /proc/uptime", ErrInvalidArgs)
+	}
+	seconds, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, nil, fmt.Errorf("%w: parsing uptime", err)
+	}
+
+	paths, err := filepath.Glob("/proc/[0-9]*/stat")
+	if err != nil {
+		return 0, nil, err
+	}
+	stats := make([]procStat, 0, len(paths))
+	for _, p := range paths {
+		b, err := os.ReadFile(p)
+		if err != nil {
+			continue
+		}
+		s, err := parseProcStat(string(b))
+		if err != nil {
+			return 0, nil, err
+		}
+		stats = append(stats, s)
+	}
+
+	// USER_HZ is 100 on every mainstream Linux architecture.
+	return int64(seconds * 100), stats, nil
+}
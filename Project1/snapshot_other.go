@@ -0,0 +1,10 @@
+//go:build !linux
+
+package main
+
+import "fmt"
+
+// readProcStats is only supported where /proc exists.
+func readProcStats() (int64, []procStat, error) {
+	return 0, nil, fmt.Errorf("%w: snapshot requires Linux /proc", ErrInvalidArgs)
+}
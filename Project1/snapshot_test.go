@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func Test_parseProcStat(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		line    string
+		want    procStat
+		wantErr error
+	}{
+		{
+			name:    "malformed",
+			line:    "42 cat R",
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name:    "too short",
+			line:    "42 (cat) R 1 2 3",
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name: "comm with spaces and parens",
+			line: "3204 (tmux: (server)) R 3200 3204 3200 0 -1 4194304 84 0 0 0 7 5 0 0 20 0 1 0 30799 2703360 305\n",
+			want: procStat{PID: 3204, Comm: "tmux: (server)", CPUTicks: 12, Priority: 20, Start: 30799},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseProcStat(tt.line)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseProcStat() = %v, want %v", got, tt.want)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_snapshotWorkload(t *testing.T) {
+	t.Parallel()
+	before := []procStat{
+		{PID: 1, CPUTicks: 100, Priority: 20, Start: 5},
+		{PID: 7, CPUTicks: 40, Priority: 39, Start: 10},
+	}
+	after := []procStat{
+		{PID: 1, CPUTicks: 100, Priority: 20, Start: 5},
+		{PID: 7, CPUTicks: 45, Priority: 39, Start: 10},
+		{PID: 9, CPUTicks: 3, Priority: 0, Start: 1004},
+	}
+	want := []Process{
+		{ProcessID: 7, ArrivalTime: 0, BurstDuration: 3, Priority: 39},
+		{ProcessID: 9, ArrivalTime: 2, BurstDuration: 2, Priority: 0},
+	}
+	if got := snapshotWorkload(before, after, 1000, 2); !reflect.DeepEqual(got, want) {
+		t.Errorf("snapshotWorkload() = %v, want %v", got, want)
+	}
+}
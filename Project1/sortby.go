@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// sortKeys map the -sort-by column names to each process's value in that column.
+var sortKeys = map[string]func(s Schedule, r Report) []float64{
+	"id": func(s Schedule, _ Report) []float64 {
+		return processValues(s, func(p Process) int64 { return p.ProcessID })
+	},
+	"priority": func(s Schedule, _ Report) []float64 {
+		return processValues(s, func(p Process) int64 { return p.Priority })
+	},
+	"burst": func(s Schedule, _ Report) []float64 {
+		return processValues(s, func(p Process) int64 { return p.BurstDuration })
+	},
+	"arrival": func(s Schedule, _ Report) []float64 {
+		return processValues(s, func(p Process) int64 { return p.ArrivalTime })
+	},
+	"wait":       func(s Schedule, _ Report) []float64 { return toFloats(s.Wait) },
+	"turnaround": func(s Schedule, _ Report) []float64 { return toFloats(s.Turnaround) },
+	"normalized": func(s Schedule, _ Report) []float64 { return s.NormalizedTurnaround() },
+	"slowdown":   func(s Schedule, r Report) []float64 { return s.BoundedSlowdown(r.SlowdownBound) },
+	"response":   func(s Schedule, _ Report) []float64 { return toFloats(s.Response()) },
+	"switches":   func(s Schedule, _ Report) []float64 { return toFloats(s.SwitchesPerProcess()) },
+	"completion": func(s Schedule, _ Report) []float64 { return toFloats(s.Completion) },
+}
+
+// sortAliases are the other names a sort key goes by.
+var sortAliases = map[string]string{"pid": "id", "exit": "completion"}
+
+func processValues(s Schedule, value func(p Process) int64) []float64 {
+	values := make([]float64, len(s.Processes))
+	for i, p := range s.Processes {
+		values[i] = float64(value(p))
+	}
+
+	return values
+}
+
+// parseSortBy parses a "column" or "column:asc|desc" -sort-by value.
+func parseSortBy(spec string) (key string, desc bool, err error) {
+	key, direction, _ := strings.Cut(strings.ToLower(spec), ":")
+	if alias, ok := sortAliases[key]; ok {
+		key = alias
+	}
+	if _, ok := sortKeys[key]; !ok {
+		names := make([]string, 0, len(sortKeys))
+		for name := range sortKeys {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return "", false, fmt.Errorf("%w: unknown sort column %q, want one of %s", ErrInvalidArgs, key, strings.Join(names, ", "))
+	}
+	switch direction {
+	case "", "asc":
+	case "desc":
+		desc = true
+	default:
+		return "", false, fmt.Errorf("%w: unknown sort direction %q, want asc or desc", ErrInvalidArgs, direction)
+	}
+
+	return key, desc, nil
+}
+
+// rowOrder returns the indexes of the processes in the order the report sorts the schedule table by,
+// input order when it does not. Ties keep input order.
+func (s Schedule) rowOrder(r Report) []int {
+	order := make([]int, len(s.Processes))
+	for i := range order {
+		order[i] = i
+	}
+	if r.SortBy == "" {
+		return order
+	}
+	key, desc, err := parseSortBy(r.SortBy)
+	if err != nil {
+		return order
+	}
+	values := sortKeys[key](s, r)
+	sort.SliceStable(order, func(i, j int) bool {
+		if desc {
+			return values[order[i]] > values[order[j]]
+		}
+		return values[order[i]] < values[order[j]]
+	})
+
+	return order
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func Test_parseSortBy(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		spec     string
+		wantKey  string
+		wantDesc bool
+		wantErr  error
+	}{
+		{spec: "wait", wantKey: "wait"},
+		{spec: "wait:desc", wantKey: "wait", wantDesc: true},
+		{spec: "Turnaround:ASC", wantKey: "turnaround"},
+		{spec: "pid", wantKey: "id"},
+		{spec: "exit:desc", wantKey: "completion", wantDesc: true},
+		{spec: "color", wantErr: ErrInvalidArgs},
+		{spec: "wait:up", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.spec, func(t *testing.T) {
+			t.Parallel()
+			key, desc, err := parseSortBy(tt.spec)
+			if key != tt.wantKey || desc != tt.wantDesc {
+				t.Errorf("parseSortBy() = %q, %v, want %q, %v", key, desc, tt.wantKey, tt.wantDesc)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSchedule_rowOrder(t *testing.T) {
+	t.Parallel()
+	s := fcfs([]Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	}, Options{})
+	tests := []struct {
+		sortBy string
+		want   []int
+	}{
+		{sortBy: "", want: []int{0, 1, 2}},
+		{sortBy: "wait:desc", want: []int{2, 1, 0}},
+		{sortBy: "priority", want: []int{1, 0, 2}},
+		{sortBy: "burst:desc", want: []int{1, 2, 0}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.sortBy, func(t *testing.T) {
+			t.Parallel()
+			if got := s.rowOrder(Report{SortBy: tt.sortBy}); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rowOrder() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
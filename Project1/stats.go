@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+)
+
+// summaryStats describes the distribution of a per-process metric.
+type summaryStats struct {
+	Mean   float64
+	Stddev float64 // population standard deviation
+	Median float64
+	P95    float64
+	Min    float64
+	Max    float64
+}
+
+// describe summarizes values; an empty slice yields all zeros.
+func describe(values []float64) summaryStats {
+	if len(values) == 0 {
+		return summaryStats{}
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	var sum, squares float64
+	for _, v := range sorted {
+		sum += v
+	}
+	mean := sum / float64(len(sorted))
+	for _, v := range sorted {
+		squares += (v - mean) * (v - mean)
+	}
+
+	return summaryStats{
+		Mean:   mean,
+		Stddev: math.Sqrt(squares / float64(len(sorted))),
+		Median: percentile(sorted, 50),
+		P95:    percentile(sorted, 95),
+		Min:    sorted[0],
+		Max:    sorted[len(sorted)-1],
+	}
+}
+
+// percentile returns the p-th percentile of sorted values, interpolating linearly between ranks.
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := p / 100 * float64(len(sorted)-1)
+	lo, hi := int(math.Floor(rank)), int(math.Ceil(rank))
+
+	return sorted[lo] + (sorted[hi]-sorted[lo])*(rank-float64(lo))
+}
+
+// statsHeader names the distribution statistics table columns.
+var statsHeader = []string{"Metric", "Mean", "Stddev", "Median", "p95", "Max"}
+
+// statsRows formats the distributions of wait, turnaround and response time, one row per metric.
+func (s Schedule) statsRows() [][]string {
+	metrics := []struct {
+		name   string
+		values []float64
+	}{
+		{name: "Wait", values: toFloats(s.Wait)},
+		{name: "Turnaround", values: toFloats(s.Turnaround)},
+		{name: "Response", values: toFloats(s.Response())},
+	}
+	rows := make([][]string, len(metrics))
+	for i, m := range metrics {
+		for j := range m.values {
+			m.values[j] = s.inUnits(m.values[j])
+		}
+		d := describe(m.values)
+		rows[i] = []string{
+			m.name,
+			s.formatUnits(d.Mean),
+			s.formatUnits(d.Stddev),
+			s.formatUnits(d.Median),
+			s.formatUnits(d.P95),
+			s.formatUnits(d.Max),
+		}
+	}
+
+	return rows
+}
+
+// outputStats outputs a table of the distributions of wait, turnaround and response time.
+func outputStats(w io.Writer, s Schedule, r Report) {
+	_, _ = fmt.Fprintln(w, "Distribution statistics")
+	outputTable(w, r.TableStyle, statsHeader, s.statsRows(), nil, nil)
+	_, _ = fmt.Fprintln(w)
+}
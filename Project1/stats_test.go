@@ -0,0 +1,44 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func Test_describe(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		values []float64
+		want   summaryStats
+	}{
+		{name: "empty", values: nil, want: summaryStats{}},
+		{name: "single", values: []float64{4}, want: summaryStats{Mean: 4, Median: 4, P95: 4, Max: 4}},
+		{
+			name:   "unsorted",
+			values: []float64{8, 0, 2},
+			want:   summaryStats{Mean: 10.0 / 3, Stddev: math.Sqrt(104.0 / 9), Median: 2, P95: 7.4, Max: 8},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := describe(tt.values)
+			for _, c := range []struct {
+				name      string
+				got, want float64
+			}{
+				{"Mean", got.Mean, tt.want.Mean},
+				{"Stddev", got.Stddev, tt.want.Stddev},
+				{"Median", got.Median, tt.want.Median},
+				{"P95", got.P95, tt.want.P95},
+				{"Max", got.Max, tt.want.Max},
+			} {
+				if math.Abs(c.got-c.want) > 1e-9 {
+					t.Errorf("describe().%s = %v, want %v", c.name, c.got, c.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// stepFrame is one point in time the step-through stops at, with the events that happened then.
+type stepFrame struct {
+	Time   int64
+	Events []schedEvent
+}
+
+// stepFrames lists the times to stop at: every time something happens, or every time unit from the
+// first arrival to the last completion when byTick is set.
+func (s Schedule) stepFrames(byTick bool) []stepFrame {
+	events := s.Events()
+	frames := make([]stepFrame, 0)
+	if byTick {
+		for t := s.FirstArrival(); t <= s.LastCompletion(); t++ {
+			frames = append(frames, stepFrame{Time: t})
+		}
+	}
+	for _, e := range events {
+		i := len(frames) - 1
+		if byTick {
+			i = int(e.Time - s.FirstArrival())
+		} else if i < 0 || frames[i].Time != e.Time {
+			frames = append(frames, stepFrame{Time: e.Time})
+			i++
+		}
+		frames[i].Events = append(frames[i].Events, e)
+	}
+
+	return frames
+}
+
+// clipGantt returns the part of a GANTT chart before time t.
+func clipGantt(gantt []TimeSlice, t int64) []TimeSlice {
+	clipped := make([]TimeSlice, 0, len(gantt))
+	for _, ts := range gantt {
+		if ts.Start >= t {
+			break
+		}
+		if ts.Stop > t {
+			ts.Stop = t
+		}
+		clipped = append(clipped, ts)
+	}
+
+	return clipped
+}
+
+// outputStepFrame outputs the state of the schedule at one frame: the events of that time, the
+// running process, the ready queue and the GANTT chart so far.
+func outputStepFrame(w io.Writer, title string, s Schedule, frames []stepFrame, i int, r Report) {
+	frame := frames[i]
+	_, _ = fmt.Fprintf(w, "%s: time %s (step %d of %d)\n\n", title, s.formatTime(frame.Time), i+1, len(frames))
+	for _, e := range frame.Events {
+		line := fmt.Sprintf("  %-14s  process %d", e.Kind, e.PID)
+		if e.Detail != "" {
+			line += " (" + e.Detail + ")"
+		}
+		_, _ = fmt.Fprintln(w, line)
+	}
+	if len(frame.Events) > 0 {
+		_, _ = fmt.Fprintln(w)
+	}
+
+	running := "IDLE"
+	if pid := s.running(frame.Time); pid != idlePID {
+		running = fmt.Sprint(pid)
+		if r.Color {
+			running = colorPID(pid, running)
+		}
+	}
+	ready := make([]string, 0)
+	for _, pid := range s.readyQueue(frame.Time) {
+		label := fmt.Sprint(pid)
+		if r.Color {
+			label = colorPID(pid, label)
+		}
+		ready = append(ready, label)
+	}
+	_, _ = fmt.Fprintln(w, "Running:    ", running)
+	_, _ = fmt.Fprintln(w, "Ready queue:", strings.Join(ready, ", "))
+	_, _ = fmt.Fprintln(w)
+
+	if gantt := clipGantt(s.timeline(), frame.Time); len(gantt) > 0 {
+		outputGantt(w, gantt, s.timeBase(), r)
+	}
+}
+
+// stepThrough shows the schedule one frame at a time, reading a command per line from in: an empty
+// line or "n" advances, "b" goes back and "q" (or the end of the input) quits. clear redraws every
+// frame on a cleared terminal screen.
+func stepThrough(w io.Writer, in io.Reader, title string, s Schedule, byTick, clear bool, r Report) {
+	frames := s.stepFrames(byTick)
+	if len(frames) == 0 {
+		return
+	}
+	input := bufio.NewScanner(in)
+	for i := 0; ; {
+		if clear {
+			_, _ = io.WriteString(w, "\x1b[H\x1b[2J")
+		}
+		outputStepFrame(w, title, s, frames, i, r)
+		_, _ = io.WriteString(w, "[Enter] next  [b] back  [q] quit: ")
+		if !input.Scan() {
+			_, _ = fmt.Fprintln(w)
+			return
+		}
+		switch strings.TrimSpace(input.Text()) {
+		case "", "n":
+			if i < len(frames)-1 {
+				i++
+			}
+		case "b":
+			if i > 0 {
+				i--
+			}
+		case "q":
+			return
+		}
+		if !clear {
+			_, _ = fmt.Fprintln(w)
+		}
+	}
+}
+
+// playbackTick is how long a time unit of a workload without a unit length lasts when played back at
+// normal speed.
+const playbackTick = time.Second
+
+// parsePlaybackSpeed parses a playback speed such as "10x" or "0.5".
+func parsePlaybackSpeed(field string) (float64, error) {
+	speed, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(field), "x"), 64)
+	if err != nil || speed <= 0 {
+		return 0, fmt.Errorf("%w: playback speed %q must be a positive factor, e.g. 10x", ErrInvalidArgs, field)
+	}
+
+	return speed, nil
+}
+
+// play replays the schedule tick by tick in real time sped up by speed, redrawing each tick's frame
+// on a cleared screen when clear is set. A time unit lasts its unit length, or playbackTick for
+// abstract units. sleep waits between frames.
+func play(w io.Writer, title string, s Schedule, speed float64, clear bool, r Report, sleep func(time.Duration)) {
+	frames := s.stepFrames(true)
+	unit := s.Unit
+	if unit == 0 {
+		unit = playbackTick
+	}
+	tick := time.Duration(float64(unit) / float64(s.timeBase().ticksPerUnit()) / speed)
+	for i := range frames {
+		if clear {
+			_, _ = io.WriteString(w, "\x1b[H\x1b[2J")
+		} else if i > 0 {
+			_, _ = fmt.Fprintln(w)
+		}
+		outputStepFrame(w, title, s, frames, i, r)
+		if i < len(frames)-1 {
+			sleep(tick)
+		}
+	}
+}
+
+// stepCommand steps through one algorithm's schedule of a workload event by event, or tick by tick,
+// reading commands from standard input, or plays it back in real time.
+func stepCommand(w io.Writer, args []string) error {
+	fs := newFlagSet("step")
+	name := fs.String("algorithm", "rr", "algorithm to step through")
+	byTick := fs.Bool("ticks", false, "stop at every time unit instead of every event")
+	playback := fs.Bool("play", false, "replay the schedule in real time instead of stepping through it")
+	speedFlag := fs.String("speed", "1x", "playback speed of -play, e.g. 10x; a time unit lasts its -time-unit or a second")
+	noColor := fs.Bool("no-color", false, "never color the output (it is only colored on a terminal anyway)")
+	options := addOptionFlags(fs)
+	logging := addLogFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := logging(); err != nil {
+		return err
+	}
+	opts, err := options()
+	if err != nil {
+		return err
+	}
+	speed, err := parsePlaybackSpeed(*speedFlag)
+	if err != nil {
+		return err
+	}
+	selected, err := selectAlgorithms(*name)
+	if err != nil {
+		return err
+	}
+	if len(selected) != 1 {
+		return fmt.Errorf("%w: must give one algorithm to step through", ErrInvalidArgs)
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: must give a scheduling file to step through", ErrInvalidArgs)
+	}
+
+	processes, err := loadWorkload(fs.Arg(0), opts.timeBase())
+	if err != nil {
+		return err
+	}
+	if err := validateJobs(processes, opts); err != nil {
+		return err
+	}
+
+	tty := isTerminal(os.Stdout)
+	r := Report{Color: tty && !*noColor && os.Getenv("NO_COLOR") == ""}
+	if *playback {
+		play(w, selected[0].title, selected[0].schedule(processes, opts), speed, tty, r, time.Sleep)
+		return nil
+	}
+	stepThrough(w, os.Stdin, selected[0].title, selected[0].schedule(processes, opts), *byTick, tty, r)
+
+	return nil
+}
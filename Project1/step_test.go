@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSchedule_stepFrames(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1, Priority: 1},
+	}
+	tests := []struct {
+		name   string
+		byTick bool
+		want   []int64
+		events []int
+	}{
+		{name: "events", want: []int64{0, 1, 2, 3}, events: []int{2, 3, 2, 1}},
+		{name: "ticks", byTick: true, want: []int64{0, 1, 2, 3}, events: []int{2, 3, 2, 1}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			frames := preemptivePriority(processes, Options{}).stepFrames(tt.byTick)
+			var (
+				got    []int64
+				events []int
+			)
+			for _, f := range frames {
+				got = append(got, f.Time)
+				events = append(events, len(f.Events))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("frame times = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(events, tt.events) {
+				t.Errorf("frame events = %v, want %v", events, tt.events)
+			}
+		})
+	}
+}
+
+func Test_clipGantt(t *testing.T) {
+	t.Parallel()
+	gantt := []TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 3, Stop: 5}, {PID: 1, Start: 5, Stop: 7}}
+	tests := []struct {
+		name string
+		t    int64
+		want []TimeSlice
+	}{
+		{name: "start", t: 0, want: []TimeSlice{}},
+		{name: "mid slice", t: 4, want: []TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 3, Stop: 4}}},
+		{name: "end", t: 7, want: gantt},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := clipGantt(gantt, tt.t); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("clipGantt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_stepThrough(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1, Priority: 1},
+	}
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{name: "end of input", input: "", want: []string{"time 0"}},
+		{name: "next and back", input: "\nb\nq\n", want: []string{"time 0", "time 1", "time 0"}},
+		{name: "past the end", input: "n\nn\nn\nn\nq\n", want: []string{"time 0", "time 1", "time 2", "time 3", "time 3"}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			s := preemptivePriority(processes, Options{})
+			stepThrough(&w, strings.NewReader(tt.input), "Priority", s, false, false, Report{})
+			var got []string
+			for _, line := range strings.Split(w.String(), "\n") {
+				if strings.HasPrefix(line, "Priority: ") {
+					fields := strings.Fields(line)
+					got = append(got, fields[1]+" "+fields[2])
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("frames shown = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_play(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1, Priority: 1},
+	}
+	tests := []struct {
+		name      string
+		opts      Options
+		speed     float64
+		wantSleep time.Duration
+	}{
+		{name: "abstract units", speed: 10, wantSleep: playbackTick / 10},
+		{name: "unit length", opts: Options{Unit: time.Millisecond}, speed: 2, wantSleep: 500 * time.Microsecond},
+		{name: "resolution", opts: Options{Resolution: 4}, speed: 1, wantSleep: playbackTick / 4},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var (
+				w      bytes.Buffer
+				sleeps []time.Duration
+			)
+			s := algorithms[2].schedule(processes, tt.opts)
+			play(&w, "Priority", s, tt.speed, false, Report{}, func(d time.Duration) { sleeps = append(sleeps, d) })
+			frames := strings.Count(w.String(), "Priority: time")
+			if frames != len(s.stepFrames(true)) || len(sleeps) != frames-1 {
+				t.Fatalf("played %d frames with %d sleeps, want %d frames", frames, len(sleeps), len(s.stepFrames(true)))
+			}
+			for _, d := range sleeps {
+				if d != tt.wantSleep {
+					t.Errorf("slept %v per tick, want %v", d, tt.wantSleep)
+				}
+			}
+			if !strings.Contains(w.String(), "Running:     2") {
+				t.Errorf("play() = %s, want process 2 shown running", w.String())
+			}
+		})
+	}
+}
+
+func Test_parsePlaybackSpeed(t *testing.T) {
+	t.Parallel()
+	for field, want := range map[string]float64{"10x": 10, "0.5x": 0.5, "3": 3} {
+		if got, err := parsePlaybackSpeed(field); err != nil || got != want {
+			t.Errorf("parsePlaybackSpeed(%q) = %v, %v, want %v", field, got, err, want)
+		}
+	}
+	for _, field := range []string{"fast", "0x", "-2x"} {
+		if _, err := parsePlaybackSpeed(field); !errors.Is(err, ErrInvalidArgs) {
+			t.Errorf("parsePlaybackSpeed(%q) error = %v, want %v", field, err, ErrInvalidArgs)
+		}
+	}
+}
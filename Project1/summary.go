@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+)
+
+// Exit codes, so scripts can branch on the outcome of a run.
+const (
+	exitOK           = 0
+	exitError        = 1 // anything else, such as an unreadable file
+	exitInvalid      = 2 // bad flags or arguments, or a workload that does not parse
+	exitValidation   = 3 // a workload that parses but cannot be scheduled
+	exitDeadlineMiss = 4 // a process missed its deadline
+	exitMismatch     = 5 // verify found the schedules differ from the expected results
+)
+
+// exitStatuses name the exit codes in the JSON summary.
+var exitStatuses = map[int]string{
+	exitOK:           "ok",
+	exitError:        "error",
+	exitInvalid:      "invalid",
+	exitValidation:   "validation failed",
+	exitDeadlineMiss: "deadline missed",
+	exitMismatch:     "mismatch",
+}
+
+// exitCode maps an error to the exit code of its kind.
+func exitCode(err error) int {
+	var parseErr *csv.ParseError
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+		return exitOK
+	case errors.Is(err, ErrDeadlineMiss):
+		return exitDeadlineMiss
+	case errors.Is(err, ErrMismatch):
+		return exitMismatch
+	case errors.Is(err, ErrValidation):
+		return exitValidation
+	case errors.Is(err, ErrInvalidArgs), errors.As(err, &parseErr):
+		return exitInvalid
+	default:
+		return exitError
+	}
+}
+
+// exit logs err, if any, and exits with its exit code.
+func exit(err error) {
+	code := exitCode(err)
+	if code == exitOK {
+		return
+	}
+	logs.Error(err.Error(), "exit_code", code)
+	os.Exit(code)
+}
+
+// runSummary is the one-line JSON outcome of a run.
+type runSummary struct {
+	Status   string             `json:"status"`
+	ExitCode int                `json:"exit_code"`
+	Error    string             `json:"error,omitempty"`
+	Results  []algorithmSummary `json:"results,omitempty"`
+}
+
+// algorithmSummary holds the headline metrics of one result.
+type algorithmSummary struct {
+	Algorithm         string  `json:"algorithm"`
+	Processes         int     `json:"processes"`
+	AverageWait       float64 `json:"average_wait"`
+	AverageTurnaround float64 `json:"average_turnaround"`
+	AverageResponse   float64 `json:"average_response"`
+	Makespan          float64 `json:"makespan"`
+	IdleTime          float64 `json:"idle_time"`
+	Throughput        float64 `json:"throughput"`
+	ContextSwitches   int     `json:"context_switches"`
+	DeadlineMisses    *int    `json:"deadline_misses,omitempty"` // only when the workload has deadlines
+	MissRatio         float64 `json:"miss_ratio,omitempty"`
+	Energy            float64 `json:"energy,omitempty"` // only with an energy model
+}
+
+// newRunSummary summarizes the results of a run that ended with err.
+func newRunSummary(results []result, err error) runSummary {
+	code := exitCode(err)
+	summary := runSummary{Status: exitStatuses[code], ExitCode: code}
+	if err != nil {
+		summary.Error = err.Error()
+	}
+	for _, res := range results {
+		s := res.schedule
+		as := algorithmSummary{
+			Algorithm:         res.title,
+			Processes:         len(s.Processes),
+			AverageWait:       s.AverageWait(),
+			AverageTurnaround: s.AverageTurnaround(),
+			AverageResponse:   s.AverageResponse(),
+			Makespan:          s.inUnits(float64(s.Makespan())),
+			IdleTime:          s.inUnits(float64(s.IdleTime())),
+			Throughput:        s.Throughput(),
+			ContextSwitches:   s.ContextSwitches(),
+			Energy:            s.EnergyUse(),
+		}
+		if s.hasDeadlines() {
+			misses := s.DeadlineMisses()
+			as.DeadlineMisses, as.MissRatio = &misses, s.MissRatio()
+		}
+		summary.Results = append(summary.Results, as)
+	}
+
+	return summary
+}
+
+// writeRunSummary writes the JSON summary of a run that ended with err to the report's summary file
+// descriptor if the report asks for one, and returns err.
+func writeRunSummary(r Report, results []result, err error) error {
+	if !r.JSONSummary {
+		return err
+	}
+	var f *os.File
+	switch r.SummaryFD {
+	case 1:
+		f = os.Stdout
+	case 2:
+		f = os.Stderr
+	default:
+		if f = os.NewFile(uintptr(r.SummaryFD), "summary"); f == nil {
+			return err
+		}
+		defer f.Close()
+	}
+	if encodeErr := json.NewEncoder(f).Encode(newRunSummary(results, err)); encodeErr != nil && err == nil {
+		return encodeErr
+	}
+
+	return err
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func Test_exitCode(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "success", err: nil, want: exitOK},
+		{name: "help", err: flag.ErrHelp, want: exitOK},
+		{name: "invalid args", err: fmt.Errorf("%w: line 2", ErrInvalidArgs), want: exitInvalid},
+		{name: "CSV", err: fmt.Errorf("%w: reading CSV", &csv.ParseError{Err: csv.ErrQuote}), want: exitInvalid},
+		{name: "validation", err: fmt.Errorf("%w: duplicate ID", ErrValidation), want: exitValidation},
+		{name: "deadline", err: ErrDeadlineMiss, want: exitDeadlineMiss},
+		{name: "mismatch", err: fmt.Errorf("%w: 2 differences", ErrMismatch), want: exitMismatch},
+		{name: "other", err: errors.New("disk on fire"), want: exitError},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := exitCode(tt.err); got != tt.want {
+				t.Errorf("exitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_newRunSummary(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+	}
+	tests := []struct {
+		name    string
+		results []result
+		err     error
+		want    runSummary
+	}{
+		{
+			name:    "success",
+			results: []result{{title: "First-come, first-serve", schedule: fcfs(processes, Options{})}},
+			want: runSummary{Status: "ok", Results: []algorithmSummary{{
+				Algorithm:         "First-come, first-serve",
+				Processes:         2,
+				AverageWait:       1,
+				AverageTurnaround: 8,
+				AverageResponse:   1,
+				Makespan:          14,
+				Throughput:        1.0 / 7,
+				ContextSwitches:   1,
+			}}},
+		},
+		{
+			name: "validation failure",
+			err:  fmt.Errorf("%w: process 1: burst must be positive", ErrValidation),
+			want: runSummary{
+				Status:   "validation failed",
+				ExitCode: exitValidation,
+				Error:    "invalid workload: process 1: burst must be positive",
+			},
+		},
+		{
+			name: "empty workload",
+			err:  validateProcesses(nil),
+			want: runSummary{
+				Status:   "invalid",
+				ExitCode: exitInvalid,
+				Error:    "invalid args: no processes to schedule",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := newRunSummary(tt.results, tt.err)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newRunSummary() = %+v, want %+v", got, tt.want)
+			}
+			if _, err := json.Marshal(got); err != nil {
+				t.Errorf("encoding the summary: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"io"
+)
+
+// Layout of the SVG GANTT chart, in pixels.
+const (
+	svgLabelWidth = 170
+	svgPlotWidth  = 800
+	svgLaneHeight = 28
+	svgRowHeight  = svgLaneHeight + 52 // title above, axis below
+)
+
+// ganttTicks returns about ten evenly spaced times from start to stop, always ending with stop, for a
+// chart's time axis.
+func ganttTicks(start, stop int64) []int64 {
+	step := (stop - start + 9) / 10
+	if step < 1 {
+		step = 1
+	}
+	var ticks []int64
+	for t := start; t <= stop; t += step {
+		ticks = append(ticks, t)
+	}
+	if ticks[len(ticks)-1] != stop {
+		ticks = append(ticks, stop)
+	}
+
+	return ticks
+}
+
+// ganttSpan returns the earliest start and latest stop over the results' timelines.
+func ganttSpan(results []result) (start, stop int64) {
+	first := true
+	for _, res := range results {
+		for _, ts := range res.schedule.timeline() {
+			if first || ts.Start < start {
+				start = ts.Start
+			}
+			if first || ts.Stop > stop {
+				stop = ts.Stop
+			}
+			first = false
+		}
+	}
+
+	return start, stop
+}
+
+// outputSVG outputs the results' GANTT charts as one SVG image, one lane per result on a shared,
+// proportional time axis, with every process in its own color.
+func outputSVG(w io.Writer, results []result, _ Report) error {
+	start, stop := ganttSpan(results)
+	scale := float64(svgPlotWidth)
+	if stop > start {
+		scale /= float64(stop - start)
+	}
+	x := func(t int64) float64 { return svgLabelWidth + float64(t-start)*scale }
+	width, height := svgLabelWidth+svgPlotWidth+20, len(results)*svgRowHeight+10
+
+	_, _ = fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n",
+		width, height, width, height)
+	_, _ = fmt.Fprintf(w, `<rect width="%d" height="%d" fill="#fff"/>`+"\n", width, height)
+	for i, res := range results {
+		top := i*svgRowHeight + 24
+		_, _ = fmt.Fprintf(w, `<text x="10" y="%d" font-weight="bold">%s</text>`+"\n",
+			top+svgLaneHeight/2+4, html.EscapeString(res.title))
+		for _, ts := range res.schedule.timeline() {
+			label, fill := sliceLabel(ts.PID), pidColor(ts.PID)
+			textColor := "#fff"
+			if ts.PID == idlePID || ts.PID == switchPID {
+				textColor = "#666"
+			}
+			_, _ = fmt.Fprintf(w, `<g><title>%s: %s–%s</title><rect x="%.2f" y="%d" width="%.2f" height="%d" fill="%s" stroke="#fff"/>`,
+				label, res.schedule.formatTime(ts.Start), res.schedule.formatTime(ts.Stop), x(ts.Start), top, float64(ts.Stop-ts.Start)*scale, svgLaneHeight, fill)
+			_, _ = fmt.Fprintf(w, `<text x="%.2f" y="%d" fill="%s" text-anchor="middle">%s</text></g>`+"\n",
+				(x(ts.Start)+x(ts.Stop))/2, top+svgLaneHeight/2+4, textColor, label)
+		}
+		axis := top + svgLaneHeight
+		_, _ = fmt.Fprintf(w, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#333"/>`+"\n",
+			svgLabelWidth, axis, svgLabelWidth+svgPlotWidth, axis)
+		for _, t := range ganttTicks(start, stop) {
+			_, _ = fmt.Fprintf(w, `<line x1="%.2f" y1="%d" x2="%.2f" y2="%d" stroke="#333"/><text x="%.2f" y="%d" text-anchor="middle" fill="#333">%s</text>`+"\n",
+				x(t), axis, x(t), axis+4, x(t), axis+16, res.schedule.formatTime(t))
+		}
+	}
+	_, err := fmt.Fprintln(w, "</svg>")
+
+	return err
+}
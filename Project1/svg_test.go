@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_ganttTicks(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		start, stop int64
+		want        []int64
+	}{
+		{name: "empty", start: 0, stop: 0, want: []int64{0}},
+		{name: "short", start: 2, stop: 5, want: []int64{2, 3, 4, 5}},
+		{name: "long", start: 0, stop: 20, want: []int64{0, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20}},
+		{name: "uneven", start: 0, stop: 15, want: []int64{0, 2, 4, 6, 8, 10, 12, 14, 15}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := ganttTicks(tt.start, tt.stop); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ganttTicks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_outputSVG(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 10, BurstDuration: 10, Priority: 1},
+	}
+	results := []result{{title: "First-come, first-serve", schedule: fcfs(processes, Options{})}}
+
+	var w bytes.Buffer
+	if err := outputSVG(&w, results, Report{}); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`<svg xmlns="http://www.w3.org/2000/svg" width="990" height="90"`,
+		`<title>1: 0–5</title><rect x="170.00" y="24" width="200.00" height="28" fill="#f28e2b"`,
+		`<title>IDLE: 5–10</title><rect x="370.00" y="24" width="200.00" height="28" fill="#e8e8e8"`,
+		`<title>2: 10–20</title><rect x="570.00" y="24" width="400.00" height="28" fill="#e15759"`,
+		`text-anchor="middle" fill="#333">20</text>`,
+		"</svg>\n",
+	} {
+		if got := w.String(); !strings.Contains(got, want) {
+			t.Errorf("outputSVG() = %v, want it to contain %q", got, want)
+		}
+	}
+}
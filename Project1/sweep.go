@@ -0,0 +1,267 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// sweepParam is a scheduler parameter a sweep can vary, set into the options from a value.
+type sweepParam struct {
+	header string
+	set    func(opts *Options, value string, base timeBase) error
+}
+
+// sweepParams are the parameters a sweep can vary besides the algorithmParams, by name.
+var sweepParams = map[string]sweepParam{
+	"quantum": {header: "Quantum", set: func(opts *Options, value string, base timeBase) error {
+		q, err := parseTime(value, base)
+		if err != nil || q <= 0 {
+			return fmt.Errorf("must be a positive time")
+		}
+		opts.Quantum = q
+		return nil
+	}},
+	"switch-cost": {header: "Switch cost", set: func(opts *Options, value string, base timeBase) error {
+		c, err := parseTime(value, base)
+		if err != nil || c < 0 {
+			return fmt.Errorf("must be a time of 0 or more")
+		}
+		opts.SwitchCost = c
+		return nil
+	}},
+}
+
+// lookupSweepParam returns the parameter a sweep names, one of the sweepParams or an "algorithm.key"
+// of the algorithmParams, which is headed by its name.
+func lookupSweepParam(name string) (sweepParam, bool) {
+	if param, ok := sweepParams[name]; ok {
+		return param, true
+	}
+	algorithm, key, _ := strings.Cut(name, ".")
+	param, ok := algorithmParams[algorithm][key]
+
+	return sweepParam{header: name, set: param.set}, ok
+}
+
+// sweepParamNames returns the names of every parameter a sweep can vary.
+func sweepParamNames() []string {
+	return append(sortedKeys(sweepParams), algorithmParamNames()...)
+}
+
+// sweep is a parameter and the values to schedule with, in order.
+type sweep struct {
+	name   string
+	param  sweepParam
+	values []string
+}
+
+// parseSweep parses "name=LOW..HIGH", stepping by one, "name=LOW..HIGH:STEP" or "name=V1,V2,...".
+func parseSweep(spec string) (sweep, error) {
+	name, values, ok := strings.Cut(spec, "=")
+	name = strings.TrimSpace(name)
+	param, known := lookupSweepParam(name)
+	if !ok || !known {
+		return sweep{}, fmt.Errorf("%w: sweep %q must be name=LOW..HIGH[:STEP] or name=V1,V2,... with a name of %s",
+			ErrInvalidArgs, spec, strings.Join(sweepParamNames(), ", "))
+	}
+	s := sweep{name: name, param: param}
+	if low, high, isRange := strings.Cut(values, ".."); isRange {
+		high, step, stepped := strings.Cut(high, ":")
+		if !stepped {
+			step = "1"
+		}
+		lo, errLow := strconv.ParseFloat(strings.TrimSpace(low), 64)
+		hi, errHigh := strconv.ParseFloat(strings.TrimSpace(high), 64)
+		by, errStep := strconv.ParseFloat(strings.TrimSpace(step), 64)
+		if errLow != nil || errHigh != nil || errStep != nil || by <= 0 || hi < lo {
+			return sweep{}, fmt.Errorf("%w: sweep %q must range from a number up to a number by a positive step", ErrInvalidArgs, spec)
+		}
+		// Count the steps rather than adding them up, and round off the float error, so 0.1 steps
+		// neither drift past the end nor print as 0.30000000000000004.
+		for i := 0; lo+float64(i)*by <= hi+by*1e-9; i++ {
+			s.values = append(s.values, strconv.FormatFloat(math.Round((lo+float64(i)*by)*1e9)/1e9, 'f', -1, 64))
+		}
+	} else {
+		for _, v := range strings.Split(values, ",") {
+			s.values = append(s.values, strings.TrimSpace(v))
+		}
+	}
+
+	return s, nil
+}
+
+// parseSweeps parses the sweeps of the specs, each of a different parameter.
+func parseSweeps(specs []string) ([]sweep, error) {
+	sweeps := make([]sweep, 0, len(specs))
+	for _, spec := range specs {
+		s, err := parseSweep(spec)
+		if err != nil {
+			return nil, err
+		}
+		for _, other := range sweeps {
+			if other.name == s.name {
+				return nil, fmt.Errorf("%w: %s is swept twice", ErrInvalidArgs, s.name)
+			}
+		}
+		sweeps = append(sweeps, s)
+	}
+
+	return sweeps, nil
+}
+
+// sweepPoints returns every combination of the values of the sweeps, index-aligned with them, in
+// order with the last sweep varying fastest.
+func sweepPoints(sweeps []sweep) [][]string {
+	points := [][]string{nil}
+	for _, s := range sweeps {
+		next := make([][]string, 0, len(points)*len(s.values))
+		for _, point := range points {
+			for _, v := range s.values {
+				next = append(next, append(append([]string(nil), point...), v))
+			}
+		}
+		points = next
+	}
+
+	return points
+}
+
+// sortedKeys returns the keys of a map of names, sorted.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// sweepRow is the outcome of one algorithm scheduled with one combination of the swept values.
+type sweepRow struct {
+	Values            []string // index-aligned with the sweeps
+	Name              string   // of the algorithm, as -algorithms selects it
+	Algorithm         string
+	AverageWait       float64
+	AverageTurnaround float64
+	ContextSwitches   int
+}
+
+// runSweep schedules the processes with the selected algorithms once per combination of the values
+// of the sweeps, which are set into a copy of the options, running up to workers combinations at
+// once. The rows are in the order of sweepPoints whatever order the schedules finish in.
+func runSweep(processes []Process, opts Options, selected []algorithm, sweeps []sweep, workers int) ([]sweepRow, error) {
+	points := sweepPoints(sweeps)
+	options := make([]Options, len(points))
+	for i, point := range points {
+		options[i] = opts
+		for j, s := range sweeps {
+			if err := s.param.set(&options[i], point[j], opts.timeBase()); err != nil {
+				return nil, fmt.Errorf("%w: sweep %s=%s: %v", ErrInvalidArgs, s.name, point[j], err)
+			}
+		}
+	}
+
+	results := make([][]result, len(points))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for n := 0; n < workers && n < len(points); n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				results[i] = scheduleAll(processes, options[i], selected)
+			}
+		}()
+	}
+	for i := range points {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	rows := make([]sweepRow, 0, len(points)*len(selected))
+	for i, point := range points {
+		for j, res := range results[i] {
+			rows = append(rows, sweepRow{
+				Values:            point,
+				Name:              selected[j].name,
+				Algorithm:         res.title,
+				AverageWait:       res.schedule.AverageWait(),
+				AverageTurnaround: res.schedule.AverageTurnaround(),
+				ContextSwitches:   res.schedule.ContextSwitches(),
+			})
+		}
+	}
+
+	return rows, nil
+}
+
+// sweepFormats are the report formats a sweep writes.
+var sweepFormats = []string{"text", "csv"}
+
+// validateSweep checks that nothing else run alongside a sweep needs the single schedule it replaces.
+func validateSweep(r Report, runs int, checkpoint, rubric string) error {
+	switch {
+	case r.Format != "text" && r.Format != "csv":
+		return fmt.Errorf("%w: -sweep only reports to the %s formats", ErrInvalidArgs, strings.Join(sweepFormats, " and "))
+	case runs > 1 || checkpoint != "" || rubric != "":
+		return fmt.Errorf("%w: -sweep cannot be combined with -runs, -checkpoint or -assert", ErrInvalidArgs)
+	}
+
+	return nil
+}
+
+// writeSweep writes the rows of the sweeps in the report's format: a text table in its table style,
+// or CSV with a column per swept parameter and the values unrounded, for plotting the metrics against
+// the parameters.
+func writeSweep(w io.Writer, sweeps []sweep, rows []sweepRow, r Report) error {
+	names := make([]string, len(sweeps))
+	for i, s := range sweeps {
+		names[i] = s.name
+	}
+	if r.Format == "csv" {
+		cw := csv.NewWriter(w)
+		_ = cw.Write(append(names, "algorithm", "average_wait", "average_turnaround", "context_switches"))
+		for _, row := range rows {
+			_ = cw.Write(append(append([]string(nil), row.Values...), row.Algorithm, strconv.FormatFloat(row.AverageWait, 'f', -1, 64),
+				strconv.FormatFloat(row.AverageTurnaround, 'f', -1, 64), strconv.Itoa(row.ContextSwitches)))
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+
+	points := len(sweepPoints(sweeps))
+	if len(sweeps) == 1 {
+		outputTitle(w, fmt.Sprintf("Sweep of %s over %d values", names[0], points))
+	} else {
+		outputTitle(w, fmt.Sprintf("Sweep of %s over %d combinations", strings.Join(names, " and "), points))
+	}
+	header := make([]string, 0, len(sweeps)+4)
+	alignment := make([]int, 0, len(sweeps)+4)
+	for _, s := range sweeps {
+		header = append(header, s.param.header)
+		alignment = append(alignment, tablewriter.ALIGN_RIGHT)
+	}
+	header = append(header, "Algorithm", "Average wait", "Average turnaround", "Context switches")
+	alignment = append(alignment, tablewriter.ALIGN_LEFT, tablewriter.ALIGN_RIGHT, tablewriter.ALIGN_RIGHT, tablewriter.ALIGN_RIGHT)
+	cells := make([][]string, len(rows))
+	for i, row := range rows {
+		cells[i] = append(append([]string(nil), row.Values...), row.Algorithm, fmt.Sprintf("%.2f", row.AverageWait),
+			fmt.Sprintf("%.2f", row.AverageTurnaround), strconv.Itoa(row.ContextSwitches))
+	}
+	outputTable(w, r.TableStyle, header, cells, nil, alignment)
+	for _, line := range switchCostBreakEvens(sweeps, rows) {
+		_, _ = fmt.Fprintln(w, line)
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_parseSweep(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		spec    string
+		want    []string
+		wantErr bool
+	}{
+		{spec: "quantum=1..4", want: []string{"1", "2", "3", "4"}},
+		{spec: "quantum=1..2:0.25", want: []string{"1", "1.25", "1.5", "1.75", "2"}},
+		{spec: "quantum=0.1..0.3:0.1", want: []string{"0.1", "0.2", "0.3"}},
+		{spec: "quantum=2, 4,8", want: []string{"2", "4", "8"}},
+		{spec: "quantum=4..1", wantErr: true},
+		{spec: "quantum=1..4:0", wantErr: true},
+		{spec: "rr.queue=rotation,fifo", want: []string{"rotation", "fifo"}},
+		{spec: "quantum", wantErr: true},
+		{spec: "lottery=1..4", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.spec, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseSweep(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSweep() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got.values, tt.want) {
+				t.Errorf("parseSweep() values = %q, want %q", got.values, tt.want)
+			}
+		})
+	}
+}
+
+func Test_runSweep(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 4},
+	}
+	sweeps, err := parseSweeps([]string{"rr.quantum=1,4", "switch-cost=0,1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows, err := runSweep(processes, Options{}, algorithms[3:4], sweeps, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []sweepRow{
+		{Values: []string{"1", "0"}, Name: "rr", Algorithm: "Round-robin", AverageWait: 3.5, AverageTurnaround: 7.5, ContextSwitches: 7},
+		{Values: []string{"1", "1"}, Name: "rr", Algorithm: "Round-robin", AverageWait: 10, AverageTurnaround: 14, ContextSwitches: 7},
+		{Values: []string{"4", "0"}, Name: "rr", Algorithm: "Round-robin", AverageWait: 2, AverageTurnaround: 6, ContextSwitches: 1},
+		{Values: []string{"4", "1"}, Name: "rr", Algorithm: "Round-robin", AverageWait: 2.5, AverageTurnaround: 6.5, ContextSwitches: 1},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("runSweep() = %+v, want %+v", rows, want)
+	}
+	if _, err := parseSweeps([]string{"quantum=1", "quantum=2"}); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("parseSweeps() error = %v, want %v", err, ErrInvalidArgs)
+	}
+	if _, err := runSweep(processes, Options{}, algorithms[3:4], []sweep{{name: "quantum", param: sweepParams["quantum"], values: []string{"-1"}}}, 1); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("runSweep() error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
+
+func Test_compareCommand_sweep(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "workload.csv")
+	if err := os.WriteFile(path, []byte("1,5,0,2\n2,9,3,1\n3,6,6,3\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr error
+	}{
+		{name: "text", args: []string{"-algorithms", "rr", "-sweep", "quantum=1..3", path}, want: "Sweep of quantum over 3 values"},
+		{name: "csv", args: []string{"-algorithms", "rr", "-format", "csv", "-sweep", "quantum=2", path}, want: "quantum,algorithm,average_wait,average_turnaround,context_switches\n2,Round-robin,"},
+		{name: "combinations", args: []string{"-algorithms", "fcfs,rr", "-sweep", "quantum=1,2", "-sweep", "switch-cost=0..2", path}, want: "Sweep of quantum and switch-cost over 6 combinations"},
+		{name: "columns", args: []string{"-algorithms", "rr", "-format", "csv", "-sweep", "rr.queue=fifo", "-sweep", "quantum=2", path}, want: "rr.queue,quantum,algorithm,average_wait,average_turnaround,context_switches\nfifo,2,Round-robin,"},
+		{name: "twice", args: []string{"-sweep", "quantum=1", "-sweep", "quantum=2", path}, wantErr: ErrInvalidArgs},
+		{name: "svg", args: []string{"-format", "svg", "-sweep", "quantum=1..3", path}, wantErr: ErrInvalidArgs},
+		{name: "runs", args: []string{"-runs", "3", "-sweep", "quantum=1..3", path}, wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			if err := compareCommand(&w, tt.args); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("compareCommand() error = %v, want %v", err, tt.wantErr)
+			}
+			if !strings.Contains(w.String(), tt.want) {
+				t.Errorf("compareCommand() = %s, want it to contain %q", w.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// switchPID marks the periods the CPU spent switching context merged into a timeline.
+const switchPID int64 = -2
+
+// sliceLabel returns the label of a timeline slice: its process ID, IDLE or CS.
+func sliceLabel(pid int64) string {
+	switch pid {
+	case idlePID:
+		return "IDLE"
+	case switchPID:
+		return "CS"
+	}
+
+	return fmt.Sprint(pid)
+}
+
+// chargeSwitches makes every context switch of the schedule take cost ticks, keeping the order the
+// scheduler chose. A slice dispatching another process than the slice before starts no sooner than
+// cost after it, delaying every later slice unless idle time absorbs the delay. The first dispatch
+// is free, as it is not counted as a switch. The switching periods are recorded, the idle periods
+// between the moved slices recomputed, and the metrics recomputed from the slices.
+func (s *Schedule) chargeSwitches(cost int64) {
+	if len(s.Gantt) == 0 {
+		return
+	}
+	idle := make([]TimeSlice, 0, len(s.Idle))
+	for _, ts := range s.Idle {
+		if ts.Stop <= s.Gantt[0].Start {
+			idle = append(idle, ts)
+		}
+	}
+	s.Idle = idle
+	end := s.Gantt[0].Stop
+	for i := 1; i < len(s.Gantt); i++ {
+		ts := &s.Gantt[i]
+		length, free := ts.Stop-ts.Start, end
+		if ts.PID != s.Gantt[i-1].PID {
+			free += cost
+		}
+		if ts.Start < free {
+			ts.Start = free
+		}
+		ts.Stop = ts.Start + length
+		if ts.PID != s.Gantt[i-1].PID {
+			if ts.Start-cost > end {
+				s.addIdle(end, ts.Start-cost)
+			}
+			s.Switching = append(s.Switching, TimeSlice{PID: switchPID, Start: ts.Start - cost, Stop: ts.Start})
+		} else if ts.Start > end {
+			s.addIdle(end, ts.Start)
+		}
+		end = ts.Stop
+	}
+	s.exactMetrics()
+}
+
+// switchCostBreakEvens returns a switchCostBreakEven line for every combination of the other swept
+// parameters when switch-cost is swept, each saying which combination it is of when there are others.
+func switchCostBreakEvens(sweeps []sweep, rows []sweepRow) []string {
+	cost := -1
+	for i, s := range sweeps {
+		if s.name == "switch-cost" {
+			cost = i
+		}
+	}
+	if cost < 0 {
+		return nil
+	}
+	var (
+		others []string
+		groups = make(map[string][]sweepRow)
+	)
+	for _, row := range rows {
+		var other []string
+		for i, s := range sweeps {
+			if i != cost {
+				other = append(other, s.name+"="+row.Values[i])
+			}
+		}
+		key := strings.Join(other, ", ")
+		if _, ok := groups[key]; !ok {
+			others = append(others, key)
+		}
+		groups[key] = append(groups[key], row)
+	}
+	lines := make([]string, 0, len(others))
+	for _, other := range others {
+		line, ok := switchCostBreakEven(groups[other], cost)
+		if !ok {
+			continue
+		}
+		if other != "" {
+			line = "With " + other + ": " + strings.ToLower(line[:1]) + line[1:]
+		}
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// switchCostBreakEven returns a line naming the first switch cost, the swept value at index cost of
+// the rows, at which round-robin, after beating first-come, first-serve on average turnaround, stops
+// beating it, or saying that it beats it at every swept cost or at none. It is false unless the rows
+// include both algorithms.
+func switchCostBreakEven(rows []sweepRow, cost int) (string, bool) {
+	var (
+		costs []string
+		rr    = make(map[string]float64)
+		fcfs  = make(map[string]float64)
+	)
+	for _, row := range rows {
+		switch row.Name {
+		case "rr":
+			costs = append(costs, row.Values[cost])
+			rr[row.Values[cost]] = row.AverageTurnaround
+		case "fcfs":
+			fcfs[row.Values[cost]] = row.AverageTurnaround
+		}
+	}
+	if len(rr) == 0 || len(fcfs) == 0 {
+		return "", false
+	}
+	var beaten bool
+	for _, cost := range costs {
+		if rr[cost] < fcfs[cost] {
+			beaten = true
+		} else if beaten {
+			return fmt.Sprintf("Round-robin stops beating first-come, first-serve on average turnaround at a switch cost of %s", cost), true
+		}
+	}
+	if beaten {
+		return "Round-robin beats first-come, first-serve on average turnaround at every switch cost swept", true
+	}
+
+	return "Round-robin does not beat first-come, first-serve on average turnaround at any switch cost swept", true
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSchedule_chargeSwitches(t *testing.T) {
+	t.Parallel()
+	s := newSchedule([]Process{
+		{ProcessID: 1, BurstDuration: 3},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2},
+		{ProcessID: 3, ArrivalTime: 10, BurstDuration: 1},
+	})
+	s.Gantt = []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 4}, {PID: 1, Start: 4, Stop: 5}, {PID: 3, Start: 10, Stop: 11}}
+	s.Idle = []TimeSlice{{Start: 5, Stop: 10}}
+	s.chargeSwitches(1)
+
+	for name, pair := range map[string][2]any{
+		"Gantt":      {s.Gantt, []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 3, Stop: 5}, {PID: 1, Start: 6, Stop: 7}, {PID: 3, Start: 10, Stop: 11}}},
+		"Switching":  {s.Switching, []TimeSlice{{PID: switchPID, Start: 2, Stop: 3}, {PID: switchPID, Start: 5, Stop: 6}, {PID: switchPID, Start: 9, Stop: 10}}},
+		"Idle":       {s.Idle, []TimeSlice{{Start: 7, Stop: 9}}},
+		"Completion": {s.Completion, []int64{7, 5, 11}},
+		"Turnaround": {s.Turnaround, []int64{7, 4, 1}},
+		"Wait":       {s.Wait, []int64{4, 2, 0}},
+	} {
+		if !reflect.DeepEqual(pair[0], pair[1]) {
+			t.Errorf("%s = %v, want %v", name, pair[0], pair[1])
+		}
+	}
+}
+
+func Test_switchCostBreakEven(t *testing.T) {
+	t.Parallel()
+	row := func(cost, name string, turnaround float64) sweepRow {
+		return sweepRow{Values: []string{cost}, Name: name, AverageTurnaround: turnaround}
+	}
+	tests := []struct {
+		name   string
+		rows   []sweepRow
+		want   string
+		wantOK bool
+	}{
+		{
+			name:   "stops",
+			rows:   []sweepRow{row("0", "rr", 7), row("0", "fcfs", 11), row("2", "rr", 11), row("2", "fcfs", 13), row("4", "rr", 15), row("4", "fcfs", 15)},
+			want:   "Round-robin stops beating first-come, first-serve on average turnaround at a switch cost of 4",
+			wantOK: true,
+		},
+		{
+			name:   "always",
+			rows:   []sweepRow{row("0", "rr", 7), row("0", "fcfs", 11), row("1", "rr", 9), row("1", "fcfs", 12)},
+			want:   "Round-robin beats first-come, first-serve on average turnaround at every switch cost swept",
+			wantOK: true,
+		},
+		{
+			name:   "never",
+			rows:   []sweepRow{row("0", "rr", 12), row("0", "fcfs", 10)},
+			want:   "Round-robin does not beat first-come, first-serve on average turnaround at any switch cost swept",
+			wantOK: true,
+		},
+		{name: "no fcfs", rows: []sweepRow{row("0", "rr", 12)}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got, ok := switchCostBreakEven(tt.rows, 0); got != tt.want || ok != tt.wantOK {
+				t.Errorf("switchCostBreakEven() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func Test_switchCostBreakEvens(t *testing.T) {
+	t.Parallel()
+	sweeps := []sweep{{name: "quantum"}, {name: "switch-cost"}}
+	row := func(quantum, cost, name string, turnaround float64) sweepRow {
+		return sweepRow{Values: []string{quantum, cost}, Name: name, AverageTurnaround: turnaround}
+	}
+	rows := []sweepRow{
+		row("1", "0", "rr", 9), row("1", "0", "fcfs", 10), row("1", "1", "rr", 12), row("1", "1", "fcfs", 11),
+		row("2", "0", "rr", 8), row("2", "0", "fcfs", 10), row("2", "1", "rr", 9), row("2", "1", "fcfs", 11),
+	}
+	want := []string{
+		"With quantum=1: round-robin stops beating first-come, first-serve on average turnaround at a switch cost of 1",
+		"With quantum=2: round-robin beats first-come, first-serve on average turnaround at every switch cost swept",
+	}
+	if got := switchCostBreakEvens(sweeps, rows); !reflect.DeepEqual(got, want) {
+		t.Errorf("switchCostBreakEvens() = %q, want %q", got, want)
+	}
+	if got := switchCostBreakEvens(sweeps[:1], rows); got != nil {
+		t.Errorf("switchCostBreakEvens() without a switch-cost sweep = %q, want nil", got)
+	}
+}
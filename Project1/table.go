@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// tableStyles are the text table styles selectable with -table-style. TSV is not drawn by tablewriter
+// and has no entry.
+var tableStyles = map[string]func(table *tablewriter.Table){
+	"ascii": func(*tablewriter.Table) {},
+	"borderless": func(table *tablewriter.Table) {
+		table.SetBorder(false)
+	},
+	"plain": func(table *tablewriter.Table) {
+		table.SetBorder(false)
+		table.SetHeaderLine(false)
+		table.SetNoWhiteSpace(true)
+		table.SetTablePadding("  ")
+		table.SetColumnSeparator("")
+		table.SetCenterSeparator("")
+		table.SetRowSeparator("")
+	},
+}
+
+const tsvStyle = "tsv"
+
+func tableStyleNames() []string {
+	names := []string{tsvStyle}
+	for name := range tableStyles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+var tsvEscaper = strings.NewReplacer("\t", " ", "\n", " ")
+
+// outputTable outputs a table in a style given:
+// • an output writer
+// • the table style
+// • the header, rows and footer (nil for none) cells
+// • the column alignments (nil for tablewriter's default)
+func outputTable(w io.Writer, style string, header []string, rows [][]string, footer []string, alignment []int) {
+	if style == tsvStyle {
+		line := func(cells []string) {
+			escaped := make([]string, len(cells))
+			for i, c := range cells {
+				escaped[i] = tsvEscaper.Replace(c)
+			}
+			_, _ = fmt.Fprintln(w, strings.Join(escaped, "\t"))
+		}
+		line(header)
+		for _, row := range rows {
+			line(row)
+		}
+		if footer != nil {
+			line(footer)
+		}
+		return
+	}
+
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(header)
+	if alignment != nil {
+		table.SetColumnAlignment(alignment)
+	}
+	table.AppendBulk(rows)
+	if footer != nil {
+		table.SetFooter(footer)
+	}
+	if apply, ok := tableStyles[style]; ok {
+		apply(table)
+	}
+	table.Render()
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_outputTable(t *testing.T) {
+	t.Parallel()
+	header := []string{"ID", "Wait"}
+	rows := [][]string{{"1", "0"}, {"2", "12"}}
+	footer := []string{"", "Average\n6.00"}
+	tests := []struct {
+		style string
+		want  string
+	}{
+		{
+			style: "ascii",
+			want: "+----+---------+\n" +
+				"| ID |  WAIT   |\n" +
+				"+----+---------+\n" +
+				"|  1 |       0 |\n" +
+				"|  2 |      12 |\n" +
+				"+----+---------+\n" +
+				"|      AVERAGE |\n" +
+				"|       6.00   |\n" +
+				"+----+---------+\n",
+		},
+		{
+			style: "borderless",
+			want: "  ID |  WAIT    \n" +
+				"-----+----------\n" +
+				"   1 |       0  \n" +
+				"   2 |      12  \n" +
+				"-----+----------\n" +
+				"       AVERAGE  \n" +
+				"        6.00    \n" +
+				"     -----------\n",
+		},
+		{
+			style: "tsv",
+			want:  "ID\tWait\n1\t0\n2\t12\n\tAverage 6.00\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.style, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			outputTable(&w, tt.style, header, rows, footer, nil)
+			if got := w.String(); got != tt.want {
+				t.Errorf("outputTable() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
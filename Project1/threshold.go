@@ -0,0 +1,18 @@
+package main
+
+// thresholdPrefix starts the optional preemption threshold column of a workload row, "threshold:1".
+// Once a process runs, only processes more important than its threshold preempt it, so a threshold
+// between its priority and the highest priority shields it from the processes in between. A threshold
+// less important than the priority has no effect, as which way that is depends on -priority-order.
+const thresholdPrefix = "threshold:"
+
+// preemptionThreshold returns the priority number a process must be preempted from below once it
+// runs, with priorities ranked lowest first: its threshold, or its priority when it has none or a
+// higher one.
+func preemptionThreshold(p Process) int64 {
+	if p.HasThreshold && p.Threshold < p.Priority {
+		return p.Threshold
+	}
+
+	return p.Priority
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPreemptivePriority_threshold(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		workload       string
+		wantCompletion []int64
+	}{
+		{name: "no threshold", workload: "1,4,0,3\n2,2,1,2\n3,1,2,0", wantCompletion: []int64{7, 4, 3}},
+		{name: "threshold", workload: "1,4,0,3,threshold:1\n2,2,1,2\n3,1,2,0", wantCompletion: []int64{7, 5, 3}},
+		{name: "threshold at priority", workload: "1,4,0,3,threshold:3\n2,2,1,2\n3,1,2,0", wantCompletion: []int64{7, 4, 3}},
+		{name: "threshold below priority", workload: "1,4,0,3,threshold:5\n2,2,1,2\n3,1,2,0", wantCompletion: []int64{7, 4, 3}},
+		{name: "threshold 0", workload: "1,4,0,3,threshold:0\n2,2,1,2\n3,1,2,0", wantCompletion: []int64{4, 7, 5}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			processes, err := loadProcesses(strings.NewReader(tt.workload))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := preemptivePriority(processes, Options{}).Completion; !reflect.DeepEqual(got, tt.wantCompletion) {
+				t.Errorf("completion = %v, want %v", got, tt.wantCompletion)
+			}
+		})
+	}
+}
+
+func Test_loadProcesses_threshold(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		workload string
+		want     int64
+		wantErr  error
+	}{
+		{workload: "1,4,0,3", want: 0},
+		{workload: "1,4,0,3,threshold:1", want: 1},
+		{workload: "1,4,0,3,class:batch,threshold:2", want: 2},
+		{workload: "1,4,0,3,threshold:4", want: 4},
+		{workload: "1,4,0,3,threshold:0", want: 0},
+		{workload: "1,4,0,3,threshold:high", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		processes, err := loadProcesses(strings.NewReader(tt.workload))
+		if err == nil {
+			err = validateProcesses(processes)
+		}
+		if tt.wantErr != nil {
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("%q: error = %v, want %v", tt.workload, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: %v", tt.workload, err)
+		} else if processes[0].Threshold != tt.want {
+			t.Errorf("%q: Threshold = %d, want %d", tt.workload, processes[0].Threshold, tt.want)
+		}
+	}
+}
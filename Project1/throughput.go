@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// throughputWindow counts the processes completing in a window of time, after Start up to and
+// including Stop.
+type throughputWindow struct {
+	Start       int64
+	Stop        int64
+	Completions int
+}
+
+// WindowedThroughput counts the completions in consecutive windows of a length in ticks, aligned to
+// multiples of the length, from the window of the first arrival to that of the last completion.
+func (s Schedule) WindowedThroughput(length int64) []throughputWindow {
+	first := s.FirstArrival() / length * length
+	windows := make([]throughputWindow, 0)
+	for start := first; start == first || start < s.LastCompletion(); start += length {
+		windows = append(windows, throughputWindow{Start: start, Stop: start + length})
+	}
+	for _, c := range s.Completion {
+		if k := (c - first - 1) / length; c > first && int(k) < len(windows) {
+			windows[k].Completions++
+		}
+	}
+
+	return windows
+}
+
+// throughputHeader names the windowed throughput table columns.
+var throughputHeader = []string{"Window", "Completions", "Cumulative", "Throughput"}
+
+// throughputRows formats the windowed throughput of the report's window length, one row per window.
+func (s Schedule) throughputRows(r Report) [][]string {
+	length := r.ThroughputWindow * s.ticksPerUnit()
+	windows := s.WindowedThroughput(length)
+	rows := make([][]string, len(windows))
+	cumulative := 0
+	for i, win := range windows {
+		cumulative += win.Completions
+		rows[i] = []string{
+			s.formatTime(win.Start) + "-" + s.formatTime(win.Stop),
+			fmt.Sprint(win.Completions),
+			fmt.Sprint(cumulative),
+			fmt.Sprintf("%.2f/t", float64(win.Completions)/float64(r.ThroughputWindow)),
+		}
+	}
+
+	return rows
+}
+
+// outputThroughput outputs a table of the completions in each window of the report's length.
+func outputThroughput(w io.Writer, s Schedule, r Report) {
+	_, _ = fmt.Fprintf(w, "Throughput per %d time units\n", r.ThroughputWindow)
+	outputTable(w, r.TableStyle, throughputHeader, s.throughputRows(r), nil, nil)
+	_, _ = fmt.Fprintln(w)
+}
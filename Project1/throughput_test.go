@@ -0,0 +1,58 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_Schedule_WindowedThroughput(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		length    int64
+		want      []throughputWindow
+	}{
+		{
+			name: "front-loaded",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2},
+				{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2},
+				{ProcessID: 3, ArrivalTime: 0, BurstDuration: 8},
+			},
+			length: 4,
+			want: []throughputWindow{
+				{Start: 0, Stop: 4, Completions: 2},
+				{Start: 4, Stop: 8, Completions: 0},
+				{Start: 8, Stop: 12, Completions: 1},
+			},
+		},
+		{
+			name:      "late arrival",
+			processes: []Process{{ProcessID: 1, ArrivalTime: 13, BurstDuration: 1}},
+			length:    5,
+			want:      []throughputWindow{{Start: 10, Stop: 15, Completions: 1}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := fcfs(tt.processes, Options{}).WindowedThroughput(tt.length); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("WindowedThroughput() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Schedule_throughputRows(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 3},
+	}
+	want := [][]string{{"0-4", "1", "1", "0.25/t"}, {"4-8", "1", "2", "0.25/t"}}
+	if got := fcfs(processes, Options{}).throughputRows(Report{ThroughputWindow: 4}); !reflect.DeepEqual(got, want) {
+		t.Errorf("throughputRows() = %q, want %q", got, want)
+	}
+}
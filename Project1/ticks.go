@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// running returns the process running from time t to t+1, or idlePID if none is.
+func (s Schedule) running(t int64) int64 {
+	for _, ts := range s.Gantt {
+		if ts.Start <= t && t < ts.Stop {
+			return ts.PID
+		}
+	}
+
+	return idlePID
+}
+
+// readyQueue returns the processes that were ready and not completed by time t, other than the one
+// running, in arrival order.
+func (s Schedule) readyQueue(t int64) []int64 {
+	running := s.running(t)
+	var (
+		ready   = make([]int64, 0)
+		arrived = make([]int, 0, len(s.Processes))
+		r       = newReadiness(s.Processes, s.Completion, ClassPolicyShared)
+	)
+	for i, p := range s.Processes {
+		if r.ready(i, t) && t < s.Completion[i] && p.ProcessID != running {
+			arrived = append(arrived, i)
+		}
+	}
+	sort.SliceStable(arrived, func(i, j int) bool {
+		return s.Processes[arrived[i]].ArrivalTime < s.Processes[arrived[j]].ArrivalTime
+	})
+	for _, i := range arrived {
+		ready = append(ready, s.Processes[i].ProcessID)
+	}
+
+	return ready
+}
+
+var ticksHeader = []string{"Time", "Running", "Ready queue"}
+
+// tickRows formats one row per time unit from the first arrival to the last completion with the
+// process running and the ready queue.
+func (s Schedule) tickRows() [][]string {
+	rows := make([][]string, 0)
+	for t := s.FirstArrival(); t < s.LastCompletion(); t++ {
+		running := "IDLE"
+		if pid := s.running(t); pid != idlePID {
+			running = fmt.Sprint(pid)
+		}
+		ready := make([]string, 0)
+		for _, pid := range s.readyQueue(t) {
+			ready = append(ready, fmt.Sprint(pid))
+		}
+		rows = append(rows, []string{s.formatTime(t), running, strings.Join(ready, ", ")})
+	}
+
+	return rows
+}
+
+// outputTicks outputs the schedule as a table of what ran and what was ready at every time unit.
+func outputTicks(w io.Writer, s Schedule, r Report) {
+	_, _ = fmt.Fprintln(w, "Per-tick timeline")
+	alignment := []int{tablewriter.ALIGN_RIGHT, tablewriter.ALIGN_RIGHT, tablewriter.ALIGN_LEFT}
+	outputTable(w, r.TableStyle, ticksHeader, s.tickRows(), nil, alignment)
+	_, _ = fmt.Fprintln(w)
+}
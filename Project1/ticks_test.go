@@ -0,0 +1,51 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSchedule_tickRows(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		want      [][]string
+	}{
+		{
+			name: "preemption",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, Priority: 2},
+				{ProcessID: 3, ArrivalTime: 1, BurstDuration: 1, Priority: 3},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1, Priority: 1},
+			},
+			want: [][]string{
+				{"0", "1", ""},
+				{"1", "2", "1, 3"},
+				{"2", "1", "3"},
+				{"3", "3", ""},
+			},
+		},
+		{
+			name: "idle",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 1, Priority: 1},
+				{ProcessID: 2, ArrivalTime: 2, BurstDuration: 1, Priority: 1},
+			},
+			want: [][]string{
+				{"0", "1", ""},
+				{"1", "IDLE", ""},
+				{"2", "2", ""},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := preemptivePriority(tt.processes, Options{}).tickRows(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tickRows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// TieBreak names the policy that picks between processes tied on remaining time or priority.
+type TieBreak string
+
+const (
+	TieBreakInput   TieBreak = "input"   // earlier in the workload file wins
+	TieBreakPID     TieBreak = "pid"     // lower process ID wins
+	TieBreakArrival TieBreak = "arrival" // earlier arrival wins
+	TieBreakRandom  TieBreak = "random"  // a seeded random order wins
+)
+
+// tieBreaks are the tie-break policies, the default first.
+var tieBreaks = []TieBreak{TieBreakInput, TieBreakPID, TieBreakArrival, TieBreakRandom}
+
+// tieBreaker ranks processes by index; the lower rank wins a tie. Input order settles equal ranks.
+// Input order is the processes' positions in the workload, which sorting by arrival does not change.
+type tieBreaker struct {
+	rank  []int64
+	input []int // position of each process in the workload
+}
+
+// parseTieBreak validates a tie-break policy name.
+func parseTieBreak(name string) (TieBreak, error) {
+	switch policy := TieBreak(name); policy {
+	case TieBreakInput, TieBreakPID, TieBreakArrival, TieBreakRandom:
+		return policy, nil
+	}
+
+	return "", fmt.Errorf("%w: unknown tie-break policy %q", ErrInvalidArgs, name)
+}
+
+// newTieBreaker ranks processes under a policy; unknown policies fall back to input order.
+func newTieBreaker(policy TieBreak, seed int64, processes []Process) tieBreaker {
+	rank := make([]int64, len(processes))
+	switch policy {
+	case TieBreakPID:
+		for i := range rank {
+			rank[i] = processes[i].ProcessID
+		}
+	case TieBreakArrival:
+		for i := range rank {
+			rank[i] = processes[i].ArrivalTime
+		}
+	case TieBreakRandom:
+		for i, r := range rand.New(rand.NewSource(seed)).Perm(len(rank)) {
+			rank[i] = int64(r)
+		}
+	default:
+		for i := range rank {
+			rank[i] = int64(processes[i].input)
+		}
+	}
+	input := make([]int, len(processes))
+	for i := range processes {
+		input[i] = processes[i].input
+	}
+
+	return tieBreaker{rank: rank, input: input}
+}
+
+// prefer reports whether process i wins a tie against process j.
+func (t tieBreaker) prefer(i, j int) bool {
+	if t.rank[i] != t.rank[j] {
+		return t.rank[i] < t.rank[j]
+	}
+	if t.input[i] != t.input[j] {
+		return t.input[i] < t.input[j]
+	}
+	return i < j
+}
+
+// inputRotation returns the indexes of the processes in input order, and the index following each
+// one in that order, wrapping around to the first.
+func inputRotation(processes []Process) (rotation, next []int) {
+	rotation = make([]int, len(processes))
+	for i := range rotation {
+		rotation[i] = i
+	}
+	sort.SliceStable(rotation, func(a, b int) bool { return processes[rotation[a]].input < processes[rotation[b]].input })
+	next = make([]int, len(processes))
+	for k, i := range rotation {
+		next[i] = rotation[(k+1)%len(rotation)]
+	}
+
+	return rotation, next
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func Test_newTieBreaker(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 4},
+		{ProcessID: 1, ArrivalTime: 2, BurstDuration: 4},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 4},
+	}
+	tests := []struct {
+		name   string
+		policy TieBreak
+		want   []int // process indexes, best first
+	}{
+		{name: "input", policy: TieBreakInput, want: []int{0, 1, 2}},
+		{name: "pid", policy: TieBreakPID, want: []int{1, 2, 0}},
+		{name: "arrival", policy: TieBreakArrival, want: []int{2, 0, 1}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tb := newTieBreaker(tt.policy, 1, processes)
+			for i := 1; i < len(tt.want); i++ {
+				if !tb.prefer(tt.want[i-1], tt.want[i]) || tb.prefer(tt.want[i], tt.want[i-1]) {
+					t.Errorf("prefer(%d, %d) ranks wrong", tt.want[i-1], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func Test_parseTieBreak(t *testing.T) {
+	t.Parallel()
+	if got, err := parseTieBreak("pid"); got != TieBreakPID || err != nil {
+		t.Errorf("parseTieBreak(pid) = %v, %v", got, err)
+	}
+	if _, err := parseTieBreak("age"); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
+
+func TestSJFSchedule_tieBreak(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 3, ArrivalTime: 0, BurstDuration: 4, Priority: 1},
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, Priority: 1},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 4, Priority: 1},
+	}
+	tests := []struct {
+		name      string
+		policy    TieBreak
+		wantGantt string
+	}{
+		{name: "input", policy: TieBreakInput, wantGantt: "|   3   |   1   |   2   |\n0\t4\t8\t12\n"},
+		{name: "pid", policy: TieBreakPID, wantGantt: "|   1   |   2   |   3   |\n0\t4\t8\t12\n"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			opts := Options{TieBreak: tt.policy}
+			for name, schedule := range map[string]func(*bytes.Buffer){
+				"SJFSchedule":         func(w *bytes.Buffer) { SJFSchedule(w, "SJF", processes, opts) },
+				"SJFPrioritySchedule": func(w *bytes.Buffer) { SJFPrioritySchedule(w, "Priority", processes, opts) },
+			} {
+				var w bytes.Buffer
+				schedule(&w)
+				if got := w.String(); !strings.Contains(got, tt.wantGantt) {
+					t.Errorf("%s() = %v, want Gantt %v", name, got, tt.wantGantt)
+				}
+			}
+		})
+	}
+}
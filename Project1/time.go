@@ -0,0 +1,151 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Times are simulated in whole ticks. A workload's times are in time units of resolution ticks each,
+// so with a resolution of 10 an arrival of 2.5 is tick 25, and every output converts ticks back into
+// time units. A resolution of 1 keeps times whole. Time units are abstract unless a time unit length
+// is given, in which case times may also be Go duration strings like "150ms" and are output as
+// durations too.
+
+// timeBase is how a workload's times map onto ticks: the ticks per time unit, and the length of a
+// time unit, 0 for abstract time units.
+type timeBase struct {
+	resolution int64
+	unit       time.Duration
+}
+
+// ticksPerUnit returns the number of ticks in a time unit, treating an unset resolution as 1.
+func (b timeBase) ticksPerUnit() int64 {
+	if b.resolution > 1 {
+		return b.resolution
+	}
+
+	return 1
+}
+
+// addTimeFlags registers the -resolution and -time-unit flags on fs. Call the returned func after
+// parsing.
+func addTimeFlags(fs *flag.FlagSet) func() (timeBase, error) {
+	resolution := fs.Int64("resolution", 1, "ticks per time unit, so times like 2.5 can be given with e.g. 10 (1 for whole times only)")
+	unit := fs.String("time-unit", "", `length of a time unit, e.g. "1ms", so times can be durations like "150ms" (abstract units when empty)`)
+
+	return func() (timeBase, error) {
+		if *resolution <= 0 {
+			return timeBase{}, fmt.Errorf("%w: resolution must be positive", ErrInvalidArgs)
+		}
+		b := timeBase{resolution: *resolution}
+		if *unit != "" {
+			d, err := time.ParseDuration(*unit)
+			if err != nil || d <= 0 {
+				return timeBase{}, fmt.Errorf("%w: time unit %q is not a positive duration", ErrInvalidArgs, *unit)
+			}
+			b.unit = d
+		}
+
+		return b, nil
+	}
+}
+
+// parseTime parses a time of a workload into ticks, rounding fractional times to the nearest tick.
+// With a time unit length, the time may be a duration string.
+func parseTime(field string, base timeBase) (int64, error) {
+	field = strings.TrimSpace(field)
+	resolution := base.ticksPerUnit()
+	if n, err := strconv.ParseInt(field, 10, 64); err == nil {
+		if n > math.MaxInt64/resolution || n < math.MinInt64/resolution {
+			return 0, fmt.Errorf("time %s out of range at a resolution of %d", field, resolution)
+		}
+		return n * resolution, nil
+	}
+	f, err := strconv.ParseFloat(field, 64)
+	if err != nil {
+		d, derr := time.ParseDuration(field)
+		switch {
+		case derr != nil:
+			return 0, fmt.Errorf("time %q is not a number or duration", field)
+		case base.unit == 0:
+			return 0, fmt.Errorf("duration %s needs a time unit", field)
+		}
+		f = float64(d) / float64(base.unit)
+	}
+	if resolution == 1 && f != math.Trunc(f) {
+		return 0, fmt.Errorf("fractional time %s needs a -resolution over 1", field)
+	}
+
+	return int64(math.Round(f * float64(resolution))), nil
+}
+
+// formatTicks formats a time in ticks in time units, with as many decimals as the resolution needs
+// and no trailing zeros, e.g. 25 ticks at a resolution of 10 as "2.5". With a time unit length it is
+// formatted as a duration instead, e.g. "2.5ms".
+func formatTicks(t int64, base timeBase) string {
+	resolution := base.ticksPerUnit()
+	if base.unit != 0 {
+		return time.Duration(math.Round(float64(t) / float64(resolution) * float64(base.unit))).String()
+	}
+	if resolution == 1 {
+		return strconv.FormatInt(t, 10)
+	}
+	decimals := len(strconv.FormatInt(resolution-1, 10))
+	s := strconv.FormatFloat(float64(t)/float64(resolution), 'f', decimals, 64)
+
+	return strings.TrimRight(strings.TrimRight(s, "0"), ".")
+}
+
+// formatUnits formats a statistic of times already in time units, such as an average, to two
+// decimals, or as a duration to a hundredth of a time unit when time units have a length.
+func (b timeBase) formatUnits(v float64) string {
+	if b.unit == 0 {
+		return fmt.Sprintf("%.2f", v)
+	}
+	d := time.Duration(math.Round(v * float64(b.unit)))
+	if b.unit >= 100 {
+		d = d.Round(b.unit / 100)
+	}
+
+	return d.String()
+}
+
+// timeBase returns how the schedule's times map onto ticks.
+func (s Schedule) timeBase() timeBase {
+	return timeBase{resolution: s.Resolution, unit: s.Unit}
+}
+
+// ticksPerUnit returns the number of ticks in a time unit of the schedule's workload.
+func (s Schedule) ticksPerUnit() int64 {
+	return s.timeBase().ticksPerUnit()
+}
+
+// formatTime formats a time of the schedule, in ticks, in the time units of its workload.
+func (s Schedule) formatTime(t int64) string {
+	return formatTicks(t, s.timeBase())
+}
+
+// formatUnits formats a statistic of the schedule's times already converted into time units.
+func (s Schedule) formatUnits(v float64) string {
+	return s.timeBase().formatUnits(v)
+}
+
+// inUnits converts a time of the schedule, or an average or other statistic of times, from ticks into
+// the time units of its workload.
+func (s Schedule) inUnits(ticks float64) float64 {
+	return ticks / float64(s.ticksPerUnit())
+}
+
+// timeBase returns how the times of the workload the options schedule map onto ticks.
+func (opts Options) timeBase() timeBase {
+	return timeBase{resolution: opts.Resolution, unit: opts.Unit}
+}
+
+// ticksPerUnit returns the number of ticks in a time unit of the workload the options schedule.
+func (opts Options) ticksPerUnit() int64 {
+	return opts.timeBase().ticksPerUnit()
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_parseTime(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		field   string
+		base    timeBase
+		want    int64
+		wantErr bool
+	}{
+		{name: "whole", field: "7", want: 7},
+		{name: "whole at resolution", field: "7", base: timeBase{resolution: 10}, want: 70},
+		{name: "fraction", field: "2.5", base: timeBase{resolution: 10}, want: 25},
+		{name: "fraction rounds", field: "0.125", base: timeBase{resolution: 10}, want: 1},
+		{name: "whole float", field: "3.0", want: 3},
+		{name: "fraction without resolution", field: "2.5", wantErr: true},
+		{name: "not a number", field: "soon", base: timeBase{resolution: 10}, wantErr: true},
+		{name: "overflow", field: "9223372036854775807", base: timeBase{resolution: 10}, wantErr: true},
+		{name: "duration", field: "2s", base: timeBase{unit: time.Millisecond}, want: 2000},
+		{name: "duration in unit", field: "150ms", base: timeBase{unit: time.Millisecond}, want: 150},
+		{name: "number in unit", field: "150", base: timeBase{unit: time.Millisecond}, want: 150},
+		{name: "fractional duration", field: "1500us", base: timeBase{resolution: 10, unit: time.Millisecond}, want: 15},
+		{name: "fractional duration without resolution", field: "1500us", base: timeBase{unit: time.Millisecond}, wantErr: true},
+		{name: "duration without unit", field: "150ms", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseTime(tt.field, tt.base)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseTime() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_formatTicks(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		ticks int64
+		base  timeBase
+		want  string
+	}{
+		{name: "whole", ticks: 12, want: "12"},
+		{name: "tenths", ticks: 25, base: timeBase{resolution: 10}, want: "2.5"},
+		{name: "whole at resolution", ticks: 30, base: timeBase{resolution: 10}, want: "3"},
+		{name: "hundredths", ticks: 105, base: timeBase{resolution: 100}, want: "1.05"},
+		{name: "quarters", ticks: 5, base: timeBase{resolution: 4}, want: "1.2"},
+		{name: "duration", ticks: 150, base: timeBase{unit: time.Millisecond}, want: "150ms"},
+		{name: "seconds", ticks: 2500, base: timeBase{unit: time.Millisecond}, want: "2.5s"},
+		{name: "fractional duration", ticks: 15, base: timeBase{resolution: 10, unit: time.Millisecond}, want: "1.5ms"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := formatTicks(tt.ticks, tt.base); got != tt.want {
+				t.Errorf("formatTicks() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_timeBase_formatUnits(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		base timeBase
+		v    float64
+		want string
+	}{
+		{name: "abstract", v: 4.3333, want: "4.33"},
+		{name: "duration", base: timeBase{unit: time.Millisecond}, v: 4.3333, want: "4.33ms"},
+		{name: "seconds", base: timeBase{unit: time.Second}, v: 90, want: "1m30s"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.base.formatUnits(tt.v); got != tt.want {
+				t.Errorf("formatUnits() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_loadProcessesAt(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		workload     string
+		opts         Options
+		want         []Process
+		wantWait     string
+		wantMakespan string
+	}{
+		{
+			name:     "fractional",
+			workload: "1,2.5,0,1\n2,1,0.5,1\n",
+			opts:     Options{Resolution: 10},
+			want: []Process{
+				{ProcessID: 1, BurstDuration: 25, ArrivalTime: 0, Priority: 1},
+				{ProcessID: 2, BurstDuration: 10, ArrivalTime: 5, Priority: 1},
+			},
+			wantWait:     "1.00",
+			wantMakespan: "3.5",
+		},
+		{
+			name:     "durations",
+			workload: "1,2s,0,1\n2,150ms,500ms,1\n",
+			opts:     Options{Unit: time.Millisecond},
+			want: []Process{
+				{ProcessID: 1, BurstDuration: 2000, ArrivalTime: 0, Priority: 1},
+				{ProcessID: 2, BurstDuration: 150, ArrivalTime: 500, Priority: 1},
+			},
+			wantWait:     "750ms",
+			wantMakespan: "2.15s",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			processes, err := loadProcessesAt(strings.NewReader(tt.workload), tt.opts.timeBase())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(processes, tt.want) {
+				t.Fatalf("loadProcessesAt() = %+v, want %+v", processes, tt.want)
+			}
+			s := algorithms[0].schedule(processes, tt.opts)
+			if got := s.formatUnits(s.AverageWait()); got != tt.wantWait {
+				t.Errorf("AverageWait() = %s, want %s", got, tt.wantWait)
+			}
+			if got := s.formatTime(s.Makespan()); got != tt.wantMakespan {
+				t.Errorf("Makespan() = %s, want %s", got, tt.wantMakespan)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// chromeTickMicros is how many trace microseconds one scheduler time unit lasts, so the viewer shows
+// one unit as one millisecond.
+const chromeTickMicros = 1000
+
+// chromeEvent is one entry of the Chrome trace_event format.
+// See https://docs.google.com/document/d/1CvAClvFfyA5R-PhYUmn5OOQtYMH4h6I0nSsKchNAySU for the spec.
+type chromeEvent struct {
+	Name  string         `json:"name"`
+	Phase string         `json:"ph"`
+	Time  float64        `json:"ts"`
+	Dur   float64        `json:"dur,omitempty"`
+	PID   int            `json:"pid"`
+	TID   int64          `json:"tid"`
+	Scope string         `json:"s,omitempty"`
+	Args  map[string]any `json:"args,omitempty"`
+}
+
+// outputChromeTrace outputs the results as Chrome trace_event JSON that chrome://tracing and Perfetto
+// open as zoomable timelines: every result is a trace process and every scheduled process a thread
+// lane within it, with its time slices and arrival. Idle time gets lane 0.
+func outputChromeTrace(w io.Writer, results []result, _ Report) error {
+	events := make([]chromeEvent, 0)
+	meta := func(name string, pid int, tid int64, value string) {
+		events = append(events, chromeEvent{Name: name, Phase: "M", PID: pid, TID: tid, Args: map[string]any{"name": value}})
+	}
+	for i, res := range results {
+		pid := i + 1
+		s := res.schedule
+		micros := func(t int64) float64 { return s.inUnits(float64(t * chromeTickMicros)) }
+		meta("process_name", pid, 0, res.title)
+		meta("thread_name", pid, 0, "IDLE")
+		lanes := make(map[int64]int64, len(s.Processes))
+		for j, p := range s.Processes {
+			lanes[p.ProcessID] = int64(j + 1)
+			meta("thread_name", pid, int64(j+1), fmt.Sprintf("P%d", p.ProcessID))
+			events = append(events, chromeEvent{
+				Name:  "arrival",
+				Phase: "i",
+				Time:  micros(p.ArrivalTime),
+				PID:   pid,
+				TID:   int64(j + 1),
+				Scope: "t",
+				Args:  map[string]any{"burst": s.inUnits(float64(p.BurstDuration)), "priority": p.Priority},
+			})
+		}
+		for _, ts := range s.timeline() {
+			name, tid := fmt.Sprintf("P%d", ts.PID), lanes[ts.PID]
+			if ts.PID == idlePID || ts.PID == switchPID {
+				name, tid = sliceLabel(ts.PID), 0
+			}
+			events = append(events, chromeEvent{
+				Name:  name,
+				Phase: "X",
+				Time:  micros(ts.Start),
+				Dur:   micros(ts.Stop - ts.Start),
+				PID:   pid,
+				TID:   tid,
+				Args:  map[string]any{"start": s.inUnits(float64(ts.Start)), "stop": s.inUnits(float64(ts.Stop))},
+			})
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(struct {
+		TraceEvents     []chromeEvent `json:"traceEvents"`
+		DisplayTimeUnit string        `json:"displayTimeUnit"`
+	}{events, "ms"})
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func Test_outputChromeTrace(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 5, ArrivalTime: 0, BurstDuration: 2, Priority: 2},
+		{ProcessID: 7, ArrivalTime: 4, BurstDuration: 1, Priority: 1},
+	}
+	results := []result{{title: "Shortest-job-first", schedule: sjf(processes, Options{})}}
+
+	var w bytes.Buffer
+	if err := outputChromeTrace(&w, results, Report{}); err != nil {
+		t.Fatal(err)
+	}
+	var trace struct {
+		TraceEvents []chromeEvent `json:"traceEvents"`
+	}
+	if err := json.Unmarshal(w.Bytes(), &trace); err != nil {
+		t.Fatal(err)
+	}
+	var got []chromeEvent
+	for _, e := range trace.TraceEvents {
+		if e.Phase == "X" {
+			e.Args = nil
+			got = append(got, e)
+		}
+	}
+	want := []chromeEvent{
+		{Name: "P5", Phase: "X", Time: 0, Dur: 2000, PID: 1, TID: 1},
+		{Name: "IDLE", Phase: "X", Time: 2000, Dur: 2000, PID: 1, TID: 0},
+		{Name: "P7", Phase: "X", Time: 4000, Dur: 1000, PID: 1, TID: 2},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("complete events = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strings"
+)
+
+// expectedResult is a result of an expected-results file, in the form of the /simulate response.
+// Fields left out of the file are not checked: a nil GANTT chart, and summary and process timing
+// keys it does not give.
+type expectedResult struct {
+	Algorithm string           `json:"algorithm"`
+	Summary   map[string]any   `json:"summary"`
+	Gantt     []apiSlice       `json:"gantt"`
+	Processes []map[string]any `json:"processes"`
+}
+
+// loadExpected reads an expected-results file.
+func loadExpected(path string) ([]expectedResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%v: error opening expected results", err)
+	}
+	var expected struct {
+		Results []expectedResult `json:"results"`
+	}
+	if err := json.Unmarshal(data, &expected); err != nil {
+		return nil, fmt.Errorf("%w: expected results %s: %v", ErrInvalidArgs, path, err)
+	}
+	if len(expected.Results) == 0 {
+		return nil, fmt.Errorf("%w: expected results %s hold no results", ErrInvalidArgs, path)
+	}
+
+	return expected.Results, nil
+}
+
+// asFields converts v to the JSON object it encodes to.
+func asFields(v any) map[string]any {
+	data, _ := json.Marshal(v)
+	fields := make(map[string]any)
+	_ = json.Unmarshal(data, &fields)
+
+	return fields
+}
+
+// diffFields returns a line per key of want whose value in got differs, numbers by more than the
+// tolerance, in key order.
+func diffFields(prefix string, got, want map[string]any, tolerance float64) []string {
+	keys := make([]string, 0, len(want))
+	for k := range want {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	diffs := make([]string, 0)
+	for _, k := range keys {
+		g, ok := got[k]
+		if !ok {
+			diffs = append(diffs, fmt.Sprintf("%s%s: unknown field", prefix, k))
+			continue
+		}
+		gn, gok := g.(float64)
+		wn, wok := want[k].(float64)
+		if gok && wok && math.Abs(gn-wn) <= tolerance || fmt.Sprint(g) == fmt.Sprint(want[k]) {
+			continue
+		}
+		diffs = append(diffs, fmt.Sprintf("%s%s: got %v, want %v", prefix, k, g, want[k]))
+	}
+
+	return diffs
+}
+
+// diffResult returns a line per difference between the produced result and the expected one.
+func diffResult(got apiResult, want expectedResult, tolerance float64) []string {
+	prefix := want.Algorithm + ": "
+	diffs := diffFields(prefix+"summary ", asFields(got.Summary), want.Summary, tolerance)
+
+	if want.Gantt != nil {
+		n := len(got.Gantt)
+		if len(want.Gantt) > n {
+			n = len(want.Gantt)
+		}
+		slice := func(slices []apiSlice, i int) string {
+			if i >= len(slices) {
+				return "none"
+			}
+			return fmt.Sprintf("P%d %d-%d", slices[i].PID, slices[i].Start, slices[i].Stop)
+		}
+		for i := 0; i < n; i++ {
+			if g, w := slice(got.Gantt, i), slice(want.Gantt, i); g != w {
+				diffs = append(diffs, fmt.Sprintf("%sgantt[%d]: got %s, want %s", prefix, i, g, w))
+			}
+		}
+	}
+
+	timing := make(map[int64]map[string]any, len(got.Processes))
+	for _, p := range got.Processes {
+		timing[p.ID] = asFields(p)
+	}
+	for _, want := range want.Processes {
+		id, _ := want["id"].(float64)
+		got, ok := timing[int64(id)]
+		if !ok {
+			diffs = append(diffs, fmt.Sprintf("%sprocess %v: not in the workload", prefix, want["id"]))
+			continue
+		}
+		diffs = append(diffs, diffFields(fmt.Sprintf("%sprocess %d ", prefix, int64(id)), got, want, tolerance)...)
+	}
+
+	return diffs
+}
+
+// verify schedules the processes with the algorithm of each expected result and returns a line per
+// difference from it.
+func verify(processes []Process, opts Options, expected []expectedResult, tolerance float64) ([]string, error) {
+	diffs := make([]string, 0)
+	for _, want := range expected {
+		selected, err := selectAlgorithms(want.Algorithm)
+		if err != nil {
+			return nil, err
+		}
+		if len(selected) != 1 {
+			return nil, fmt.Errorf("%w: expected result of %q must name one algorithm", ErrInvalidArgs, want.Algorithm)
+		}
+		res := scheduleAll(processes, opts, selected)[0]
+		diffs = append(diffs, diffResult(newAPIResult(selected[0].name, res), want, tolerance)...)
+	}
+
+	return diffs, nil
+}
+
+// verifyCommand checks the schedules of a workload file against an expected-results file, listing
+// every difference, or with -update writes the expected results of the selected algorithms.
+func verifyCommand(w io.Writer, args []string) error {
+	fs := newFlagSet("verify")
+	tolerance := fs.Float64("tolerance", 1e-6, "largest difference of numbers still counted as equal, e.g. 0.01 for rounded expectations")
+	update := fs.Bool("update", false, "write the expected results of -algorithms instead of checking them")
+	names := fs.String("algorithms", "all", "comma separated algorithms to write with -update")
+	options := addOptionFlags(fs)
+	logging := addLogFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := logging(); err != nil {
+		return err
+	}
+	opts, err := options()
+	if err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		return fmt.Errorf("%w: must give a scheduling file and an expected-results file", ErrInvalidArgs)
+	}
+	processes, err := loadWorkload(fs.Arg(0), opts.timeBase())
+	if err != nil {
+		return err
+	}
+	if err := validateJobs(processes, opts); err != nil {
+		return err
+	}
+
+	if *update {
+		selected, err := selectAlgorithms(*names)
+		if err != nil {
+			return err
+		}
+		data, err := json.MarshalIndent(newSimulateResponse(selected, scheduleAll(processes, opts, selected)), "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(fs.Arg(1), append(data, '\n'), 0o644); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(w, "Wrote the expected results of %d algorithms to %s\n", len(selected), fs.Arg(1))
+		return nil
+	}
+
+	expected, err := loadExpected(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+	diffs, err := verify(processes, opts, expected, *tolerance)
+	if err != nil {
+		return err
+	}
+	if len(diffs) > 0 {
+		_, _ = fmt.Fprintln(w, strings.Join(diffs, "\n"))
+		return fmt.Errorf("%w: %d differences from %s", ErrMismatch, len(diffs), fs.Arg(1))
+	}
+	checked := make([]string, len(expected))
+	for i, want := range expected {
+		checked[i] = want.Algorithm
+	}
+	_, _ = fmt.Fprintf(w, "OK: %s match %s\n", strings.Join(checked, ", "), fs.Arg(1))
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_verifyCommand(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	workload := filepath.Join(dir, "workload.csv")
+	if err := os.WriteFile(workload, []byte("1,5,0,2\n2,9,3,1\n3,6,6,3\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	golden := filepath.Join(dir, "golden.json")
+	var w bytes.Buffer
+	if err := verifyCommand(&w, []string{"-update", "-algorithms", "fcfs,rr", workload, golden}); err != nil {
+		t.Fatalf("verifyCommand(-update) = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    []string
+		wantErr error
+	}{
+		{name: "golden", args: []string{workload, golden}, want: []string{"OK: fcfs, rr match"}},
+		{
+			name:    "partial",
+			args:    []string{workload, write("partial.json", `{"results": [{"algorithm": "fcfs", "summary": {"average_wait": 3.33}, "processes": [{"id": 3, "wait": 8}]}]}`)},
+			want:    []string{"summary average_wait: got 3.3333333333333335, want 3.33"},
+			wantErr: ErrMismatch,
+		},
+		{
+			name: "tolerance",
+			args: []string{"-tolerance", "0.01", workload, write("rounded.json", `{"results": [{"algorithm": "fcfs", "summary": {"average_wait": 3.33}}]}`)},
+			want: []string{"OK: fcfs match"},
+		},
+		{
+			name:    "gantt",
+			args:    []string{workload, write("gantt.json", `{"results": [{"algorithm": "fcfs", "gantt": [{"pid": 1, "start": 0, "stop": 5}, {"pid": 3, "start": 5, "stop": 11}]}]}`)},
+			want:    []string{"fcfs: gantt[1]: got P2 5-14, want P3 5-11", "fcfs: gantt[2]: got P3 14-20, want none"},
+			wantErr: ErrMismatch,
+		},
+		{
+			name:    "unknown process",
+			args:    []string{workload, write("unknown.json", `{"results": [{"algorithm": "sjf", "processes": [{"id": 9, "wait": 0}]}]}`)},
+			want:    []string{"sjf: process 9: not in the workload"},
+			wantErr: ErrMismatch,
+		},
+		{name: "unknown algorithm", args: []string{workload, write("bad.json", `{"results": [{"algorithm": "lottery"}]}`)}, wantErr: ErrInvalidArgs},
+		{name: "no results", args: []string{workload, write("empty.json", `{"results": []}`)}, wantErr: ErrInvalidArgs},
+		{name: "one file", args: []string{workload}, wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			if err := verifyCommand(&w, tt.args); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("verifyCommand() error = %v, want %v", err, tt.wantErr)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(w.String(), want) {
+					t.Errorf("verifyCommand() = %q, want it to contain %q", w.String(), want)
+				}
+			}
+		})
+	}
+}
+
+func Test_diffFields(t *testing.T) {
+	t.Parallel()
+	got := map[string]any{"wait": 2.0, "algorithm": "fcfs"}
+	want := map[string]any{"wait": 2.004, "algorithm": "rr", "energy": 1.0}
+	diffs := diffFields("p ", got, want, 0.01)
+	wantDiffs := []string{"p algorithm: got fcfs, want rr", "p energy: unknown field"}
+	if !reflect.DeepEqual(diffs, wantDiffs) {
+		t.Errorf("diffFields() = %q, want %q", diffs, wantDiffs)
+	}
+}
@@ -0,0 +1,48 @@
+//go:build js && wasm
+
+package main
+
+import "syscall/js"
+
+func init() {
+	platformMain = jsMain
+}
+
+// jsMain exposes the simulator to JavaScript as the global "scheduler" object and keeps the program
+// running so its functions stay callable:
+// • scheduler.simulate(request) takes a POST /simulate request, as an object or JSON, and returns the
+// response object, or {error} if the request is invalid
+// • scheduler.report(csv, format, algorithms) returns {report} holding the report of a workload CSV
+// in an output format for a comma separated list of algorithms, or {error}
+func jsMain() {
+	js.Global().Set("scheduler", js.ValueOf(map[string]any{
+		"simulate": js.FuncOf(jsSimulate),
+		"report":   js.FuncOf(jsReport),
+	}))
+	select {}
+}
+
+func jsSimulate(_ js.Value, args []js.Value) any {
+	if len(args) != 1 {
+		return map[string]any{"error": "simulate takes one request"}
+	}
+	jsonAPI := js.Global().Get("JSON")
+	request := args[0]
+	if request.Type() != js.TypeString {
+		request = jsonAPI.Call("stringify", request)
+	}
+
+	return jsonAPI.Call("parse", string(simulateJSON([]byte(request.String()))))
+}
+
+func jsReport(_ js.Value, args []js.Value) any {
+	if len(args) != 3 {
+		return map[string]any{"error": "report takes a workload CSV, a format and the algorithms"}
+	}
+	report, err := renderReport(args[0].String(), args[1].String(), args[2].String())
+	if err != nil {
+		return map[string]any{"error": err.Error()}
+	}
+
+	return map[string]any{"report": report}
+}
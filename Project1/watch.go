@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+)
+
+// watchInterval is how often a watched workload file is checked for changes.
+const watchInterval = 500 * time.Millisecond
+
+// watchFile calls run, then again every time the file at path changes, until ctx is done. Changes
+// are noticed by polling its size and modification time every interval. clear clears the terminal
+// before each run so the output refreshes in place. Errors of run are logged rather than ending the
+// watch, since the file is likely in the middle of being edited.
+func watchFile(ctx context.Context, path string, interval time.Duration, w io.Writer, clear bool, run func() error) error {
+	last, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	for changed := true; ; {
+		if changed {
+			if clear {
+				_, _ = io.WriteString(w, "\x1b[H\x1b[2J")
+			}
+			if err := run(); err != nil {
+				logs.Error(err.Error())
+			}
+			logs.Info("watching for changes", "file", path)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+		info, err := os.Stat(path)
+		changed = err == nil && (info.Size() != last.Size() || !info.ModTime().Equal(last.ModTime()))
+		if changed {
+			last = info
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func Test_watchFile(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "workload.csv")
+	if err := os.WriteFile(path, []byte("1,5,0,2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	runs := make(chan int, 10)
+	n := 0
+	run := func() error {
+		n++
+		runs <- n
+		if n == 2 {
+			cancel()
+		}
+		return nil
+	}
+	done := make(chan error)
+	go func() { done <- watchFile(ctx, path, time.Millisecond, io.Discard, false, run) }()
+
+	<-runs
+	if err := os.WriteFile(path, []byte("1,5,0,2\n2,9,3,1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("watchFile() = %v", err)
+	}
+	if n != 2 {
+		t.Errorf("runs = %d, want 2 (one for the change)", n)
+	}
+	if ctx.Err() != context.Canceled {
+		t.Errorf("watch ended by %v, want the change's run", ctx.Err())
+	}
+}
+
+func Test_watchFile_missing(t *testing.T) {
+	t.Parallel()
+	run := func() error {
+		t.Error("run called for a missing file")
+		return nil
+	}
+	err := watchFile(context.Background(), filepath.Join(t.TempDir(), "nope.csv"), time.Millisecond, io.Discard, false, run)
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("watchFile() = %v, want %v", err, os.ErrNotExist)
+	}
+}